@@ -0,0 +1,149 @@
+// Code generated by rel gen accessor. DO NOT EDIT.
+
+package rel_test
+
+import (
+	"time"
+
+	"github.com/Fs02/rel"
+)
+
+// PrimaryFields of AccessorUser.
+func (a AccessorUser) PrimaryFields() []string {
+	return []string{"id"}
+}
+
+// PrimaryValues of AccessorUser.
+func (a AccessorUser) PrimaryValues() []interface{} {
+	return []interface{}{a.ID}
+}
+
+// FieldScanner of AccessorUser.
+func (a *AccessorUser) FieldScanner(field string) (interface{}, bool) {
+	switch field {
+	case "id":
+		return rel.Nullable(&a.ID), true
+	case "name":
+		return rel.Nullable(&a.Name), true
+	case "age":
+		return rel.Nullable(&a.Age), true
+	case "status":
+		return rel.Nullable(&a.Status), true
+	case "score":
+		return rel.Nullable(&a.Score), true
+	case "active":
+		return rel.Nullable(&a.Active), true
+	case "note":
+		return &a.Note, true
+	case "created_at":
+		return rel.Nullable(&a.CreatedAt), true
+	case "updated_at":
+		return rel.Nullable(&a.UpdatedAt), true
+	case "deleted_at":
+		return &a.DeletedAt, true
+	}
+
+	return nil, false
+}
+
+// FieldValue of AccessorUser.
+func (a *AccessorUser) FieldValue(field string) (interface{}, bool) {
+	switch field {
+	case "id":
+		return a.ID, true
+	case "name":
+		return a.Name, true
+	case "age":
+		return a.Age, true
+	case "status":
+		return a.Status, true
+	case "score":
+		return a.Score, true
+	case "active":
+		return a.Active, true
+	case "note":
+		if a.Note == nil {
+			return nil, true
+		}
+
+		return *a.Note, true
+	case "created_at":
+		return a.CreatedAt, true
+	case "updated_at":
+		return a.UpdatedAt, true
+	case "deleted_at":
+		if a.DeletedAt == nil {
+			return nil, true
+		}
+
+		return *a.DeletedAt, true
+	}
+
+	return nil, false
+}
+
+// SetFieldValue of AccessorUser.
+func (a *AccessorUser) SetFieldValue(field string, value interface{}) bool {
+	switch field {
+	case "id":
+		if val, ok := value.(int); ok {
+			a.ID = val
+			return true
+		}
+	case "name":
+		if val, ok := value.(string); ok {
+			a.Name = val
+			return true
+		}
+	case "age":
+		if val, ok := value.(int); ok {
+			a.Age = val
+			return true
+		}
+	case "status":
+		if val, ok := value.(Status); ok {
+			a.Status = val
+			return true
+		}
+	case "score":
+		if val, ok := value.(float64); ok {
+			a.Score = val
+			return true
+		}
+	case "active":
+		if val, ok := value.(bool); ok {
+			a.Active = val
+			return true
+		}
+	case "note":
+		if val, ok := value.(string); ok {
+			if a.Note == nil {
+				a.Note = new(string)
+			}
+
+			*a.Note = val
+			return true
+		}
+	case "created_at":
+		if val, ok := value.(time.Time); ok {
+			a.CreatedAt = val
+			return true
+		}
+	case "updated_at":
+		if val, ok := value.(time.Time); ok {
+			a.UpdatedAt = val
+			return true
+		}
+	case "deleted_at":
+		if val, ok := value.(time.Time); ok {
+			if a.DeletedAt == nil {
+				a.DeletedAt = new(time.Time)
+			}
+
+			*a.DeletedAt = val
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,180 @@
+package rel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fs02/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:generate go run ./cmd/rel gen accessor -tests -type=AccessorUser -output=accessor_gen_test.go
+
+type Status string
+
+// ReflectUser uses reflection to access its fields.
+type ReflectUser struct {
+	ID        int
+	Name      string
+	Age       int
+	Status    Status
+	Score     float64
+	Active    bool
+	Note      *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// AccessorUser has the same fields as ReflectUser, with generated accessor.
+type AccessorUser struct {
+	ID        int
+	Name      string
+	Age       int
+	Status    Status
+	Score     float64
+	Active    bool
+	Note      *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+var accessorFields = []string{"id", "name", "age", "status", "score", "active", "note", "created_at", "updated_at", "deleted_at"}
+
+func TestAccessor(t *testing.T) {
+	var (
+		note    = "note"
+		now     = time.Now()
+		user    AccessorUser
+		doc     = rel.NewDocument(&user)
+		reflect ReflectUser
+		rdoc    = rel.NewDocument(&reflect)
+		values  = []interface{}{1, "name", 20, Status("active"), 9.5, true, note, now, now, now}
+	)
+
+	for i, field := range accessorFields {
+		assert.True(t, doc.SetValue(field, values[i]))
+		assert.True(t, rdoc.SetValue(field, values[i]))
+
+		value, ok := doc.Value(field)
+		assert.True(t, ok)
+		assert.Equal(t, values[i], value)
+	}
+
+	assert.Equal(t, 1, doc.PrimaryValue())
+	assert.Equal(t, rdoc.PrimaryFields(), doc.PrimaryFields())
+	assert.Equal(t, len(rdoc.Scanners(accessorFields)), len(doc.Scanners(accessorFields)))
+
+	// falls back to reflection.
+	assert.True(t, doc.SetValue("id", int64(2)))
+	assert.Equal(t, 2, user.ID)
+	assert.True(t, doc.SetValue("note", nil))
+	assert.Nil(t, user.Note)
+	assert.Equal(t, "name", user.Name)
+	assert.False(t, doc.SetValue("unknown", 1))
+
+	_, ok := doc.Value("unknown")
+	assert.False(t, ok)
+}
+
+func BenchmarkDocument_Scanners(b *testing.B) {
+	b.Run("Reflection", func(b *testing.B) {
+		var user ReflectUser
+		doc := rel.NewDocument(&user)
+
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			doc.Scanners(accessorFields)
+		}
+	})
+
+	b.Run("Accessor", func(b *testing.B) {
+		var user AccessorUser
+		doc := rel.NewDocument(&user)
+
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			doc.Scanners(accessorFields)
+		}
+	})
+}
+
+func BenchmarkDocument_Value(b *testing.B) {
+	b.Run("Reflection", func(b *testing.B) {
+		doc := rel.NewDocument(&ReflectUser{ID: 1, Name: "name"})
+
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, field := range accessorFields {
+				doc.Value(field)
+			}
+		}
+	})
+
+	b.Run("Accessor", func(b *testing.B) {
+		doc := rel.NewDocument(&AccessorUser{ID: 1, Name: "name"})
+
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, field := range accessorFields {
+				doc.Value(field)
+			}
+		}
+	})
+}
+
+func BenchmarkDocument_SetValue(b *testing.B) {
+	var (
+		now    = time.Now()
+		values = []interface{}{1, "name", 20, Status("active"), 9.5, true, "note", now, now, now}
+	)
+
+	b.Run("Reflection", func(b *testing.B) {
+		doc := rel.NewDocument(&ReflectUser{})
+
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for i, field := range accessorFields {
+				doc.SetValue(field, values[i])
+			}
+		}
+	})
+
+	b.Run("Accessor", func(b *testing.B) {
+		doc := rel.NewDocument(&AccessorUser{})
+
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for i, field := range accessorFields {
+				doc.SetValue(field, values[i])
+			}
+		}
+	})
+}
+
+func BenchmarkCollection_FindAllScan(b *testing.B) {
+	const size = 1000
+
+	b.Run("Reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var users []ReflectUser
+			col := rel.NewCollection(&users)
+			for i := 0; i < size; i++ {
+				col.Add().Scanners(accessorFields)
+			}
+		}
+	})
+
+	b.Run("Accessor", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var users []AccessorUser
+			col := rel.NewCollection(&users)
+			for i := 0; i < size; i++ {
+				col.Add().Scanners(accessorFields)
+			}
+		}
+	})
+}
@@ -10,8 +10,8 @@ type Adapter interface {
 	Ping(ctx context.Context) error
 	Aggregate(ctx context.Context, query Query, mode string, field string) (int, error)
 	Query(ctx context.Context, query Query) (Cursor, error)
-	Insert(ctx context.Context, query Query, primaryField string, mutates map[string]Mutate) (interface{}, error)
-	InsertAll(ctx context.Context, query Query, primaryField string, fields []string, bulkMutates []map[string]Mutate) ([]interface{}, error)
+	Insert(ctx context.Context, query Query, primaryField string, mutates map[string]Mutate, onConflict OnConflict) (interface{}, error)
+	InsertAll(ctx context.Context, query Query, primaryField string, fields []string, bulkMutates []map[string]Mutate, onConflict OnConflict) ([]interface{}, error)
 	Update(ctx context.Context, query Query, mutates map[string]Mutate) (int, error)
 	Delete(ctx context.Context, query Query) (int, error)
 
@@ -21,3 +21,12 @@ type Adapter interface {
 
 	Apply(ctx context.Context, migration Migration) error
 }
+
+// Introspector is an optional interface implemented by adapter that is able to read the schema of the database.
+type Introspector interface {
+	// Tables returns definition of every tables, including its columns, primary, unique and foreign keys.
+	// Single auto increment primary key is reported as column with ID type.
+	Tables(ctx context.Context) ([]Table, error)
+	// Indexes returns indexes of the table, excluding the ones that backs primary, unique and foreign keys.
+	Indexes(ctx context.Context, table string) ([]Index, error)
+}
@@ -0,0 +1,143 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/adapter/sql"
+)
+
+var _ rel.Introspector = (*Adapter)(nil)
+
+// Tables returns definition of every tables in the current database.
+func (a *Adapter) Tables(ctx context.Context) ([]rel.Table, error) {
+	rows, err := a.QueryStrings(ctx, "SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME;", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]rel.Table, len(rows))
+	for i := range rows {
+		if tables[i], err = a.table(ctx, rows[i][0].String); err != nil {
+			return nil, err
+		}
+	}
+
+	return tables, nil
+}
+
+func (a *Adapter) table(ctx context.Context, name string) (rel.Table, error) {
+	rows, err := a.QueryStrings(ctx, "SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, COLUMN_KEY, EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION;", []interface{}{name})
+	if err != nil {
+		return rel.Table{}, err
+	}
+
+	columns := make([]rel.Column, len(rows))
+	for i, row := range rows {
+		var (
+			typ   = strings.ToLower(row[1].String)
+			extra = strings.ToLower(row[5].String)
+		)
+
+		// mysql 8.0.19+ omits display width, bool is the only type mapped to tinyint.
+		if typ == "tinyint" {
+			typ = "tinyint(1)"
+		}
+
+		columns[i] = sql.IntrospectColumn(row[0].String, typ, row[2].String == "NO", row[3])
+
+		if row[4].String == "PRI" && strings.Contains(extra, "auto_increment") {
+			columns[i].Type = rel.ID
+		}
+
+		columns[i].Default = introspectDefault(columns[i].Default, extra)
+	}
+
+	keyColumns, err := a.keyColumns(ctx, name)
+	if err != nil {
+		return rel.Table{}, err
+	}
+
+	return sql.IntrospectTable(name, columns, sql.IntrospectKeys(keyColumns)), nil
+}
+
+// introspectDefault keeps function default as raw expression.
+// mysql returns string literal without quotes, expression default is marked as DEFAULT_GENERATED on mysql 8.
+func introspectDefault(value interface{}, extra string) interface{} {
+	raw, ok := value.(rel.Raw)
+	if !ok {
+		return value
+	}
+
+	switch expr := strings.ToUpper(string(raw)); {
+	case expr == "CURRENT_TIMESTAMP" || strings.HasPrefix(expr, "CURRENT_TIMESTAMP("):
+		return raw
+	case strings.Contains(extra, "default_generated"):
+		return rel.Raw("(" + string(raw) + ")")
+	case strings.HasSuffix(expr, ")"):
+		// mariadb returns quoted literal, thus unquoted value is an expression.
+		return raw
+	default:
+		return string(raw)
+	}
+}
+
+func (a *Adapter) keyColumns(ctx context.Context, table string) ([]sql.KeyColumn, error) {
+	rows, err := a.QueryStrings(ctx, `SELECT tc.CONSTRAINT_NAME, tc.CONSTRAINT_TYPE, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.UPDATE_RULE, rc.DELETE_RULE
+FROM information_schema.TABLE_CONSTRAINTS tc
+JOIN information_schema.KEY_COLUMN_USAGE kcu ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.TABLE_NAME = tc.TABLE_NAME AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+LEFT JOIN information_schema.REFERENTIAL_CONSTRAINTS rc ON rc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND rc.TABLE_NAME = tc.TABLE_NAME AND rc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+WHERE tc.TABLE_SCHEMA = DATABASE() AND tc.TABLE_NAME = ? AND tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+ORDER BY FIELD(tc.CONSTRAINT_TYPE, 'PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY'), tc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION;`, []interface{}{table})
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]sql.KeyColumn, len(rows))
+	for i, row := range rows {
+		columns[i] = sql.KeyColumn{
+			Name:      row[0].String,
+			Type:      rel.KeyType(row[1].String),
+			Column:    row[2].String,
+			RefTable:  row[3].String,
+			RefColumn: row[4].String,
+			OnUpdate:  row[5].String,
+			OnDelete:  row[6].String,
+		}
+	}
+
+	return columns, nil
+}
+
+// Indexes returns indexes of the table, excluding indexes of primary, unique and foreign keys.
+func (a *Adapter) Indexes(ctx context.Context, table string) ([]rel.Index, error) {
+	keyColumns, err := a.keyColumns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.QueryStrings(ctx, "SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY INDEX_NAME, SEQ_IN_INDEX;", []interface{}{table})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		constraints  = make(map[string]bool, len(keyColumns))
+		indexColumns = make([]sql.IndexColumn, 0, len(rows))
+	)
+
+	for _, kc := range keyColumns {
+		constraints[kc.Name] = true
+	}
+
+	for _, row := range rows {
+		if row[0].String == "PRIMARY" || constraints[row[0].String] {
+			continue
+		}
+
+		indexColumns = append(indexColumns, sql.IndexColumn{Name: row[0].String, Unique: row[1].String == "0", Column: row[2].String})
+	}
+
+	return sql.IntrospectIndexes(table, indexColumns), nil
+}
@@ -29,8 +29,11 @@ var (
 	_ rel.Adapter = (*Adapter)(nil)
 
 	// Config for mysql adapter.
+	// WindowFunction is disabled to support mysql 5, which reads and discards the excess rows of LimitPerParent preload.
+	// Set it to true before creating the adapter when using mysql 8 or later.
 	Config = sql.Config{
 		DropIndexOnTable: true,
+		OnDuplicateKey:   true,
 		Placeholder:      "?",
 		EscapeChar:       "`",
 		IncrementFunc:    incrementFunc,
@@ -38,10 +38,16 @@ func TestAdapter_specs(t *testing.T) {
 	// Migration Specs
 	// - Rename column is only supported by MySQL 8.0
 	specs.Migrate(t, repo, specs.SkipRenameColumn)
+	specs.Introspect(t, repo)
 
 	// Query Specs
 	specs.Query(t, repo)
 	specs.QueryJoin(t, repo)
+	specs.QuerySubQuery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QuerySelectExpr(t, repo)
+	specs.QueryPaginate(t, repo)
+	specs.QueryIterate(t, repo)
 	specs.QueryNotFound(t, repo)
 
 	// Preload specs
@@ -54,22 +60,31 @@ func TestAdapter_specs(t *testing.T) {
 	specs.PreloadBelongsTo(t, repo)
 	specs.PreloadBelongsToWithQuery(t, repo)
 	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadManyToMany(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadJoin(t, repo)
+	specs.PreloadLimitPerParent(t, repo)
 
 	// Aggregate Specs
 	specs.Aggregate(t, repo)
+	specs.AggregateFloat(t, repo)
+	specs.AggregateGroup(t, repo)
 
 	// Insert Specs
 	specs.Insert(t, repo)
 	specs.InsertHasMany(t, repo)
 	specs.InsertHasOne(t, repo)
 	specs.InsertBelongsTo(t, repo)
+	specs.InsertManyToMany(t, repo)
 	specs.Inserts(t, repo)
 	specs.InsertAll(t, repo)
 	specs.InsertAllPartialCustomPrimary(t, repo)
+	specs.Upsert(t, repo)
 
 	// Update Specs
 	specs.Update(t, repo)
 	specs.UpdateNotFound(t, repo)
+	specs.UpdateStaleRecord(t, repo)
 	specs.UpdateHasManyInsert(t, repo)
 	specs.UpdateHasManyUpdate(t, repo)
 	specs.UpdateHasManyReplace(t, repo)
@@ -77,6 +92,7 @@ func TestAdapter_specs(t *testing.T) {
 	specs.UpdateHasOneUpdate(t, repo)
 	specs.UpdateBelongsToInsert(t, repo)
 	specs.UpdateBelongsToUpdate(t, repo)
+	specs.UpdateManyToMany(t, repo)
 	specs.UpdateAtomic(t, repo)
 	specs.Updates(t, repo)
 	specs.UpdateAll(t, repo)
@@ -86,6 +102,8 @@ func TestAdapter_specs(t *testing.T) {
 	specs.DeleteBelongsTo(t, repo)
 	specs.DeleteHasOne(t, repo)
 	specs.DeleteHasMany(t, repo)
+	specs.DeleteManyToMany(t, repo)
+	specs.DeleteStaleRecord(t, repo)
 	specs.DeleteAll(t, repo)
 
 	// Constraint specs
@@ -138,3 +156,34 @@ func TestCheck(t *testing.T) {
 		check(errors.New("error"))
 	})
 }
+
+func TestIntrospectDefault(t *testing.T) {
+	assert.Equal(t, 0, introspectDefault(0, ""))
+	assert.Equal(t, "draft", introspectDefault(rel.Raw("draft"), ""))
+	assert.Equal(t, rel.Raw("CURRENT_TIMESTAMP"), introspectDefault(rel.Raw("CURRENT_TIMESTAMP"), "default_generated"))
+	assert.Equal(t, rel.Raw("(uuid())"), introspectDefault(rel.Raw("uuid()"), "default_generated"))
+	assert.Equal(t, rel.Raw("current_timestamp()"), introspectDefault(rel.Raw("current_timestamp()"), ""))
+}
+
+func TestAdapter_Upsert_insertedID(t *testing.T) {
+	adapter, err := Open(dsn())
+	assert.Nil(t, err)
+	defer adapter.Close()
+
+	var (
+		repo     = rel.New(adapter)
+		inserted = specs.User{Name: "upsert inserted"}
+		users    = []specs.User{{Name: "upsert all inserted"}}
+	)
+
+	assert.Nil(t, repo.Upsert(ctx, &inserted))
+	assert.NotEqual(t, 0, inserted.ID)
+
+	// updated record keeps its id.
+	updated := specs.User{ID: inserted.ID, Name: "upsert updated"}
+	assert.Nil(t, repo.Upsert(ctx, &updated))
+	assert.Equal(t, inserted.ID, updated.ID)
+
+	assert.Nil(t, repo.InsertAll(ctx, &users, rel.OnConflictReplace()))
+	assert.NotEqual(t, 0, users[0].ID)
+}
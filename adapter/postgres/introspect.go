@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/adapter/sql"
+)
+
+var _ rel.Introspector = (*Adapter)(nil)
+
+// Tables returns definition of every tables in the current schema.
+func (adapter *Adapter) Tables(ctx context.Context) ([]rel.Table, error) {
+	rows, err := adapter.QueryStrings(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name;", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]rel.Table, len(rows))
+	for i := range rows {
+		if tables[i], err = adapter.table(ctx, rows[i][0].String); err != nil {
+			return nil, err
+		}
+	}
+
+	return tables, nil
+}
+
+func (adapter *Adapter) table(ctx context.Context, name string) (rel.Table, error) {
+	rows, err := adapter.QueryStrings(ctx, `SELECT column_name, data_type, is_nullable, column_default, character_maximum_length, numeric_precision, numeric_scale
+FROM information_schema.columns
+WHERE table_schema = current_schema() AND table_name = $1
+ORDER BY ordinal_position;`, []interface{}{name})
+	if err != nil {
+		return rel.Table{}, err
+	}
+
+	var (
+		columns = make([]rel.Column, len(rows))
+		serials = make(map[string]bool)
+	)
+
+	for i, row := range rows {
+		var (
+			typ = row[1].String
+		)
+
+		switch {
+		case row[4].Valid:
+			typ += "(" + row[4].String + ")"
+		case typ == "numeric" && row[5].Valid:
+			typ += "(" + row[5].String + "," + row[6].String + ")"
+		}
+
+		if strings.HasPrefix(row[3].String, "nextval(") {
+			serials[row[0].String] = true
+			row[3].Valid = false
+		}
+
+		columns[i] = sql.IntrospectColumn(row[0].String, typ, row[2].String == "NO", row[3])
+	}
+
+	keyColumns, err := adapter.keyColumns(ctx, name)
+	if err != nil {
+		return rel.Table{}, err
+	}
+
+	keys := sql.IntrospectKeys(keyColumns)
+
+	// serial and bigserial column is mapped to id only when it's a single primary key.
+	for i := range columns {
+		if serials[columns[i].Name] && (columns[i].Type == rel.Int || columns[i].Type == rel.BigInt) && singlePrimaryKey(keys, columns[i].Name) {
+			columns[i].Type = rel.ID
+		}
+	}
+
+	return sql.IntrospectTable(name, columns, keys), nil
+}
+
+func (adapter *Adapter) keyColumns(ctx context.Context, table string) ([]sql.KeyColumn, error) {
+	rows, err := adapter.QueryStrings(ctx, `SELECT tc.constraint_name, tc.constraint_type, kcu.column_name, ref.table_name, ref.column_name, rc.update_rule, rc.delete_rule
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+LEFT JOIN information_schema.referential_constraints rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name
+LEFT JOIN information_schema.key_column_usage ref ON ref.constraint_schema = rc.unique_constraint_schema AND ref.constraint_name = rc.unique_constraint_name AND ref.ordinal_position = kcu.position_in_unique_constraint
+WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+ORDER BY CASE tc.constraint_type WHEN 'PRIMARY KEY' THEN 0 WHEN 'UNIQUE' THEN 1 ELSE 2 END, tc.constraint_name, kcu.ordinal_position;`, []interface{}{table})
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]sql.KeyColumn, len(rows))
+	for i, row := range rows {
+		columns[i] = sql.KeyColumn{
+			Name:      row[0].String,
+			Type:      rel.KeyType(row[1].String),
+			Column:    row[2].String,
+			RefTable:  row[3].String,
+			RefColumn: row[4].String,
+			OnUpdate:  row[5].String,
+			OnDelete:  row[6].String,
+		}
+	}
+
+	return columns, nil
+}
+
+// Indexes returns indexes of the table, excluding indexes of primary and unique keys.
+// Expression of an index is returned as unescaped column.
+func (adapter *Adapter) Indexes(ctx context.Context, table string) ([]rel.Index, error) {
+	rows, err := adapter.QueryStrings(ctx, `SELECT i.relname, ix.indisunique, ix.indkey[k.n - 1] = 0, pg_catalog.pg_get_indexdef(ix.indexrelid, k.n, true)
+FROM pg_catalog.pg_index ix
+JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+CROSS JOIN LATERAL generate_series(1, ix.indnkeyatts) AS k(n)
+WHERE n.nspname = current_schema() AND t.relname = $1 AND NOT ix.indisprimary
+AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_constraint c WHERE c.conindid = ix.indexrelid)
+ORDER BY i.relname, k.n;`, []interface{}{table})
+	if err != nil {
+		return nil, err
+	}
+
+	indexColumns := make([]sql.IndexColumn, len(rows))
+	for i, row := range rows {
+		column := row[3].String
+		if row[2].String == "true" {
+			column = string(sql.UnescapeCharacter) + column
+		}
+
+		indexColumns[i] = sql.IndexColumn{Name: row[0].String, Unique: row[1].String == "true", Column: column}
+	}
+
+	return sql.IntrospectIndexes(table, indexColumns), nil
+}
+
+func singlePrimaryKey(keys []rel.Key, column string) bool {
+	for _, key := range keys {
+		if key.Type == rel.PrimaryKey {
+			return len(key.Columns) == 1 && key.Columns[0] == column
+		}
+	}
+
+	return false
+}
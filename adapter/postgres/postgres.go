@@ -35,6 +35,7 @@ var (
 		EscapeChar:          "\"",
 		Ordinal:             true,
 		InsertDefaultValues: true,
+		WindowFunction:      true,
 		ErrorFunc:           errorFunc,
 		MapColumnFunc:       mapColumnFunc,
 	}
@@ -57,39 +58,60 @@ func Open(dsn string) (*Adapter, error) {
 }
 
 // Insert inserts a record to database and returns its id.
-func (adapter *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (interface{}, error) {
+func (adapter *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (interface{}, error) {
 	var (
 		id              int64
-		statement, args = sql.NewBuilder(adapter.Config).Returning(primaryField).Insert(query.Table, mutates)
+		statement, args = sql.NewBuilder(adapter.Config).Returning(primaryField).Insert(query.Table, mutates, onConflict)
 		rows, err       = adapter.query(ctx, statement, args)
 	)
 
-	if err == nil && rows.Next() {
-		defer rows.Close()
-		rows.Scan(&id)
+	if err != nil {
+		return nil, err
 	}
 
-	return id, err
+	defer rows.Close()
+
+	// conflicting insertion that is ignored doesn't return any row.
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	if err := rows.Scan(&id); err != nil {
+		return nil, err
+	}
+
+	return id, nil
 }
 
 // InsertAll inserts multiple records to database and returns its ids.
-func (adapter *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate) ([]interface{}, error) {
+func (adapter *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]interface{}, error) {
 	var (
 		ids             []interface{}
-		statement, args = sql.NewBuilder(adapter.Config).Returning(primaryField).InsertAll(query.Table, fields, bulkMutates)
+		statement, args = sql.NewBuilder(adapter.Config).Returning(primaryField).InsertAll(query.Table, fields, bulkMutates, onConflict)
 		rows, err       = adapter.query(ctx, statement, args)
 	)
 
-	if err == nil {
-		defer rows.Close()
-		for rows.Next() {
-			var id int64
-			rows.Scan(&id)
-			ids = append(ids, id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
 		}
+
+		ids = append(ids, id)
+	}
+
+	// ignored records are not returned, thus the remaining ids can't be matched to the records.
+	if len(ids) != len(bulkMutates) {
+		return nil, rows.Err()
 	}
 
-	return ids, err
+	return ids, rows.Err()
 }
 
 func (adapter *Adapter) query(ctx context.Context, statement string, args []interface{}) (*db.Rows, error) {
@@ -175,6 +197,8 @@ func mapColumnFunc(column *rel.Column) (string, int, int) {
 	case rel.Int, rel.BigInt, rel.Text:
 		column.Limit = 0
 		typ, m, n = sql.MapColumn(column)
+	case rel.Binary:
+		typ = "BYTEA"
 	default:
 		typ, m, n = sql.MapColumn(column)
 	}
@@ -42,10 +42,16 @@ func TestAdapter_specs(t *testing.T) {
 
 	// Migration Specs
 	specs.Migrate(t, repo)
+	specs.Introspect(t, repo)
 
 	// Query Specs
 	specs.Query(t, repo)
 	specs.QueryJoin(t, repo)
+	specs.QuerySubQuery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QuerySelectExpr(t, repo)
+	specs.QueryPaginate(t, repo)
+	specs.QueryIterate(t, repo)
 	specs.QueryNotFound(t, repo)
 
 	// Preload specs
@@ -58,22 +64,31 @@ func TestAdapter_specs(t *testing.T) {
 	specs.PreloadBelongsTo(t, repo)
 	specs.PreloadBelongsToWithQuery(t, repo)
 	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadManyToMany(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadJoin(t, repo)
+	specs.PreloadLimitPerParent(t, repo)
 
 	// Aggregate Specs
 	specs.Aggregate(t, repo)
+	specs.AggregateFloat(t, repo)
+	specs.AggregateGroup(t, repo)
 
 	// Insert Specs
 	specs.Insert(t, repo)
 	specs.InsertHasMany(t, repo)
 	specs.InsertHasOne(t, repo)
 	specs.InsertBelongsTo(t, repo)
+	specs.InsertManyToMany(t, repo)
 	specs.Inserts(t, repo)
 	specs.InsertAll(t, repo)
 	specs.InsertAllPartialCustomPrimary(t, repo)
+	specs.Upsert(t, repo)
 
 	// Update Specs
 	specs.Update(t, repo)
 	specs.UpdateNotFound(t, repo)
+	specs.UpdateStaleRecord(t, repo)
 	specs.UpdateHasManyInsert(t, repo)
 	specs.UpdateHasManyUpdate(t, repo)
 	specs.UpdateHasManyReplace(t, repo)
@@ -81,6 +96,7 @@ func TestAdapter_specs(t *testing.T) {
 	specs.UpdateHasOneUpdate(t, repo)
 	specs.UpdateBelongsToInsert(t, repo)
 	specs.UpdateBelongsToUpdate(t, repo)
+	specs.UpdateManyToMany(t, repo)
 	specs.UpdateAtomic(t, repo)
 	specs.Updates(t, repo)
 	specs.UpdateAll(t, repo)
@@ -90,6 +106,8 @@ func TestAdapter_specs(t *testing.T) {
 	specs.DeleteBelongsTo(t, repo)
 	specs.DeleteHasOne(t, repo)
 	specs.DeleteHasMany(t, repo)
+	specs.DeleteManyToMany(t, repo)
+	specs.DeleteStaleRecord(t, repo)
 	specs.DeleteAll(t, repo)
 
 	// Constraint specs
@@ -122,3 +140,39 @@ func TestAdapter_Exec_error(t *testing.T) {
 	_, _, err = adapter.Exec(ctx, "error", nil)
 	assert.NotNil(t, err)
 }
+
+func TestAdapter_Indexes_expression(t *testing.T) {
+	adapter, err := Open(dsn())
+	assert.Nil(t, err)
+	defer adapter.Close()
+
+	_, _, err = adapter.Exec(ctx, "CREATE TABLE expression_indexes (id SERIAL PRIMARY KEY, email VARCHAR(255), name VARCHAR(255));", nil)
+	assert.Nil(t, err)
+	defer adapter.Exec(ctx, "DROP TABLE expression_indexes;", nil)
+
+	_, _, err = adapter.Exec(ctx, "CREATE INDEX expression_indexes_email_idx ON expression_indexes (lower(email), name);", nil)
+	assert.Nil(t, err)
+
+	indexes, err := adapter.Indexes(ctx, "expression_indexes")
+	assert.Nil(t, err)
+	assert.Equal(t, []rel.Index{
+		{Op: rel.SchemaCreate, Table: "expression_indexes", Name: "expression_indexes_email_idx", Columns: []string{"^lower(email::text)", "name"}},
+	}, indexes)
+}
+
+func TestAdapter_Tables_bigserial(t *testing.T) {
+	adapter, err := Open(dsn())
+	assert.Nil(t, err)
+	defer adapter.Close()
+
+	_, _, err = adapter.Exec(ctx, "CREATE TABLE bigserial_ids (id BIGSERIAL PRIMARY KEY, counter BIGSERIAL);", nil)
+	assert.Nil(t, err)
+	defer adapter.Exec(ctx, "DROP TABLE bigserial_ids;", nil)
+
+	table, err := adapter.table(ctx, "bigserial_ids")
+	assert.Nil(t, err)
+	assert.Equal(t, []rel.TableDefinition{
+		rel.Column{Op: rel.SchemaCreate, Name: "id", Type: rel.ID},
+		rel.Column{Op: rel.SchemaCreate, Name: "counter", Type: rel.BigInt, Required: true},
+	}, table.Definitions)
+}
@@ -59,3 +59,71 @@ func Aggregate(t *testing.T, repo rel.Repository) {
 		})
 	}
 }
+
+// AggregateFloat tests aggregate float specifications.
+func AggregateFloat(t *testing.T, repo rel.Repository) {
+	var (
+		users = []User{
+			{Name: "aggregate float", Gender: "male", Age: 10},
+			{Name: "aggregate float", Gender: "male", Age: 11},
+		}
+		query = rel.From("users").Where(where.Eq("name", "aggregate float"))
+	)
+
+	repo.MustInsertAll(ctx, &users)
+
+	avg, err := repo.AggregateFloat(ctx, query, "avg", "age")
+	assert.Nil(t, err)
+	assert.Equal(t, 10.5, avg)
+
+	sum, err := repo.AggregateFloat(ctx, query, "sum", "age")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(21), sum)
+
+	none, err := repo.AggregateFloat(ctx, query.Where(where.Eq("age", 0)), "avg", "age")
+	assert.Nil(t, err)
+	assert.Zero(t, none)
+
+	decimal, err := repo.AggregateDecimal(ctx, query, "sum", "age")
+	assert.Nil(t, err)
+	assert.Equal(t, "21", decimal.RatString())
+}
+
+// AggregateGroup tests aggregate group specifications.
+func AggregateGroup(t *testing.T, repo rel.Repository) {
+	type GenderStat struct {
+		Gender string
+		Count  int
+		AvgAge float64 `db:"avg_age"`
+		MaxAge int     `db:"oldest"`
+	}
+
+	var (
+		stats []GenderStat
+		rows  []map[string]interface{}
+		users = []User{
+			{Name: "aggregate group", Gender: "female", Age: 20},
+			{Name: "aggregate group", Gender: "male", Age: 10},
+			{Name: "aggregate group", Gender: "male", Age: 15},
+		}
+		query = rel.From("users").Where(where.Eq("name", "aggregate group")).Group("gender").SortAsc("gender")
+	)
+
+	repo.MustInsertAll(ctx, &users)
+
+	err := repo.AggregateGroup(ctx, &stats, query,
+		rel.NewSelectAggregate("count", "*"),
+		rel.NewSelectAggregate("avg", "age"),
+		rel.NewSelectAggregate("max", "age").As("oldest"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []GenderStat{
+		{Gender: "female", Count: 1, AvgAge: 20, MaxAge: 20},
+		{Gender: "male", Count: 2, AvgAge: 12.5, MaxAge: 15},
+	}, stats)
+
+	err = repo.AggregateGroup(ctx, &rows, query.Having(where.Gt("count(*)", 1)), rel.NewSelectAggregate("count", "*"))
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "male", rows[0]["gender"])
+}
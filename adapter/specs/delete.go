@@ -89,6 +89,42 @@ func DeleteHasMany(t *testing.T, repo rel.Repository) {
 	assert.Equal(t, rel.NotFoundError{}, repo.Find(ctx, &Address{}, where.Eq("id", user.Addresses[1].ID)))
 }
 
+// DeleteManyToMany tests delete specifications.
+func DeleteManyToMany(t *testing.T, repo rel.Repository) {
+	var (
+		user = User{
+			Name:  "user",
+			Age:   100,
+			Roles: []Role{{Name: "delete role"}},
+		}
+	)
+
+	repo.MustInsert(ctx, &user)
+	assert.NotEqual(t, 0, user.ID)
+	assert.NotEqual(t, 0, user.Roles[0].ID)
+
+	assert.Nil(t, repo.Delete(ctx, &user, rel.Cascade(true)))
+	assert.Equal(t, rel.NotFoundError{}, repo.Find(ctx, &User{}, where.Eq("id", user.ID)))
+	assert.Equal(t, 0, repo.MustCount(ctx, "user_roles", where.Eq("user_id", user.ID)))
+	assert.Nil(t, repo.Find(ctx, &Role{}, where.Eq("id", user.Roles[0].ID)))
+}
+
+// DeleteStaleRecord tests specification for deleting a record using outdated lock version.
+func DeleteStaleRecord(t *testing.T, repo rel.Repository) {
+	var (
+		category = Category{Name: "stale delete"}
+	)
+
+	repo.MustInsert(ctx, &category)
+
+	stale := category
+	repo.MustUpdate(ctx, &category, rel.Set("name", "updated"))
+
+	assert.Equal(t, rel.StaleRecordError{Table: "categories"}, repo.Delete(ctx, &stale))
+	assert.Nil(t, repo.Delete(ctx, &category))
+	assert.Equal(t, rel.NotFoundError{}, repo.Find(ctx, &Category{}, where.Eq("id", category.ID)))
+}
+
 // DeleteAll tests delete all specifications.
 func DeleteAll(t *testing.T, repo rel.Repository) {
 	repo.MustInsert(ctx, &User{Name: "delete", Age: 100})
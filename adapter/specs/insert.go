@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
 	"github.com/stretchr/testify/assert"
 )
@@ -259,3 +260,99 @@ func assertRecords(t *testing.T, repo rel.Repository, records interface{}) {
 		assert.Equal(t, found, *v)
 	}
 }
+
+// Upsert tests insert on conflict specifications.
+func Upsert(t *testing.T, repo rel.Repository) {
+	var (
+		user = User{Name: "upsert", Age: 10}
+	)
+
+	repo.MustInsert(ctx, &user)
+
+	t.Run("Upsert", func(t *testing.T) {
+		var (
+			found  User
+			upsert = User{ID: user.ID, Name: "upserted", Age: 20}
+		)
+
+		assert.Nil(t, repo.Upsert(ctx, &upsert))
+		assert.Equal(t, user.ID, upsert.ID)
+
+		repo.MustFind(ctx, &found, where.Eq("id", user.ID))
+		assert.Equal(t, "upserted", found.Name)
+		assert.Equal(t, 20, found.Age)
+	})
+
+	t.Run("UpsertFields", func(t *testing.T) {
+		var (
+			found  User
+			upsert = User{ID: user.ID, Name: "ignored", Age: 30}
+		)
+
+		assert.Nil(t, repo.Upsert(ctx, &upsert, rel.OnConflictKeyUpdate("id", "age")))
+
+		repo.MustFind(ctx, &found, where.Eq("id", user.ID))
+		assert.Equal(t, "upserted", found.Name)
+		assert.Equal(t, 30, found.Age)
+	})
+
+	t.Run("InsertIgnore", func(t *testing.T) {
+		var (
+			found User
+		)
+
+		assert.Nil(t, repo.Insert(ctx, &User{ID: user.ID, Name: "ignored"}, rel.OnConflictIgnore()))
+
+		repo.MustFind(ctx, &found, where.Eq("id", user.ID))
+		assert.Equal(t, "upserted", found.Name)
+	})
+
+	t.Run("InsertAllReplace", func(t *testing.T) {
+		var (
+			found []User
+			users = []User{
+				{ID: user.ID, Name: "replaced", Age: 40},
+			}
+		)
+
+		assert.Nil(t, repo.InsertAll(ctx, &users, rel.OnConflictReplace()))
+
+		repo.MustFindAll(ctx, &found, where.Eq("id", user.ID))
+		assert.Len(t, found, 1)
+		assert.Equal(t, "replaced", found[0].Name)
+		assert.Equal(t, 40, found[0].Age)
+	})
+}
+
+// InsertManyToMany tests specification for insertion with many to many association.
+func InsertManyToMany(t *testing.T, repo rel.Repository) {
+	var (
+		result User
+		role   = Role{Name: "insert existing role"}
+	)
+
+	repo.MustInsert(ctx, &role)
+
+	var (
+		user = User{
+			Name: "insert many to many",
+			Roles: []Role{
+				role,
+				{Name: "insert new role"},
+			},
+		}
+	)
+
+	err := repo.Insert(ctx, &user)
+	assert.Nil(t, err)
+	assert.NotZero(t, user.ID)
+	assert.Len(t, user.Roles, 2)
+	assert.Equal(t, role, user.Roles[0])
+	assert.NotZero(t, user.Roles[1].ID)
+	assert.Equal(t, "insert new role", user.Roles[1].Name)
+
+	repo.MustFind(ctx, &result, where.Eq("id", user.ID))
+	repo.MustPreload(ctx, &result, "roles", sort.Asc("id"))
+
+	assert.Equal(t, user, result)
+}
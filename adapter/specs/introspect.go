@@ -0,0 +1,152 @@
+package specs
+
+import (
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+// Introspect specs.
+func Introspect(t *testing.T, repo rel.Repository) {
+	introspector, ok := repo.Adapter(ctx).(rel.Introspector)
+	if !assert.True(t, ok, "adapter should implement rel.Introspector") {
+		return
+	}
+
+	tables, err := introspector.Tables(ctx)
+	assert.Nil(t, err)
+
+	t.Run("Column", func(t *testing.T) {
+		users := introspectedTable(t, tables, "users")
+
+		assert.Equal(t, rel.Column{Op: rel.SchemaCreate, Name: "id", Type: rel.ID}, introspectedColumn(t, users, "id"))
+		assert.Equal(t, rel.Column{Op: rel.SchemaCreate, Name: "slug", Type: rel.String, Limit: 30}, introspectedColumn(t, users, "slug"))
+		assert.Equal(t, rel.Column{Op: rel.SchemaCreate, Name: "age", Type: rel.Int, Required: true, Default: 0}, introspectedColumn(t, users, "age"))
+		assert.Equal(t, rel.DateTime, introspectedColumn(t, users, "created_at").Type)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		var (
+			adapter = repo.Adapter(ctx).(rel.Adapter)
+			table   = rel.Table{
+				Op:   rel.SchemaCreate,
+				Name: "introspect_defaults",
+				Definitions: []rel.TableDefinition{
+					rel.Column{Name: "id", Type: rel.ID},
+					rel.Column{Name: "active", Type: rel.Bool, Default: false},
+					rel.Column{Name: "status", Type: rel.String, Default: "draft"},
+					rel.Column{Name: "created_at", Type: rel.DateTime, Default: rel.Raw("CURRENT_TIMESTAMP")},
+				},
+			}
+		)
+
+		assert.Nil(t, adapter.Apply(ctx, table))
+		defer adapter.Apply(ctx, rel.Table{Op: rel.SchemaDrop, Name: "introspect_defaults"})
+
+		tables, err := introspector.Tables(ctx)
+		assert.Nil(t, err)
+
+		defaults := introspectedTable(t, tables, "introspect_defaults")
+		assert.Equal(t, false, introspectedColumn(t, defaults, "active").Default)
+		assert.Equal(t, "draft", introspectedColumn(t, defaults, "status").Default)
+		assert.Equal(t, rel.Raw("CURRENT_TIMESTAMP"), introspectedColumn(t, defaults, "created_at").Default)
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		var (
+			adapter = repo.Adapter(ctx).(rel.Adapter)
+			table   = rel.Table{
+				Op:   rel.SchemaCreate,
+				Name: "introspect_binaries",
+				Definitions: []rel.TableDefinition{
+					rel.Column{Name: "id", Type: rel.ID},
+					rel.Column{Name: "data", Type: rel.Binary},
+				},
+			}
+		)
+
+		assert.Nil(t, adapter.Apply(ctx, table))
+		defer adapter.Apply(ctx, rel.Table{Op: rel.SchemaDrop, Name: "introspect_binaries"})
+
+		tables, err := introspector.Tables(ctx)
+		assert.Nil(t, err)
+
+		binaries := introspectedTable(t, tables, "introspect_binaries")
+		assert.Equal(t, rel.Column{Op: rel.SchemaCreate, Name: "data", Type: rel.Binary}, introspectedColumn(t, binaries, "data"))
+	})
+
+	t.Run("UniqueKey", func(t *testing.T) {
+		keys := introspectedKeys(introspectedTable(t, tables, "users"), rel.UniqueKey)
+		if assert.Len(t, keys, 1) {
+			assert.Equal(t, []string{"slug"}, keys[0].Columns)
+		}
+	})
+
+	t.Run("ForeignKey", func(t *testing.T) {
+		keys := introspectedKeys(introspectedTable(t, tables, "addresses"), rel.ForeignKey)
+		if assert.Len(t, keys, 1) {
+			assert.Equal(t, []string{"user_id"}, keys[0].Columns)
+			assert.Equal(t, "users", keys[0].Reference.Table)
+			assert.Equal(t, []string{"id"}, keys[0].Reference.Columns)
+		}
+	})
+
+	t.Run("PrimaryKeys", func(t *testing.T) {
+		composites := introspectedTable(t, tables, "composites")
+		keys := introspectedKeys(composites, rel.PrimaryKey)
+		if assert.Len(t, keys, 1) {
+			assert.Equal(t, []string{"primary1", "primary2"}, keys[0].Columns)
+		}
+
+		assert.Equal(t, rel.Int, introspectedColumn(t, composites, "primary1").Type)
+		assert.Len(t, introspectedKeys(introspectedTable(t, tables, "user_roles"), rel.ForeignKey), 2)
+	})
+
+	t.Run("Indexes", func(t *testing.T) {
+		var (
+			adapter = repo.Adapter(ctx).(rel.Adapter)
+			index   = rel.Index{Op: rel.SchemaCreate, Table: "users", Name: "users_age_name_idx", Columns: []string{"age", "name"}}
+		)
+
+		assert.Nil(t, adapter.Apply(ctx, index))
+		defer adapter.Apply(ctx, rel.Index{Op: rel.SchemaDrop, Table: "users", Name: "users_age_name_idx"})
+
+		indexes, err := introspector.Indexes(ctx, "users")
+		assert.Nil(t, err)
+		assert.Equal(t, []rel.Index{index}, indexes)
+	})
+}
+
+func introspectedTable(t *testing.T, tables []rel.Table, name string) rel.Table {
+	for _, table := range tables {
+		if table.Name == name {
+			return table
+		}
+	}
+
+	t.Errorf("table %s is not introspected", name)
+	return rel.Table{}
+}
+
+func introspectedColumn(t *testing.T, table rel.Table, name string) rel.Column {
+	for _, def := range table.Definitions {
+		if column, ok := def.(rel.Column); ok && column.Name == name {
+			return column
+		}
+	}
+
+	t.Errorf("column %s.%s is not introspected", table.Name, name)
+	return rel.Column{}
+}
+
+func introspectedKeys(table rel.Table, typ rel.KeyType) []rel.Key {
+	var keys []rel.Key
+	for _, def := range table.Definitions {
+		if key, ok := def.(rel.Key); ok && key.Type == typ {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
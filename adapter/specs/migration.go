@@ -8,11 +8,21 @@ import (
 	"github.com/Fs02/rel/migrator"
 )
 
-var m migrator.Migrator
-
 // Setup database for specs execution.
 func Setup(t *testing.T, repo rel.Repository) func() {
-	m = migrator.New(repo)
+	m := migrator.New(repo)
+	registerSetup(&m)
+	m.Migrate(ctx)
+
+	return func() {
+		for i := 0; i < 6; i++ {
+			m.Rollback(ctx)
+		}
+	}
+}
+
+// registerSetup registers migrations of tables used by specs.
+func registerSetup(m *migrator.Migrator) {
 	m.Register(1,
 		func(schema *rel.Schema) {
 			schema.CreateTable("users", func(t *rel.Table) {
@@ -83,18 +93,52 @@ func Setup(t *testing.T, repo rel.Repository) func() {
 		},
 	)
 
-	m.Migrate(ctx)
+	m.Register(5,
+		func(schema *rel.Schema) {
+			schema.CreateTable("roles", func(t *rel.Table) {
+				t.ID("id")
+				t.String("name", rel.Limit(30))
+			})
 
-	return func() {
-		for i := 0; i < 4; i++ {
-			m.Rollback(ctx)
-		}
-	}
+			schema.CreateTable("user_roles", func(t *rel.Table) {
+				t.Int("user_id", rel.Unsigned(true))
+				t.Int("role_id", rel.Unsigned(true))
+
+				t.PrimaryKeys([]string{"user_id", "role_id"})
+				t.ForeignKey("user_id", "users", "id")
+				t.ForeignKey("role_id", "roles", "id")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("user_roles")
+			schema.DropTable("roles")
+		},
+	)
+
+	m.Register(6,
+		func(schema *rel.Schema) {
+			schema.CreateTable("categories", func(t *rel.Table) {
+				t.ID("id")
+				t.Int("parent_id", rel.Unsigned(true))
+				t.String("name", rel.Limit(30))
+				t.Int("lock_version", rel.Default(0))
+
+				t.ForeignKey("parent_id", "categories", "id")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("categories")
+		},
+	)
 }
 
 // Migrate specs.
+// Migrations of setup are registered as already applied, so only migrations of this spec are migrated and rolled back.
 func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
-	m.Register(5,
+	m := migrator.New(repo)
+	registerSetup(&m)
+
+	m.Register(7,
 		func(schema *rel.Schema) {
 			schema.CreateTable("dummies", func(t *rel.Table) {
 				t.ID("id")
@@ -112,6 +156,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 				t.String("string1")
 				t.String("string2", rel.Default("string"), rel.Limit(100))
 				t.Text("text")
+				t.Binary("binary")
 				t.Date("date1")
 				t.Date("date2", rel.Default(time.Now()))
 				t.DateTime("datetime1")
@@ -131,7 +176,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(6,
+	m.Register(8,
 		func(schema *rel.Schema) {
 			schema.AlterTable("dummies", func(t *rel.AlterTable) {
 				t.Bool("new_column")
@@ -150,7 +195,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	defer m.Rollback(ctx)
 
 	if SkipRenameColumn.enabled(flags) {
-		m.Register(7,
+		m.Register(9,
 			func(schema *rel.Schema) {
 				schema.AlterTable("dummies", func(t *rel.AlterTable) {
 					t.RenameColumn("text", "teks")
@@ -169,7 +214,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 		defer m.Rollback(ctx)
 	}
 
-	m.Register(8,
+	m.Register(10,
 		func(schema *rel.Schema) {
 			schema.CreateIndex("dummies", "int1_idx", []string{"int1"})
 			schema.CreateIndex("dummies", "string1_string2_idx", []string{"string1", "string2"})
@@ -181,7 +226,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(9,
+	m.Register(11,
 		func(schema *rel.Schema) {
 			schema.RenameTable("dummies", "new_dummies")
 		},
@@ -191,7 +236,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(10,
+	m.Register(12,
 		func(schema *rel.Schema) {
 			schema.CreateTableIfNotExists("dummies2", func(t *rel.Table) {
 				t.ID("id")
@@ -203,7 +248,7 @@ func Migrate(t *testing.T, repo rel.Repository, flags ...Flag) {
 	)
 	defer m.Rollback(ctx)
 
-	m.Register(11,
+	m.Register(13,
 		func(schema *rel.Schema) {
 			schema.CreateTableIfNotExists("dummies2", func(t *rel.Table) {
 				t.ID("id")
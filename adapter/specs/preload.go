@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
 	"github.com/stretchr/testify/assert"
 )
@@ -177,3 +178,99 @@ func PreloadBelongsToSlice(t *testing.T, repo rel.Repository) {
 		assert.Equal(t, user, result[i].User)
 	}
 }
+
+// PreloadManyToMany tests specification for preloading many to many association.
+func PreloadManyToMany(t *testing.T, repo rel.Repository) {
+	var (
+		result []User
+		role   = Role{Name: "preload shared role"}
+	)
+
+	repo.MustInsert(ctx, &role)
+
+	var (
+		users = []User{
+			{Name: "preload many to many 1", Roles: []Role{role, {Name: "preload role 1"}}},
+			{Name: "preload many to many 2", Roles: []Role{role}},
+			{Name: "preload many to many 3"},
+		}
+	)
+
+	repo.MustInsert(ctx, &users[0])
+	repo.MustInsert(ctx, &users[1])
+	repo.MustInsert(ctx, &users[2])
+
+	err := repo.FindAll(ctx, &result, where.In("id", users[0].ID, users[1].ID, users[2].ID), sort.Asc("id"))
+	assert.Nil(t, err)
+
+	err = repo.Preload(ctx, &result, "roles", sort.Asc("id"))
+	assert.Nil(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, users[0].Roles, result[0].Roles)
+	assert.Equal(t, users[1].Roles, result[1].Roles)
+	assert.Empty(t, result[2].Roles)
+}
+
+// PreloadNested tests specification for preloading nested associations using preload query.
+func PreloadNested(t *testing.T, repo rel.Repository) {
+	var (
+		result User
+		user   = createPreloadUser(repo)
+	)
+
+	err := repo.Find(ctx, &result, where.Eq("id", user.ID), rel.Preload("addresses",
+		where.Eq("name", "home"),
+		rel.Preload("user"),
+	))
+	assert.Nil(t, err)
+	assert.Len(t, result.Addresses, 1)
+	assert.Equal(t, user.Addresses[1].ID, result.Addresses[0].ID)
+	assert.Equal(t, user.ID, result.Addresses[0].User.ID)
+	assert.Equal(t, user.Name, result.Addresses[0].User.Name)
+}
+
+// PreloadJoin tests specification for preloading belongs to association using join.
+func PreloadJoin(t *testing.T, repo rel.Repository) {
+	var (
+		result  []Address
+		user    = createPreloadUser(repo)
+		address = Address{Name: "orphan"}
+	)
+
+	repo.MustInsert(ctx, &address)
+
+	err := repo.FindAll(ctx, &result,
+		where.In("id", user.Addresses[0].ID, address.ID),
+		sort.Asc("id"),
+		rel.PreloadJoin("user", rel.Preload("addresses")),
+	)
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, user.Addresses[0].ID, result[0].ID)
+	assert.Equal(t, user.ID, result[0].User.ID)
+	assert.Equal(t, user.Name, result[0].User.Name)
+	assert.Len(t, result[0].User.Addresses, 3)
+	assert.Equal(t, address.ID, result[1].ID)
+	assert.Equal(t, User{}, result[1].User)
+}
+
+// PreloadLimitPerParent tests specification for preloading has many association with limit for each parent.
+func PreloadLimitPerParent(t *testing.T, repo rel.Repository) {
+	var (
+		result []User
+		users  = []User{
+			createPreloadUser(repo),
+			createPreloadUser(repo),
+		}
+	)
+
+	err := repo.FindAll(ctx, &result, where.In("id", users[0].ID, users[1].ID), sort.Asc("id"),
+		rel.Preload("addresses", sort.Desc("id"), rel.LimitPerParent(2)),
+	)
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+
+	for i := range result {
+		assert.Equal(t, []Address{users[i].Addresses[2], users[i].Addresses[1]}, result[i].Addresses)
+	}
+}
@@ -1,9 +1,11 @@
 package specs
 
 import (
+	"io"
 	"testing"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/expr"
 	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
 	"github.com/stretchr/testify/assert"
@@ -82,6 +84,270 @@ func QueryJoin(t *testing.T, repo rel.Repository) {
 	run(t, repo, tests)
 }
 
+// QuerySubQuery tests query specifications using subquery.
+func QuerySubQuery(t *testing.T, repo rel.Repository) {
+	var (
+		users = []User{
+			{Name: "subquery1", Gender: "male", Age: 70},
+			{Name: "subquery2", Gender: "male", Age: 71},
+			{Name: "subquery3", Gender: "female", Age: 72},
+		}
+		names = where.In("name", "subquery1", "subquery2", "subquery3")
+	)
+
+	repo.MustInsert(ctx, &users[0])
+	repo.MustInsert(ctx, &users[1])
+	repo.MustInsert(ctx, &users[2])
+
+	repo.MustInsert(ctx, &Address{Name: "subquery address1", UserID: &users[0].ID})
+	repo.MustInsert(ctx, &Address{Name: "subquery address2", UserID: &users[2].ID})
+
+	tests := []struct {
+		name     string
+		queriers []rel.Querier
+		expected []User
+	}{
+		{
+			name: "In",
+			queriers: []rel.Querier{
+				where.In("id", rel.Select("user_id").From("addresses").Where(where.Like("name", "subquery%"))),
+			},
+			expected: []User{users[0], users[2]},
+		},
+		{
+			name: "Nin",
+			queriers: []rel.Querier{
+				where.Gt("age", 60),
+				names,
+				where.Nin("id", rel.Select("user_id").From("addresses").Where(where.Eq("name", "subquery address1"))),
+			},
+			expected: []User{users[1], users[2]},
+		},
+		{
+			name: "Exists",
+			queriers: []rel.Querier{
+				where.Gte("age", 70),
+				where.Exists(rel.From("addresses").Where(where.Like("name", "subquery%"), where.Fragment("addresses.user_id = users.id"))),
+				where.Eq("gender", "male"),
+			},
+			expected: []User{users[0]},
+		},
+		{
+			name: "NotExists",
+			queriers: []rel.Querier{
+				names,
+				where.NotExists(rel.From("addresses").Where(where.Fragment("addresses.user_id = users.id"))),
+			},
+			expected: []User{users[1]},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				result []User
+			)
+
+			assert.Nil(t, repo.FindAll(ctx, &result, append(test.queriers, sort.Asc("id"))...))
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+// QueryWith tests query specifications using common table expression.
+func QueryWith(t *testing.T, repo rel.Repository) {
+	var (
+		root       = Category{Name: "root"}
+		other      = Category{Name: "other root"}
+		child      Category
+		grandChild Category
+	)
+
+	repo.MustInsert(ctx, &root)
+	repo.MustInsert(ctx, &other)
+
+	child = Category{Name: "child", ParentID: &root.ID}
+	repo.MustInsert(ctx, &child)
+
+	grandChild = Category{Name: "grand child", ParentID: &child.ID}
+	repo.MustInsert(ctx, &grandChild)
+
+	repo.MustInsert(ctx, &Category{Name: "other child", ParentID: &other.ID})
+
+	t.Run("With", func(t *testing.T) {
+		var (
+			result []Category
+			query  = rel.With("roots", rel.From("categories").Where(where.Nil("parent_id"))).From("roots").Where(where.Ne("name", "other root"))
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, query))
+		assert.Equal(t, []Category{root}, result)
+	})
+
+	t.Run("WithRecursive", func(t *testing.T) {
+		var (
+			result []Category
+			tree   = rel.From("categories").Where(where.Eq("id", root.ID)).
+				UnionAll(rel.Select("categories.*").From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))
+			query = rel.WithRecursive("tree", tree).From("tree")
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, query.SortAsc("id")))
+		assert.Equal(t, []Category{root, child, grandChild}, result)
+
+		count, err := repo.Count(ctx, "tree", query.Where(where.NotNil("parent_id")))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
+
+// QuerySelectExpr tests query specifications using select expressions.
+func QuerySelectExpr(t *testing.T, repo rel.Repository) {
+	var (
+		names = where.Eq("name", "select expr")
+		users = []User{
+			{Name: "select expr", Gender: "male", Age: 20},
+			{Name: "select expr", Gender: "female", Age: 20},
+			{Name: "select expr", Gender: "male", Age: 30},
+		}
+	)
+
+	repo.MustInsertAll(ctx, &users)
+
+	t.Run("Aggregate", func(t *testing.T) {
+		type ageGroup struct {
+			Age   int
+			Total int
+		}
+
+		var (
+			result []ageGroup
+			query  = rel.From("users").Select("age").SelectExpr(expr.Count("*").As("total")).Where(names).Group("age").SortAsc("age")
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, query))
+		assert.Equal(t, []ageGroup{{Age: 20, Total: 2}, {Age: 30, Total: 1}}, result)
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		type userRank struct {
+			ID       int64
+			Rank     int
+			AgeCount int
+			Label    string
+		}
+
+		var (
+			result []userRank
+			query  = rel.From("users").Select("id").SelectExpr(
+				expr.RowNumber().Over(expr.PartitionBy("age").SortDesc("id")).As("rank"),
+				expr.Count("*").Over(expr.PartitionBy("age")).As("age_count"),
+				expr.Case().When(where.Gte("age", 30), expr.Field("name")).ElseValue(expr.Field("gender")).As("label"),
+			).Where(names).SortAsc("id")
+		)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, query))
+		assert.Equal(t, []userRank{
+			{ID: users[0].ID, Rank: 2, AgeCount: 2, Label: "male"},
+			{ID: users[1].ID, Rank: 1, AgeCount: 2, Label: "female"},
+			{ID: users[2].ID, Rank: 1, AgeCount: 1, Label: "select expr"},
+		}, result)
+	})
+}
+
+// QueryPaginate tests keyset pagination specifications.
+func QueryPaginate(t *testing.T, repo rel.Repository) {
+	var (
+		expected []User
+		names    = where.Like("name", "paginate%")
+	)
+
+	for i := 0; i < 5; i++ {
+		user := User{Name: "paginate", Age: 80 + i%2}
+		repo.MustInsert(ctx, &user)
+	}
+
+	repo.MustFindAll(ctx, &expected, names, sort.Desc("age"), sort.Asc("id"))
+	assert.Len(t, expected, 5)
+
+	var (
+		result []User
+		cursor string
+		page   = rel.Paginate(2, sort.Desc("age"), sort.Asc("id"))
+	)
+
+	for i := 0; i < 3; i++ {
+		var (
+			users []User
+		)
+
+		next, err := page.After(cursor)
+		assert.Nil(t, err)
+
+		assert.Nil(t, repo.FindAll(ctx, &users, names, next))
+		result = append(result, users...)
+
+		cursor, err = next.Cursor(&users)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, "", cursor)
+	assert.Equal(t, expected, result)
+}
+
+// QueryIterate tests iterator specifications.
+func QueryIterate(t *testing.T, repo rel.Repository) {
+	var (
+		expected []User
+		names    = where.Like("name", "iterate%")
+	)
+
+	for i := 0; i < 5; i++ {
+		user := User{Name: "iterate", Age: 90 + i%2}
+		repo.MustInsert(ctx, &user)
+	}
+
+	repo.MustFindAll(ctx, &expected, names, sort.Desc("age"), sort.Asc("id"))
+
+	tests := []struct {
+		name    string
+		options []rel.IteratorOption
+	}{
+		{
+			name:    "Batch",
+			options: []rel.IteratorOption{rel.BatchSize(2)},
+		},
+		{
+			name:    "Stream",
+			options: []rel.IteratorOption{rel.BatchSize(2), rel.Stream()},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				result []User
+				it     = repo.Iterate(ctx, rel.From("users").Where(names).SortDesc("age"), test.options...)
+			)
+
+			defer it.Close()
+
+			for {
+				var user User
+				if err := it.Next(&user); err == io.EOF {
+					break
+				} else {
+					assert.Nil(t, err)
+				}
+
+				result = append(result, user)
+			}
+
+			assert.Equal(t, expected, result)
+		})
+	}
+}
+
 // QueryNotFound tests query specifications when no result found.
 func QueryNotFound(t *testing.T, repo rel.Repository) {
 	t.Run("NotFound", func(t *testing.T) {
@@ -37,6 +37,7 @@ type User struct {
 	Note           *string
 	Addresses      []Address
 	PrimaryAddress *Address
+	Roles          []Role `through:"user_roles"`
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -51,6 +52,20 @@ type Address struct {
 	UpdatedAt time.Time
 }
 
+// Role defines roles schema.
+type Role struct {
+	ID   int64
+	Name string
+}
+
+// Category defines categories schema.
+type Category struct {
+	ID          int64
+	ParentID    *int64
+	Name        string
+	LockVersion int
+}
+
 // Extra defines extra schema.
 type Extra struct {
 	ID     uint
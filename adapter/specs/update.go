@@ -58,6 +58,27 @@ func UpdateNotFound(t *testing.T, repo rel.Repository) {
 	assert.Equal(t, rel.NotFoundError{}, repo.Update(ctx, &user))
 }
 
+// UpdateStaleRecord tests specification for updating a record using outdated lock version.
+func UpdateStaleRecord(t *testing.T, repo rel.Repository) {
+	var (
+		category = Category{Name: "stale update"}
+	)
+
+	repo.MustInsert(ctx, &category)
+
+	stale := category
+
+	assert.Nil(t, repo.Update(ctx, &category, rel.Set("name", "first update")))
+	assert.Equal(t, 1, category.LockVersion)
+
+	err := repo.Update(ctx, &stale, rel.Set("name", "second update"))
+	assert.Equal(t, rel.StaleRecordError{Table: "categories"}, err)
+	assert.Equal(t, 0, stale.LockVersion)
+
+	assert.Nil(t, repo.Find(ctx, &stale, where.Eq("id", category.ID)))
+	assert.Equal(t, category, stale)
+}
+
 // UpdateHasManyInsert tests specification for updating a record and inserting has many association.
 func UpdateHasManyInsert(t *testing.T, repo rel.Repository) {
 	var (
@@ -389,3 +410,48 @@ func UpdateAll(t *testing.T, repo rel.Repository) {
 		})
 	}
 }
+
+// UpdateManyToMany tests specification for updating a record and linking or unlinking many to many association.
+func UpdateManyToMany(t *testing.T, repo rel.Repository) {
+	var (
+		result User
+		role   = Role{Name: "update existing role"}
+		user   = User{
+			Name: "update many to many",
+			Roles: []Role{
+				{Name: "update unlinked role"},
+				{Name: "update kept role"},
+			},
+		}
+	)
+
+	repo.MustInsert(ctx, &role)
+	repo.MustInsert(ctx, &user)
+
+	changeset := rel.NewChangeset(&user)
+	user.Roles = []Role{
+		user.Roles[1],
+		role,
+		{Name: "update new role"},
+	}
+
+	err := repo.Update(ctx, &user, changeset)
+	assert.Nil(t, err)
+	assert.Len(t, user.Roles, 3)
+	assert.NotZero(t, user.Roles[2].ID)
+
+	repo.MustFind(ctx, &result, where.Eq("id", user.ID))
+	repo.MustPreload(ctx, &result, "roles")
+
+	assert.ElementsMatch(t, user.Roles, result.Roles)
+
+	// replace all roles using structset.
+	user.Roles = []Role{role}
+	assert.Nil(t, repo.Update(ctx, &user))
+
+	result = User{}
+	repo.MustFind(ctx, &result, where.Eq("id", user.ID))
+	repo.MustPreload(ctx, &result, "roles")
+
+	assert.Equal(t, []Role{role}, result.Roles)
+}
@@ -65,6 +65,15 @@ func (a *Adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error
 	rows, err := a.query(ctx, statement, args)
 	finish(err)
 
+	if err == nil && !a.Config.WindowFunction && query.PartitionQuery.Field != "" && query.PartitionQuery.Limit > 0 {
+		cur, err := NewPartitionCursor(rows, query)
+		if err != nil {
+			return nil, err
+		}
+
+		return cur, nil
+	}
+
 	return &Cursor{rows}, a.Config.ErrorFunc(err)
 }
 
@@ -101,23 +110,34 @@ func (a *Adapter) exec(ctx context.Context, statement string, args []interface{}
 }
 
 // Insert inserts a record to database and returns its id.
-func (a *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (interface{}, error) {
+func (a *Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (interface{}, error) {
 	var (
-		statement, args = NewBuilder(a.Config).Insert(query.Table, mutates)
-		id, _, err      = a.Exec(ctx, statement, args)
+		statement, args        = NewBuilder(a.Config).Insert(query.Table, mutates, onConflict)
+		id, affectedCount, err = a.Exec(ctx, statement, args)
 	)
 
-	return id, err
+	// last insert id is only reliable when the record is known to be inserted.
+	if err != nil || (onConflict.Replace && !a.inserted(affectedCount, 1)) || (onConflict.Ignore && affectedCount != 1) {
+		return nil, err
+	}
+
+	return id, nil
 }
 
 // InsertAll inserts all record to database and returns its ids.
-func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate) ([]interface{}, error) {
-	statement, args := NewBuilder(a.Config).InsertAll(query.Table, fields, bulkMutates)
-	id, _, err := a.Exec(ctx, statement, args)
+func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]interface{}, error) {
+	statement, args := NewBuilder(a.Config).InsertAll(query.Table, fields, bulkMutates, onConflict)
+	id, affectedCount, err := a.Exec(ctx, statement, args)
 	if err != nil {
 		return nil, err
 	}
 
+	// ids can't be predicted when some records are skipped or updated.
+	// affected count of multiple records on duplicate key update can't tell which record is updated.
+	if (onConflict.Replace && (len(bulkMutates) != 1 || !a.inserted(affectedCount, 1))) || (onConflict.Ignore && int(affectedCount) != len(bulkMutates)) {
+		return nil, nil
+	}
+
 	var (
 		ids = make([]interface{}, len(bulkMutates))
 		inc = 1
@@ -149,6 +169,13 @@ func (a *Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField s
 	return ids, nil
 }
 
+// inserted returns true when replacing insertion is known to insert the records.
+// On duplicate key update counts inserted row as 1, updated row as 2 and unchanged row as 0,
+// while on conflict update counts both inserted and updated row as 1.
+func (a *Adapter) inserted(affectedCount int64, count int) bool {
+	return a.Config.OnDuplicateKey && affectedCount == int64(count)
+}
+
 // Update updates a record in database.
 func (a *Adapter) Update(ctx context.Context, query rel.Query, mutates map[string]rel.Mutate) (int, error) {
 	var (
@@ -237,22 +264,26 @@ func (a *Adapter) Rollback(ctx context.Context) error {
 
 // Apply table.
 func (a *Adapter) Apply(ctx context.Context, migration rel.Migration) error {
+	_, _, err := a.Exec(ctx, a.MigrationStatement(migration), nil)
+	return err
+}
+
+// MigrationStatement returns statement that will be executed to apply the migration.
+func (a *Adapter) MigrationStatement(migration rel.Migration) string {
 	var (
-		statement string
-		builder   = NewBuilder(a.Config)
+		builder = NewBuilder(a.Config)
 	)
 
 	switch v := migration.(type) {
 	case rel.Table:
-		statement = builder.Table(v)
+		return builder.Table(v)
 	case rel.Index:
-		statement = builder.Index(v)
+		return builder.Index(v)
 	case rel.Raw:
-		statement = string(v)
+		return string(v)
 	}
 
-	_, _, err := a.Exec(ctx, statement, nil)
-	return err
+	return ""
 }
 
 // New initialize adapter without db.
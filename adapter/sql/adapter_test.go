@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/sort"
+	"github.com/Fs02/rel/where"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 )
@@ -114,6 +116,51 @@ func TestAdapter_FindAll_transaction(t *testing.T) {
 	}))
 }
 
+func TestAdapter_FindAll_partition(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		repo    = rel.New(adapter)
+		names   = []Name{
+			{ID: 201, Name: "partition-a"},
+			{ID: 202, Name: "partition-a"},
+			{ID: 203, Name: "partition-a"},
+			{ID: 204, Name: "partition-b"},
+		}
+	)
+
+	defer adapter.Close()
+
+	repo.MustInsertAll(ctx, &names)
+	defer repo.MustDeleteAll(ctx, rel.From("names").Where(where.Gte("id", 201)))
+
+	for _, windowFunction := range []bool{false, true} {
+		var (
+			result []Name
+		)
+
+		adapter.Config.WindowFunction = windowFunction
+
+		assert.Nil(t, repo.FindAll(ctx, &result, where.Like("name", "partition-%"), sort.Desc("id"), rel.LimitPartition("name", 2)))
+		assert.Equal(t, []Name{names[3], names[2], names[1]}, result)
+
+		assert.Nil(t, repo.FindAll(ctx, &result, where.Like("name", "partition-%"), sort.Desc("id"), rel.LimitPartition("name", 2), rel.Limit(1), rel.Offset(1)))
+		assert.Equal(t, []Name{names[2]}, result)
+	}
+}
+
+func TestAdapter_FindAll_partitionFieldNotSelected(t *testing.T) {
+	var (
+		adapter = open(t)
+		repo    = rel.New(adapter)
+	)
+
+	defer adapter.Close()
+
+	err := repo.FindAll(context.TODO(), &[]Name{}, rel.Select("id").From("names").LimitPartition("name", 2))
+	assert.Equal(t, errors.New("rel: partition field name is not selected"), err)
+}
+
 func TestAdapter_Query_error(t *testing.T) {
 	var (
 		adapter = open(t)
@@ -176,6 +223,64 @@ func TestAdapter_InsertAll_customPrimary(t *testing.T) {
 	assert.Equal(t, "Zoro", names[1].Name)
 }
 
+func TestAdapter_Insert_onConflict(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		query   = rel.From("names")
+	)
+	defer adapter.Close()
+
+	id, err := adapter.Insert(ctx, query, "id", map[string]rel.Mutate{"id": rel.Set("id", 30), "name": rel.Set("name", "Luffy")}, rel.OnConflictIgnore())
+	assert.Nil(t, err)
+	assert.EqualValues(t, 30, id)
+
+	// ignored, last insert id doesn't belong to this record.
+	id, err = adapter.Insert(ctx, query, "id", map[string]rel.Mutate{"id": rel.Set("id", 30), "name": rel.Set("name", "Zoro")}, rel.OnConflictIgnore())
+	assert.Nil(t, err)
+	assert.Nil(t, id)
+
+	// replaced, can be either inserted or updated.
+	id, err = adapter.Insert(ctx, query, "id", map[string]rel.Mutate{"id": rel.Set("id", 30), "name": rel.Set("name", "Zoro")}, rel.OnConflictKeyReplace("id"))
+	assert.Nil(t, err)
+	assert.Nil(t, id)
+}
+
+func TestAdapter_InsertAll_onConflict(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+		query   = rel.From("names")
+		fields  = []string{"name"}
+	)
+	defer adapter.Close()
+
+	_, _, err := adapter.Exec(ctx, "INSERT INTO names (id, name) VALUES (40, 'Luffy');", nil)
+	assert.Nil(t, err)
+
+	// every records are inserted.
+	ids, err := adapter.InsertAll(ctx, query, "id", fields, []map[string]rel.Mutate{
+		{"name": rel.Set("name", "Zoro")},
+		{"name": rel.Set("name", "Sanji")},
+	}, rel.OnConflictIgnore())
+	assert.Nil(t, err)
+	assert.Len(t, ids, 2)
+
+	// one of the record is skipped.
+	ids, err = adapter.InsertAll(ctx, query, "id", []string{"id", "name"}, []map[string]rel.Mutate{
+		{"id": rel.Set("id", 40), "name": rel.Set("name", "Luffy")},
+		{"id": rel.Set("id", 60), "name": rel.Set("name", "Nami")},
+	}, rel.OnConflictIgnore())
+	assert.Nil(t, err)
+	assert.Nil(t, ids)
+
+	ids, err = adapter.InsertAll(ctx, query, "id", []string{"id", "name"}, []map[string]rel.Mutate{
+		{"id": rel.Set("id", 40), "name": rel.Set("name", "Luffy")},
+	}, rel.OnConflictKeyReplace("id"))
+	assert.Nil(t, err)
+	assert.Nil(t, ids)
+}
+
 func TestAdapter_Update(t *testing.T) {
 	var (
 		adapter = open(t)
@@ -295,7 +400,7 @@ func TestAdapter_InsertAll_error(t *testing.T) {
 		{"notexist": rel.Set("notexist", "12")},
 	}
 
-	ids, err := adapter.InsertAll(context.TODO(), rel.Query{}, "id", fields, mutations)
+	ids, err := adapter.InsertAll(context.TODO(), rel.Query{}, "id", fields, mutations, rel.OnConflict{})
 	assert.NotNil(t, err)
 	assert.Nil(t, ids)
 }
@@ -363,3 +468,21 @@ func TestAdapter_Apply(t *testing.T) {
 		adapter.Apply(ctx, rel.Raw("SELECT 1;"))
 	})
 }
+
+func TestAdapter_MigrationStatement(t *testing.T) {
+	var (
+		adapter = New(Config{EscapeChar: "`", MapColumnFunc: MapColumn})
+	)
+
+	assert.Equal(t, "CREATE TABLE `tests` (`username` VARCHAR(255));", adapter.MigrationStatement(rel.Table{
+		Name:        "tests",
+		Definitions: []rel.TableDefinition{rel.Column{Name: "username", Type: rel.String}},
+	}))
+	assert.Equal(t, "CREATE INDEX `username_idx` ON `tests` (`username`);", adapter.MigrationStatement(rel.Index{
+		Name:    "username_idx",
+		Table:   "tests",
+		Columns: []string{"username"},
+	}))
+	assert.Equal(t, "SELECT 1;", adapter.MigrationStatement(rel.Raw("SELECT 1;")))
+	assert.Equal(t, "", adapter.MigrationStatement(rel.Do(nil)))
+}
@@ -12,6 +12,9 @@ import (
 // UnescapeCharacter disable field escaping when it starts with this character.
 var UnescapeCharacter byte = '^'
 
+// PartitionRowNumber is the column name of row number used to limit records for each partition.
+const PartitionRowNumber = "rel_row_number"
+
 var fieldCache sync.Map
 
 // Builder defines information of query b.
@@ -156,6 +159,8 @@ func (b *Builder) column(buffer *Buffer, column rel.Column) {
 			buffer.WriteByte('\'')
 			buffer.WriteString(v)
 			buffer.WriteByte('\'')
+		case rel.Raw:
+			buffer.WriteString(string(v))
 		default:
 			// TODO: improve
 			bytes, _ := json.Marshal(column.Default)
@@ -284,18 +289,78 @@ func (b *Builder) Find(query rel.Query) (string, []interface{}) {
 
 	// TODO: calculate arguments size and if possible buffer size
 
-	b.fields(&buffer, query.SelectQuery.OnlyDistinct, query.SelectQuery.Fields)
-	b.query(&buffer, query)
+	b.with(&buffer, query.WithQuery)
+
+	if query.PartitionQuery.Field != "" && query.PartitionQuery.Limit > 0 {
+		b.partition(&buffer, query)
+	} else {
+		b.fields(&buffer, query.SelectQuery)
+		b.query(&buffer, query)
+	}
+
+	buffer.WriteString(";")
 
 	return buffer.String(), buffer.Arguments
 }
 
+// partition writes query that limits the number of records for each partition.
+// Records are numbered using ROW_NUMBER when window function is supported,
+// otherwise limit and offset are omitted and records are expected to be limited by the cursor.
+func (b *Builder) partition(buffer *Buffer, query rel.Query) {
+	if !b.config.WindowFunction {
+		query.LimitQuery, query.OffsetQuery = 0, 0
+		b.fields(buffer, query.SelectQuery)
+		b.query(buffer, query)
+		return
+	}
+
+	var (
+		selectQuery = query.SelectQuery
+		sorts       = query.SortQuery
+		limit       = query.LimitQuery
+		offset      = query.OffsetQuery
+		lock        = query.LockQuery
+		rowNumber   = rel.NewRowNumber().Over(rel.Window{
+			Partition: []string{query.PartitionQuery.Field},
+			Sort:      sorts,
+		}).As(PartitionRowNumber)
+	)
+
+	if len(selectQuery.Fields) == 0 && len(selectQuery.Exprs) == 0 {
+		selectQuery.Fields = []string{query.Table + ".*"}
+	}
+
+	selectQuery.Exprs = append(append([]rel.SelectExpr(nil), selectQuery.Exprs...), rowNumber)
+
+	buffer.WriteString("SELECT * FROM (")
+	b.fields(buffer, selectQuery)
+
+	query.SortQuery, query.LimitQuery, query.OffsetQuery, query.LockQuery = nil, 0, 0, ""
+	b.query(buffer, query)
+
+	buffer.WriteString(") AS ")
+	buffer.WriteString(Escape(b.config, query.Table))
+	buffer.WriteString(" WHERE ")
+	buffer.WriteString(Escape(b.config, PartitionRowNumber))
+	buffer.WriteString("<=")
+	buffer.WriteString(strconv.Itoa(query.PartitionQuery.Limit))
+
+	b.orderBy(buffer, sorts)
+	b.limitOffset(buffer, limit, offset)
+
+	if lock != "" {
+		buffer.WriteByte(' ')
+		buffer.WriteString(string(lock))
+	}
+}
+
 // Aggregate generates query for aggregation.
 func (b *Builder) Aggregate(query rel.Query, mode string, field string) (string, []interface{}) {
 	var (
 		buffer Buffer
 	)
 
+	b.with(&buffer, query.WithQuery)
 	buffer.WriteString("SELECT ")
 	buffer.WriteString(mode)
 	buffer.WriteByte('(')
@@ -309,10 +374,65 @@ func (b *Builder) Aggregate(query rel.Query, mode string, field string) (string,
 	}
 
 	b.query(&buffer, query)
+	buffer.WriteString(";")
 
 	return buffer.String(), buffer.Arguments
 }
 
+// subQuery writes nested select query wrapped in parentheses, using the same buffer to keep placeholder ordering.
+func (b *Builder) subQuery(buffer *Buffer, query rel.Query) {
+	buffer.WriteByte('(')
+
+	if query.SQLQuery.Statement != "" {
+		buffer.WriteString(strings.TrimSuffix(query.SQLQuery.Statement, ";"))
+		buffer.Append(query.SQLQuery.Values...)
+	} else {
+		b.with(buffer, query.WithQuery)
+		b.fields(buffer, query.SelectQuery)
+		b.query(buffer, query)
+	}
+
+	buffer.WriteByte(')')
+}
+
+func (b *Builder) with(buffer *Buffer, withs []rel.WithQuery) {
+	if len(withs) == 0 {
+		return
+	}
+
+	buffer.WriteString("WITH ")
+
+	// recursive keyword applies to the whole with clause.
+	for _, with := range withs {
+		if with.Recursive {
+			buffer.WriteString("RECURSIVE ")
+			break
+		}
+	}
+
+	for i, with := range withs {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		buffer.WriteString(Escape(b.config, with.Name))
+		buffer.WriteString(" AS ")
+		b.subQuery(buffer, with.Query)
+	}
+
+	buffer.WriteByte(' ')
+}
+
+func (b *Builder) unionAll(buffer *Buffer, unions []rel.Query) {
+	for _, union := range unions {
+		buffer.WriteString(" UNION ALL ")
+
+		// compound select can't be wrapped in parentheses in some database (eg: sqlite3).
+		b.fields(buffer, union.SelectQuery)
+		b.query(buffer, union)
+	}
+}
+
 func (b *Builder) query(buffer *Buffer, query rel.Query) {
 	b.from(buffer, query.Table)
 	b.join(buffer, query.Table, query.JoinQuery)
@@ -323,6 +443,7 @@ func (b *Builder) query(buffer *Buffer, query rel.Query) {
 		b.having(buffer, query.GroupQuery.Filter)
 	}
 
+	b.unionAll(buffer, query.UnionAllQuery)
 	b.orderBy(buffer, query.SortQuery)
 	b.limitOffset(buffer, query.LimitQuery, query.OffsetQuery)
 
@@ -330,15 +451,14 @@ func (b *Builder) query(buffer *Buffer, query rel.Query) {
 		buffer.WriteByte(' ')
 		buffer.WriteString(string(query.LockQuery))
 	}
-
-	buffer.WriteString(";")
 }
 
 // Insert generates query for insert.
-func (b *Builder) Insert(table string, mutates map[string]rel.Mutate) (string, []interface{}) {
+func (b *Builder) Insert(table string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (string, []interface{}) {
 	var (
 		buffer Buffer
 		count  = len(mutates)
+		fields = make([]string, 0, count)
 	)
 
 	buffer.WriteString("INSERT INTO ")
@@ -357,6 +477,7 @@ func (b *Builder) Insert(table string, mutates map[string]rel.Mutate) (string, [
 				buffer.WriteString(field)
 				buffer.WriteString(b.config.EscapeChar)
 				buffer.Arguments[i] = mut.Value
+				fields = append(fields, field)
 			}
 
 			if i < count-1 {
@@ -378,6 +499,8 @@ func (b *Builder) Insert(table string, mutates map[string]rel.Mutate) (string, [
 		buffer.WriteByte(')')
 	}
 
+	b.onConflict(&buffer, fields, onConflict)
+
 	if b.returnField != "" {
 		buffer.WriteString(" RETURNING ")
 		buffer.WriteString(b.config.EscapeChar)
@@ -391,7 +514,7 @@ func (b *Builder) Insert(table string, mutates map[string]rel.Mutate) (string, [
 }
 
 // InsertAll generates query for multiple insert.
-func (b *Builder) InsertAll(table string, fields []string, bulkMutates []map[string]rel.Mutate) (string, []interface{}) {
+func (b *Builder) InsertAll(table string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) (string, []interface{}) {
 	var (
 		buffer       Buffer
 		fieldsCount  = len(fields)
@@ -442,6 +565,8 @@ func (b *Builder) InsertAll(table string, fields []string, bulkMutates []map[str
 		}
 	}
 
+	b.onConflict(&buffer, fields, onConflict)
+
 	if b.returnField != "" {
 		buffer.WriteString(" RETURNING ")
 		buffer.WriteString(b.config.EscapeChar)
@@ -454,6 +579,127 @@ func (b *Builder) InsertAll(table string, fields []string, bulkMutates []map[str
 	return buffer.String(), buffer.Arguments
 }
 
+func (b *Builder) onConflict(buffer *Buffer, fields []string, onConflict rel.OnConflict) {
+	if !onConflict.Ignore && !onConflict.Replace {
+		return
+	}
+
+	if b.config.OnDuplicateKey {
+		b.onDuplicateKey(buffer, fields, onConflict)
+		return
+	}
+
+	buffer.WriteString(" ON CONFLICT")
+
+	if len(onConflict.Keys) > 0 {
+		buffer.WriteString(" (")
+		for i, key := range onConflict.Keys {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.WriteString(Escape(b.config, key))
+		}
+		buffer.WriteByte(')')
+	}
+
+	if !onConflict.Ignore {
+		fields = conflictFields(fields, onConflict)
+	}
+
+	if onConflict.Ignore || len(fields) == 0 {
+		buffer.WriteString(" DO NOTHING")
+		return
+	}
+
+	buffer.WriteString(" DO UPDATE SET ")
+
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		field = Escape(b.config, field)
+		buffer.WriteString(field)
+		buffer.WriteString("=EXCLUDED.")
+		buffer.WriteString(field)
+	}
+}
+
+func (b *Builder) onDuplicateKey(buffer *Buffer, fields []string, onConflict rel.OnConflict) {
+	if onConflict.Ignore {
+		// mysql doesn't support ignoring only duplicate error, reassign a field to itself instead.
+		var (
+			field string
+		)
+
+		if len(onConflict.Keys) > 0 {
+			field = onConflict.Keys[0]
+		} else if len(fields) > 0 {
+			field = fields[0]
+		} else {
+			return
+		}
+
+		field = Escape(b.config, field)
+		buffer.WriteString(" ON DUPLICATE KEY UPDATE ")
+		buffer.WriteString(field)
+		buffer.WriteByte('=')
+		buffer.WriteString(field)
+		return
+	}
+
+	fields = conflictFields(fields, onConflict)
+	if len(fields) == 0 {
+		return
+	}
+
+	buffer.WriteString(" ON DUPLICATE KEY UPDATE ")
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		field = Escape(b.config, field)
+		buffer.WriteString(field)
+		buffer.WriteString("=VALUES(")
+		buffer.WriteString(field)
+		buffer.WriteByte(')')
+	}
+}
+
+// conflictFields returns fields to be replaced on conflict, conflict keys are never replaced unless it's the only fields available.
+func conflictFields(fields []string, onConflict rel.OnConflict) []string {
+	if len(onConflict.Fields) > 0 {
+		return onConflict.Fields
+	}
+
+	var (
+		result = make([]string, 0, len(fields))
+	)
+
+	for _, field := range fields {
+		var (
+			key = false
+		)
+
+		for i := range onConflict.Keys {
+			if key = onConflict.Keys[i] == field; key {
+				break
+			}
+		}
+
+		if !key {
+			result = append(result, field)
+		}
+	}
+
+	if len(result) == 0 {
+		return fields
+	}
+
+	return result
+}
+
 // Update generates query for update.
 func (b *Builder) Update(table string, mutates map[string]rel.Mutate, filter rel.FilterQuery) (string, []interface{}) {
 	var (
@@ -518,9 +764,9 @@ func (b *Builder) Delete(table string, filter rel.FilterQuery) (string, []interf
 	return buffer.String(), buffer.Arguments
 }
 
-func (b *Builder) fields(buffer *Buffer, distinct bool, fields []string) {
-	if len(fields) == 0 {
-		if distinct {
+func (b *Builder) fields(buffer *Buffer, selectQuery rel.SelectQuery) {
+	if len(selectQuery.Fields) == 0 && len(selectQuery.Exprs) == 0 {
+		if selectQuery.OnlyDistinct {
 			buffer.WriteString("SELECT DISTINCT *")
 			return
 		}
@@ -530,20 +776,104 @@ func (b *Builder) fields(buffer *Buffer, distinct bool, fields []string) {
 
 	buffer.WriteString("SELECT ")
 
-	if distinct {
+	if selectQuery.OnlyDistinct {
 		buffer.WriteString("DISTINCT ")
 	}
 
-	l := len(fields) - 1
-	for i, f := range fields {
+	for i, f := range selectQuery.Fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
 		buffer.WriteString(Escape(b.config, f))
+	}
 
-		if i < l {
+	for i, expr := range selectQuery.Exprs {
+		if i > 0 || len(selectQuery.Fields) > 0 {
 			buffer.WriteByte(',')
 		}
+
+		b.selectExpr(buffer, expr)
 	}
 }
 
+func (b *Builder) selectExpr(buffer *Buffer, expr rel.SelectExpr) {
+	switch expr.Type {
+	case rel.SelectFieldOp:
+		buffer.WriteString(Escape(b.config, expr.Field))
+	case rel.SelectAggregateOp:
+		buffer.WriteString(strings.ToUpper(expr.Function))
+		buffer.WriteByte('(')
+		buffer.WriteString(Escape(b.config, expr.Field))
+		buffer.WriteByte(')')
+	case rel.SelectRowNumberOp:
+		buffer.WriteString("ROW_NUMBER()")
+	case rel.SelectCaseOp:
+		buffer.WriteString("CASE")
+
+		for _, c := range expr.Cases {
+			buffer.WriteString(" WHEN ")
+			b.filter(buffer, c.When)
+			buffer.WriteString(" THEN ")
+			b.selectValue(buffer, c.Then)
+		}
+
+		if expr.Else != nil {
+			buffer.WriteString(" ELSE ")
+			b.selectValue(buffer, expr.Else)
+		}
+
+		buffer.WriteString(" END")
+	}
+
+	if expr.Window != nil {
+		b.window(buffer, *expr.Window)
+	}
+
+	if expr.Alias != "" {
+		buffer.WriteString(" AS ")
+		buffer.WriteString(b.config.EscapeChar)
+		buffer.WriteString(expr.Alias)
+		buffer.WriteString(b.config.EscapeChar)
+	}
+}
+
+func (b *Builder) selectValue(buffer *Buffer, value interface{}) {
+	if expr, ok := value.(rel.SelectExpr); ok {
+		b.selectExpr(buffer, expr)
+		return
+	}
+
+	buffer.WriteString(b.ph())
+	buffer.Append(value)
+}
+
+func (b *Builder) window(buffer *Buffer, window rel.Window) {
+	buffer.WriteString(" OVER (")
+
+	if len(window.Partition) > 0 {
+		buffer.WriteString("PARTITION BY ")
+
+		for i, f := range window.Partition {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+
+			buffer.WriteString(Escape(b.config, f))
+		}
+	}
+
+	if len(window.Sort) > 0 {
+		if len(window.Partition) > 0 {
+			buffer.WriteByte(' ')
+		}
+
+		b.sorts(buffer, window.Sort)
+	}
+
+	buffer.WriteByte(')')
+}
+
 func (b *Builder) from(buffer *Buffer, table string) {
 	buffer.WriteString(" FROM ")
 	buffer.WriteString(b.config.EscapeChar)
@@ -575,9 +905,7 @@ func (b *Builder) join(buffer *Buffer, table string, joins []rel.JoinQuery) {
 		buffer.WriteByte(' ')
 
 		if join.Table != "" {
-			buffer.WriteString(b.config.EscapeChar)
-			buffer.WriteString(join.Table)
-			buffer.WriteString(b.config.EscapeChar)
+			buffer.WriteString(Escape(b.config, join.Table))
 			buffer.WriteString(" ON ")
 			buffer.WriteString(from)
 			buffer.WriteString("=")
@@ -628,7 +956,16 @@ func (b *Builder) orderBy(buffer *Buffer, orders []rel.SortQuery) {
 		return
 	}
 
-	buffer.WriteString(" ORDER BY")
+	buffer.WriteByte(' ')
+	b.sorts(buffer, orders)
+}
+
+func (b *Builder) sorts(buffer *Buffer, orders []rel.SortQuery) {
+	var (
+		length = len(orders)
+	)
+
+	buffer.WriteString("ORDER BY")
 	for i, order := range orders {
 		buffer.WriteByte(' ')
 		buffer.WriteString(Escape(b.config, order.Field))
@@ -695,6 +1032,12 @@ func (b *Builder) filter(buffer *Buffer, filter rel.FilterQuery) {
 	case rel.FilterFragmentOp:
 		buffer.WriteString(filter.Field)
 		buffer.Append(filter.Value.([]interface{})...)
+	case rel.FilterExistsOp:
+		buffer.WriteString("EXISTS ")
+		b.subQuery(buffer, filter.Value.(rel.Query))
+	case rel.FilterNotExistsOp:
+		buffer.WriteString("NOT EXISTS ")
+		b.subQuery(buffer, filter.Value.(rel.Query))
 	}
 }
 
@@ -740,6 +1083,11 @@ func (b *Builder) buildComparison(buffer *Buffer, filter rel.FilterQuery) {
 		buffer.WriteString(">=")
 	}
 
+	if query, ok := filter.Value.(rel.Query); ok {
+		b.subQuery(buffer, query)
+		return
+	}
+
 	buffer.WriteString(b.ph())
 	buffer.Append(filter.Value)
 }
@@ -752,11 +1100,19 @@ func (b *Builder) buildInclusion(buffer *Buffer, filter rel.FilterQuery) {
 	buffer.WriteString(Escape(b.config, filter.Field))
 
 	if filter.Type == rel.FilterInOp {
-		buffer.WriteString(" IN (")
+		buffer.WriteString(" IN ")
 	} else {
-		buffer.WriteString(" NOT IN (")
+		buffer.WriteString(" NOT IN ")
+	}
+
+	if len(values) == 1 {
+		if query, ok := values[0].(rel.Query); ok {
+			b.subQuery(buffer, query)
+			return
+		}
 	}
 
+	buffer.WriteByte('(')
 	buffer.WriteString(b.ph())
 	for i := 1; i <= len(values)-1; i++ {
 		buffer.WriteByte(',')
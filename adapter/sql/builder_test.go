@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/expr"
 	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
 	"github.com/stretchr/testify/assert"
@@ -59,7 +60,7 @@ func TestBuilder_Table(t *testing.T) {
 			},
 		},
 		{
-			result: "CREATE TABLE `columns` (`bool` BOOL NOT NULL DEFAULT false, `int` INT(11) UNSIGNED, `bigint` BIGINT(20) UNSIGNED, `float` FLOAT(24) UNSIGNED, `decimal` DECIMAL(6,2) UNSIGNED, `string` VARCHAR(144) UNIQUE, `text` TEXT(1000), `date` DATE, `datetime` DATETIME, `time` TIME, `timestamp` TIMESTAMP DEFAULT '2020-01-01 01:00:00', `blob` blob, PRIMARY KEY (`int`), FOREIGN KEY (`int`, `string`) REFERENCES `products` (`id`, `name`) ON DELETE CASCADE ON UPDATE CASCADE, UNIQUE `date_unique` (`date`)) Engine=InnoDB;",
+			result: "CREATE TABLE `columns` (`bool` BOOL NOT NULL DEFAULT false, `int` INT(11) UNSIGNED, `bigint` BIGINT(20) UNSIGNED, `float` FLOAT(24) UNSIGNED, `decimal` DECIMAL(6,2) UNSIGNED, `string` VARCHAR(144) UNIQUE, `text` TEXT(1000), `binary` BLOB, `date` DATE, `datetime` DATETIME DEFAULT CURRENT_TIMESTAMP, `time` TIME, `timestamp` TIMESTAMP DEFAULT '2020-01-01 01:00:00', `blob` blob, PRIMARY KEY (`int`), FOREIGN KEY (`int`, `string`) REFERENCES `products` (`id`, `name`) ON DELETE CASCADE ON UPDATE CASCADE, UNIQUE `date_unique` (`date`)) Engine=InnoDB;",
 			table: rel.Table{
 				Op:   rel.SchemaCreate,
 				Name: "columns",
@@ -71,8 +72,9 @@ func TestBuilder_Table(t *testing.T) {
 					rel.Column{Name: "decimal", Type: rel.Decimal, Precision: 6, Scale: 2, Unsigned: true},
 					rel.Column{Name: "string", Type: rel.String, Limit: 144, Unique: true},
 					rel.Column{Name: "text", Type: rel.Text, Limit: 1000},
+					rel.Column{Name: "binary", Type: rel.Binary},
 					rel.Column{Name: "date", Type: rel.Date},
-					rel.Column{Name: "datetime", Type: rel.DateTime},
+					rel.Column{Name: "datetime", Type: rel.DateTime, Default: rel.Raw("CURRENT_TIMESTAMP")},
 					rel.Column{Name: "time", Type: rel.Time},
 					rel.Column{Name: "timestamp", Type: rel.Timestamp, Default: time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)},
 					rel.Column{Name: "blob", Type: "blob"},
@@ -307,6 +309,62 @@ func TestBuilder_Find(t *testing.T) {
 			nil,
 			query.Offset(10).Limit(10),
 		},
+		{
+			"WITH `adults` AS (SELECT * FROM `users` WHERE `age`>=?) SELECT * FROM `adults` WHERE `name`=?;",
+			[]interface{}{18, "foo"},
+			rel.With("adults", query.Where(where.Gte("age", 18))).From("adults").Where(where.Eq("name", "foo")),
+		},
+		{
+			"WITH RECURSIVE `tree` AS (SELECT * FROM `categories` WHERE `id`=? UNION ALL SELECT `categories`.* FROM `categories` JOIN `tree` ON `tree`.`id`=`categories`.`parent_id`) SELECT * FROM `tree` ORDER BY `id` ASC;",
+			[]interface{}{1},
+			rel.WithRecursive("tree", rel.From("categories").Where(where.Eq("id", 1)).UnionAll(rel.Select("categories.*").From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))).From("tree").SortAsc("id"),
+		},
+		{
+			"SELECT * FROM `users` WHERE `group_id` IN (?,?) ORDER BY `id` DESC;",
+			[]interface{}{1, 2},
+			query.Where(where.In("group_id", 1, 2)).SortDesc("id").Limit(5).LimitPartition("group_id", 3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.QueryString, func(t *testing.T) {
+			var (
+				builder  = NewBuilder(config)
+				qs, args = builder.Find(test.Query)
+			)
+
+			assert.Equal(t, test.QueryString, qs)
+			assert.Equal(t, test.Args, args)
+		})
+	}
+}
+
+func TestBuilder_Find_partition(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder:    "$",
+			EscapeChar:     "\"",
+			Ordinal:        true,
+			WindowFunction: true,
+		}
+		query = rel.From("users").Where(where.In("group_id", 1, 2)).LimitPartition("group_id", 3)
+	)
+
+	tests := []struct {
+		QueryString string
+		Args        []interface{}
+		Query       rel.Query
+	}{
+		{
+			"SELECT * FROM (SELECT \"users\".*,ROW_NUMBER() OVER (PARTITION BY \"group_id\") AS \"rel_row_number\" FROM \"users\" WHERE \"group_id\" IN ($1,$2)) AS \"users\" WHERE \"rel_row_number\"<=3;",
+			[]interface{}{1, 2},
+			query,
+		},
+		{
+			"SELECT * FROM (SELECT \"id\",\"group_id\",ROW_NUMBER() OVER (PARTITION BY \"group_id\" ORDER BY \"id\" DESC) AS \"rel_row_number\" FROM \"users\" WHERE \"group_id\" IN ($1,$2)) AS \"users\" WHERE \"rel_row_number\"<=3 ORDER BY \"id\" DESC LIMIT 5 OFFSET 1;",
+			[]interface{}{1, 2},
+			query.Select("id", "group_id").SortDesc("id").Limit(5).Offset(1),
+		},
 	}
 
 	for _, test := range tests {
@@ -388,6 +446,27 @@ func TestBuilder_Find_ordinal(t *testing.T) {
 			nil,
 			query.Offset(10).Limit(10),
 		},
+		{
+			"SELECT * FROM \"users\" WHERE (\"age\">$1 AND \"id\" IN (SELECT \"user_id\" FROM \"orders\" WHERE (\"status\"=$2 AND \"total\">$3)) AND \"name\"<>$4);",
+			[]interface{}{18, "paid", 100, "admin"},
+			query.Where(
+				where.Gt("age", 18),
+				where.In("id", rel.Select("user_id").From("orders").Where(where.Eq("status", "paid"), where.Gt("total", 100))),
+				where.Ne("name", "admin"),
+			),
+		},
+		{
+			"WITH RECURSIVE \"active\" AS (SELECT * FROM \"users\" WHERE \"active\"=$1),\"tree\" AS (SELECT * FROM \"categories\" WHERE \"id\"=$2 UNION ALL SELECT \"categories\".* FROM \"categories\" JOIN \"tree\" ON \"tree\".\"id\"=\"categories\".\"parent_id\" WHERE \"categories\".\"depth\"<$3) SELECT * FROM \"tree\" WHERE \"name\"<>$4;",
+			[]interface{}{true, 1, 5, "root"},
+			rel.With("active", query.Where(where.Eq("active", true))).
+				WithRecursive("tree", rel.From("categories").Where(where.Eq("id", 1)).UnionAll(rel.Select("categories.*").From("categories").JoinOn("tree", "tree.id", "categories.parent_id").Where(where.Lt("categories.depth", 5)))).
+				From("tree").Where(where.Ne("name", "root")),
+		},
+		{
+			"SELECT * FROM \"users\" WHERE (NOT EXISTS (SELECT * FROM \"orders\" WHERE \"status\"=$1) OR \"age\"<$2) LIMIT 10;",
+			[]interface{}{"paid", 18},
+			query.Where(where.NotExists(rel.From("orders").Where(where.Eq("status", "paid"))).OrLt("age", 18)).Limit(10),
+		},
 	}
 
 	for _, test := range tests {
@@ -450,6 +529,10 @@ func TestBuilder_Aggregate(t *testing.T) {
 	qs, args = builder.Aggregate(query.Group("gender"), "sum", "transactions.total")
 	assert.Nil(t, args)
 	assert.Equal(t, "SELECT sum(`transactions`.`total`) AS sum,`gender` FROM `users` GROUP BY `gender`;", qs)
+
+	qs, args = builder.Aggregate(rel.With("adults", query.Where(where.Gte("age", 18))).From("adults"), "count", "*")
+	assert.Equal(t, []interface{}{18}, args)
+	assert.Equal(t, "WITH `adults` AS (SELECT * FROM `users` WHERE `age`>=?) SELECT count(*) AS count FROM `adults`;", qs)
 }
 
 func BenchmarkBuilder_Insert(b *testing.B) {
@@ -467,7 +550,7 @@ func BenchmarkBuilder_Insert(b *testing.B) {
 	)
 
 	for n := 0; n < b.N; n++ {
-		builder.Insert("users", mutates)
+		builder.Insert("users", mutates, rel.OnConflict{})
 	}
 }
 
@@ -483,7 +566,7 @@ func TestBuilder_Insert(t *testing.T) {
 			"age":   rel.Set("age", 10),
 			"agree": rel.Set("agree", true),
 		}
-		qs, args = builder.Insert("users", mutates)
+		qs, args = builder.Insert("users", mutates, rel.OnConflict{})
 	)
 
 	assert.Regexp(t, fmt.Sprint(`^INSERT INTO `, "`users`", ` \((`, "`", `\w*`, "`", `,?){3}\) VALUES \(\?,\?,\?\);`), qs)
@@ -507,7 +590,7 @@ func TestBuilder_Insert_ordinal(t *testing.T) {
 			"age":   rel.Set("age", 10),
 			"agree": rel.Set("agree", true),
 		}
-		qs, args = builder.Returning("id").Insert("users", mutates)
+		qs, args = builder.Returning("id").Insert("users", mutates, rel.OnConflict{})
 	)
 
 	assert.Regexp(t, `^INSERT INTO \"users\" \(("\w*",?){3}\) VALUES \(\$1,\$2,\$3\) RETURNING \"id\";`, qs)
@@ -526,7 +609,7 @@ func TestBuilder_Insert_defaultValuesDisabled(t *testing.T) {
 		}
 		builder  = NewBuilder(config)
 		mutates  = map[string]rel.Mutate{}
-		qs, args = builder.Insert("users", mutates)
+		qs, args = builder.Insert("users", mutates, rel.OnConflict{})
 	)
 
 	assert.Equal(t, "INSERT INTO `users` () VALUES ();", qs)
@@ -542,13 +625,80 @@ func TestBuilder_Insert_defaultValuesEnabled(t *testing.T) {
 		}
 		builder  = NewBuilder(config)
 		mutates  = map[string]rel.Mutate{}
-		qs, args = builder.Returning("id").Insert("users", mutates)
+		qs, args = builder.Returning("id").Insert("users", mutates, rel.OnConflict{})
 	)
 
 	assert.Equal(t, "INSERT INTO `users` DEFAULT VALUES RETURNING `id`;", qs)
 	assert.Nil(t, args)
 }
 
+func TestBuilder_Insert_onConflict(t *testing.T) {
+	var (
+		mutates = map[string]rel.Mutate{
+			"name": rel.Set("name", "foo"),
+		}
+	)
+
+	tests := []struct {
+		result     string
+		config     Config
+		onConflict rel.OnConflict
+	}{
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT DO NOTHING RETURNING \"id\";",
+			config:     Config{Placeholder: "$", EscapeChar: "\"", Ordinal: true},
+			onConflict: rel.OnConflictIgnore(),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT (\"name\") DO NOTHING RETURNING \"id\";",
+			config:     Config{Placeholder: "$", EscapeChar: "\"", Ordinal: true},
+			onConflict: rel.OnConflictKeyIgnore("name"),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT (\"id\") DO UPDATE SET \"name\"=EXCLUDED.\"name\" RETURNING \"id\";",
+			config:     Config{Placeholder: "$", EscapeChar: "\"", Ordinal: true},
+			onConflict: rel.OnConflictKeyReplace("id"),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT (\"name\") DO UPDATE SET \"name\"=EXCLUDED.\"name\" RETURNING \"id\";",
+			config:     Config{Placeholder: "$", EscapeChar: "\"", Ordinal: true},
+			onConflict: rel.OnConflictKeyReplace("name"),
+		},
+		{
+			result:     "INSERT INTO \"users\" (\"name\") VALUES ($1) ON CONFLICT (\"name\") DO UPDATE SET \"age\"=EXCLUDED.\"age\" RETURNING \"id\";",
+			config:     Config{Placeholder: "$", EscapeChar: "\"", Ordinal: true},
+			onConflict: rel.OnConflictKeyUpdate("name", "age"),
+		},
+		{
+			result:     "INSERT INTO `users` (`name`) VALUES (?) ON DUPLICATE KEY UPDATE `name`=`name` RETURNING `id`;",
+			config:     Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+			onConflict: rel.OnConflictIgnore(),
+		},
+		{
+			result:     "INSERT INTO `users` (`name`) VALUES (?) ON DUPLICATE KEY UPDATE `id`=`id` RETURNING `id`;",
+			config:     Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+			onConflict: rel.OnConflictKeyIgnore("id"),
+		},
+		{
+			result:     "INSERT INTO `users` (`name`) VALUES (?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`) RETURNING `id`;",
+			config:     Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true},
+			onConflict: rel.OnConflictReplace(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				builder  = NewBuilder(test.config)
+				qs, args = builder.Returning("id").Insert("users", mutates, test.onConflict)
+			)
+
+			assert.Equal(t, test.result, qs)
+			assert.Equal(t, []interface{}{"foo"}, args)
+		})
+	}
+}
+
 func BenchmarkBuilder_InsertAll(b *testing.B) {
 	var (
 		config = Config{
@@ -571,7 +721,7 @@ func BenchmarkBuilder_InsertAll(b *testing.B) {
 	)
 
 	for n := 0; n < b.N; n++ {
-		builder.InsertAll("users", []string{"name"}, bulkMutates)
+		builder.InsertAll("users", []string{"name"}, bulkMutates, rel.OnConflict{})
 	}
 }
 
@@ -596,12 +746,12 @@ func TestBuilder_InsertAll(t *testing.T) {
 		}
 	)
 
-	statement, args := builder.InsertAll("users", []string{"name"}, bulkMutates)
+	statement, args := builder.InsertAll("users", []string{"name"}, bulkMutates, rel.OnConflict{})
 	assert.Equal(t, "INSERT INTO `users` (`name`) VALUES (?),(DEFAULT),(?);", statement)
 	assert.Equal(t, []interface{}{"foo", "boo"}, args)
 
 	// with age
-	statement, args = builder.InsertAll("users", []string{"name", "age"}, bulkMutates)
+	statement, args = builder.InsertAll("users", []string{"name", "age"}, bulkMutates, rel.OnConflict{})
 	assert.Equal(t, "INSERT INTO `users` (`name`,`age`) VALUES (?,DEFAULT),(DEFAULT,?),(?,?);", statement)
 	assert.Equal(t, []interface{}{"foo", 10, "boo", 20}, args)
 }
@@ -629,17 +779,49 @@ func TestBuilder_InsertAll_ordinal(t *testing.T) {
 		}
 	)
 
-	statement, args := builder.Returning("id").InsertAll("users", []string{"name"}, bulkMutates)
+	statement, args := builder.Returning("id").InsertAll("users", []string{"name"}, bulkMutates, rel.OnConflict{})
 	assert.Equal(t, "INSERT INTO \"users\" (\"name\") VALUES ($1),(DEFAULT),($2) RETURNING \"id\";", statement)
 	assert.Equal(t, []interface{}{"foo", "boo"}, args)
 
 	// with age
 	builder.count = 0
-	statement, args = builder.Returning("id").InsertAll("users", []string{"name", "age"}, bulkMutates)
+	statement, args = builder.Returning("id").InsertAll("users", []string{"name", "age"}, bulkMutates, rel.OnConflict{})
 	assert.Equal(t, "INSERT INTO \"users\" (\"name\",\"age\") VALUES ($1,DEFAULT),(DEFAULT,$2),($3,$4) RETURNING \"id\";", statement)
 	assert.Equal(t, []interface{}{"foo", 10, "boo", 20}, args)
 }
 
+func TestBuilder_InsertAll_onConflict(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder:         "$",
+			EscapeChar:          "\"",
+			Ordinal:             true,
+			InsertDefaultValues: true,
+		}
+		builder     = NewBuilder(config)
+		bulkMutates = []map[string]rel.Mutate{
+			{
+				"id":   rel.Set("id", 1),
+				"name": rel.Set("name", "foo"),
+			},
+			{
+				"id":   rel.Set("id", 2),
+				"name": rel.Set("name", "boo"),
+			},
+		}
+	)
+
+	statement, args := builder.Returning("id").InsertAll("users", []string{"id", "name"}, bulkMutates, rel.OnConflictKeyReplace("id"))
+	assert.Equal(t, "INSERT INTO \"users\" (\"id\",\"name\") VALUES ($1,$2),($3,$4) ON CONFLICT (\"id\") DO UPDATE SET \"name\"=EXCLUDED.\"name\" RETURNING \"id\";", statement)
+	assert.Equal(t, []interface{}{1, "foo", 2, "boo"}, args)
+
+	// on duplicate key
+	builder = NewBuilder(Config{Placeholder: "?", EscapeChar: "`", OnDuplicateKey: true})
+	statement, args = builder.InsertAll("users", []string{"id", "name"}, bulkMutates, rel.OnConflictKeyReplace("id"))
+	assert.Equal(t, "INSERT INTO `users` (`id`,`name`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`);", statement)
+	assert.Equal(t, []interface{}{1, "foo", 2, "boo"}, args)
+}
+
 func TestBuilder_Update(t *testing.T) {
 	var (
 		config = Config{
@@ -792,6 +974,10 @@ func TestBuilder_Select(t *testing.T) {
 			result: "SELECT SUM(`transactions`.`total`) AS total",
 			fields: []string{"SUM(transactions.total) AS total"},
 		},
+		{
+			result: "SELECT `transactions`.*,`buyer`.`id` AS `buyer.id`",
+			fields: []string{"transactions.*", "buyer.id AS buyer.id"},
+		},
 	}
 
 	for _, test := range tests {
@@ -800,7 +986,7 @@ func TestBuilder_Select(t *testing.T) {
 				buffer Buffer
 			)
 
-			builder.fields(&buffer, test.distinct, test.fields)
+			builder.fields(&buffer, rel.SelectQuery{OnlyDistinct: test.distinct, Fields: test.fields})
 			assert.Equal(t, test.result, buffer.String())
 		})
 	}
@@ -820,6 +1006,73 @@ func TestBuilder_From(t *testing.T) {
 	assert.Equal(t, " FROM `users`", buffer.String())
 }
 
+func TestBuilder_SelectExpr(t *testing.T) {
+	var (
+		config = Config{
+			Placeholder: "$",
+			EscapeChar:  "\"",
+			Ordinal:     true,
+		}
+	)
+
+	tests := []struct {
+		result string
+		args   []interface{}
+		query  rel.Query
+	}{
+		{
+			result: "SELECT \"name\"",
+			query:  rel.Build("", expr.Field("name")),
+		},
+		{
+			result: "SELECT \"user_id\",SUM(\"total\") AS \"total\",COUNT(*) AS \"count\"",
+			query:  rel.Select("user_id").SelectExpr(expr.Sum("total").As("total"), expr.Count("*").As("count")),
+		},
+		{
+			result: "SELECT DISTINCT AVG(\"transactions\".\"total\")",
+			query:  rel.Build("", rel.Select().Distinct(), expr.Avg("transactions.total")),
+		},
+		{
+			result: "SELECT \"id\",COUNT(*) OVER (PARTITION BY \"user_id\") AS \"user_count\"",
+			query:  rel.Select("id").SelectExpr(expr.Count("*").Over(expr.PartitionBy("user_id")).As("user_count")),
+		},
+		{
+			result: "SELECT ROW_NUMBER() OVER (PARTITION BY \"user_id\",\"status\" ORDER BY \"created_at\" DESC, \"id\" ASC) AS \"rank\"",
+			query:  rel.Build("", expr.RowNumber().Over(expr.PartitionBy("user_id", "status").SortDesc("created_at").SortAsc("id")).As("rank")),
+		},
+		{
+			result: "SELECT SUM(\"total\") OVER (ORDER BY \"id\" ASC) AS \"running_total\"",
+			query:  rel.Build("", expr.Sum("total").Over(expr.PartitionBy().SortAsc("id")).As("running_total")),
+		},
+		{
+			result: "SELECT MAX(\"total\") OVER () AS \"max_total\"",
+			query:  rel.Build("", expr.Max("total").Over(expr.PartitionBy()).As("max_total")),
+		},
+		{
+			result: "SELECT \"id\",CASE WHEN \"total\">=$1 THEN $2 WHEN \"total\">=$3 THEN $4 ELSE \"status\" END AS \"tier\"",
+			args:   []interface{}{1000, "gold", 500, "silver"},
+			query: rel.Select("id").SelectExpr(expr.Case().
+				When(where.Gte("total", 1000), "gold").
+				When(where.Gte("total", 500), "silver").
+				ElseValue(expr.Field("status")).
+				As("tier")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.result, func(t *testing.T) {
+			var (
+				buffer  Buffer
+				builder = NewBuilder(config)
+			)
+
+			builder.fields(&buffer, test.query.SelectQuery)
+			assert.Equal(t, test.result, buffer.String())
+			assert.Equal(t, test.args, buffer.Arguments)
+		})
+	}
+}
+
 func TestBuilder_Join(t *testing.T) {
 	var (
 		config = Config{
@@ -853,6 +1106,10 @@ func TestBuilder_Join(t *testing.T) {
 			rel.From("transactions").JoinOn("users", "users.id", "transactions.user_id").
 				JoinOn("payments", "payments.id", "transactions.payment_id"),
 		},
+		{
+			" LEFT JOIN `users` AS `buyer` ON `transactions`.`user_id`=`buyer`.`id`",
+			rel.From("transactions").JoinWith("LEFT JOIN", "users AS buyer", "transactions.user_id", "buyer.id"),
+		},
 	}
 
 	for _, test := range tests {
@@ -1251,6 +1508,36 @@ func TestBuilder_Filter(t *testing.T) {
 			[]interface{}{"%value1%", "%value2%"},
 			where.And(where.Like("field1", "%value1%"), where.NotLike("field2", "%value2%")),
 		},
+		{
+			"`id` IN (SELECT `user_id` FROM `orders` WHERE `status`=?)",
+			[]interface{}{"paid"},
+			where.In("id", rel.Select("user_id").From("orders").Where(where.Eq("status", "paid"))),
+		},
+		{
+			"`id` NOT IN (SELECT `user_id` FROM `orders`)",
+			nil,
+			where.Nin("id", rel.Select("user_id").From("orders")),
+		},
+		{
+			"`score`>(SELECT AVG(score) FROM `users`)",
+			nil,
+			where.Gt("score", rel.Select("^AVG(score)").From("users")),
+		},
+		{
+			"EXISTS (SELECT * FROM `orders` WHERE `orders`.`user_id`=`users`.`id`)",
+			nil,
+			where.Exists(rel.From("orders").Where(where.Fragment("`orders`.`user_id`=`users`.`id`"))),
+		},
+		{
+			"NOT EXISTS (SELECT * FROM `orders` WHERE `status`=?)",
+			[]interface{}{"paid"},
+			where.NotExists(rel.From("orders").Where(where.Eq("status", "paid"))),
+		},
+		{
+			"`id` IN (SELECT `user_id` FROM `orders` WHERE status=?)",
+			[]interface{}{"paid"},
+			where.In("id", rel.Build("", rel.SQL("SELECT `user_id` FROM `orders` WHERE status=?;", "paid"))),
+		},
 		{
 			"",
 			nil,
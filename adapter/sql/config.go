@@ -7,11 +7,14 @@ import (
 )
 
 // Config holds configuration for adapter.
+// WindowFunction enables ROW_NUMBER to limit records for each partition, otherwise records are limited while reading the cursor.
 type Config struct {
 	Placeholder         string
 	Ordinal             bool
 	InsertDefaultValues bool
 	DropIndexOnTable    bool
+	OnDuplicateKey      bool
+	WindowFunction      bool
 	EscapeChar          string
 	ErrorFunc           func(error) error
 	IncrementFunc       func(Adapter) int
@@ -54,6 +57,9 @@ func MapColumn(column *rel.Column) (string, int, int) {
 	case rel.Text:
 		typ = "TEXT"
 		m = column.Limit
+	case rel.Binary:
+		typ = "BLOB"
+		m = column.Limit
 	case rel.Date:
 		typ = "DATE"
 		timeLayout = "2006-01-02"
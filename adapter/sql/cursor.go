@@ -2,6 +2,10 @@ package sql
 
 import (
 	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/Fs02/rel"
 )
 
 // Cursor used for retrieving result.
@@ -18,3 +22,99 @@ func (c *Cursor) Fields() ([]string, error) {
 func (c *Cursor) NopScanner() interface{} {
 	return &sql.RawBytes{}
 }
+
+// PartitionCursor limits the number of rows for each distinct value of partition column while reading the result.
+// It's used when database doesn't support window function, limit and offset of the query are applied after the partition limit.
+type PartitionCursor struct {
+	*Cursor
+	partition int
+	limit     int
+	offset    int
+	count     int
+	key       interface{}
+	scanners  []interface{}
+	counts    map[interface{}]int
+}
+
+// Next prepares the next row that is still within the limit of its partition.
+func (pc *PartitionCursor) Next() bool {
+	for pc.Cursor.Next() {
+		if pc.limit > 0 && pc.count >= pc.offset+pc.limit {
+			return false
+		}
+
+		if err := pc.Cursor.Scan(pc.scanners...); err != nil {
+			// let the error returned by the actual scan.
+			return true
+		}
+
+		key := pc.key
+		if b, ok := key.([]byte); ok {
+			key = string(b)
+		}
+
+		pc.counts[key]++
+		if pc.counts[key] > pc.partition {
+			continue
+		}
+
+		pc.count++
+		if pc.limit > 0 && pc.count <= pc.offset {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// NewPartitionCursor wraps rows to limit the number of rows for each partition.
+func NewPartitionCursor(rows *sql.Rows, query rel.Query) (*PartitionCursor, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	var (
+		field = query.PartitionQuery.Field
+		cur   = &PartitionCursor{
+			Cursor:    &Cursor{rows},
+			partition: query.PartitionQuery.Limit,
+			limit:     int(query.LimitQuery),
+			offset:    int(query.OffsetQuery),
+			scanners:  make([]interface{}, len(columns)),
+			counts:    make(map[interface{}]int),
+		}
+		found = false
+	)
+
+	if i := strings.LastIndexByte(field, '.'); i >= 0 {
+		field = field[i+1:]
+	}
+
+	for i := range columns {
+		if !found && columns[i] == field {
+			found = true
+			cur.scanners[i] = &cur.key
+		} else {
+			cur.scanners[i] = discard{}
+		}
+	}
+
+	if !found {
+		rows.Close()
+		return nil, errors.New("rel: partition field " + field + " is not selected")
+	}
+
+	return cur, nil
+}
+
+// discard scanner ignores the scanned value.
+// Unlike sql.RawBytes, it allows the same row to be scanned again.
+type discard struct{}
+
+func (discard) Scan(interface{}) error {
+	return nil
+}
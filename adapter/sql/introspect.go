@@ -0,0 +1,306 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Fs02/rel"
+)
+
+var (
+	reColumnType = regexp.MustCompile(`^([a-z0-9 ]*[a-z0-9])\s*(?:\((\d+)(?:\s*,\s*(\d+))?\))?$`)
+	reCast       = regexp.MustCompile(`::[a-z ]+$`)
+)
+
+// QueryStrings performs raw query and reads all rows as nullable strings.
+// It's intended for reading database schema, where each row is small and the rows are loaded at once.
+func (a *Adapter) QueryStrings(ctx context.Context, statement string, args []interface{}) ([][]sql.NullString, error) {
+	finish := a.Instrumenter.Observe(ctx, "adapter-query", statement)
+	rows, err := a.query(ctx, statement, args)
+	finish(err)
+
+	if err != nil {
+		return nil, a.Config.ErrorFunc(err)
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result   [][]sql.NullString
+		scanners = make([]interface{}, len(columns))
+	)
+
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		for i := range values {
+			scanners[i] = &values[i]
+		}
+
+		if err := rows.Scan(scanners...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, values)
+	}
+
+	return result, rows.Err()
+}
+
+// IntrospectColumn builds column definition from database type, it's the reverse of MapColumn.
+// Unknown type is kept as is using upper cased type name, limit is only kept for string column.
+func IntrospectColumn(name string, typ string, required bool, def sql.NullString) rel.Column {
+	var (
+		column = rel.Column{
+			Op:       rel.SchemaCreate,
+			Name:     name,
+			Required: required,
+		}
+		fields = strings.Fields(strings.ToLower(typ))
+	)
+
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == "unsigned" {
+			column.Unsigned = true
+			fields = append(fields[:i], fields[i+1:]...)
+			i--
+		}
+	}
+
+	var (
+		result = reColumnType.FindStringSubmatch(strings.Join(fields, " "))
+	)
+
+	if def.Valid {
+		column.Default = IntrospectDefault(def.String)
+	}
+
+	if result == nil {
+		column.Type = rel.ColumnType(strings.ToUpper(typ))
+		return column
+	}
+
+	var (
+		base = result[1]
+		m, _ = strconv.Atoi(result[2])
+		n, _ = strconv.Atoi(result[3])
+	)
+
+	switch base {
+	case "bool", "boolean":
+		column.Type = rel.Bool
+	case "tinyint":
+		column.Type = rel.Int
+		if m == 1 {
+			column.Type = rel.Bool
+			column.Default = introspectBoolDefault(column.Default)
+		}
+	case "int", "integer", "smallint", "mediumint", "int2", "int4":
+		column.Type = rel.Int
+	case "bigint", "int8":
+		column.Type = rel.BigInt
+	case "float", "real", "double", "double precision", "float4", "float8":
+		column.Type = rel.Float
+	case "decimal", "numeric":
+		column.Type = rel.Decimal
+		column.Precision = m
+		column.Scale = n
+	case "varchar", "character varying", "char", "character":
+		column.Type = rel.String
+		column.Limit = m
+	case "text", "tinytext", "mediumtext", "longtext":
+		column.Type = rel.Text
+	case "blob", "tinyblob", "mediumblob", "longblob", "bytea":
+		column.Type = rel.Binary
+	case "date":
+		column.Type = rel.Date
+	case "datetime", "timestamptz", "timestamp with time zone":
+		column.Type = rel.DateTime
+	case "time", "time without time zone":
+		column.Type = rel.Time
+	case "timestamp", "timestamp without time zone":
+		column.Type = rel.Timestamp
+	default:
+		column.Type = rel.ColumnType(strings.ToUpper(typ))
+	}
+
+	return column
+}
+
+// introspectBoolDefault converts 0 and 1 default of tinyint(1) column to bool.
+func introspectBoolDefault(value interface{}) interface{} {
+	if i, ok := value.(int); ok && (i == 0 || i == 1) {
+		return i == 1
+	}
+
+	return value
+}
+
+// IntrospectDefault converts default value literal into go value.
+// Quoted literal is returned as string, while unquoted literal is converted to bool, int or float when possible.
+// Other value such as function call is returned as raw expression.
+func IntrospectDefault(value string) interface{} {
+	value = strings.TrimSpace(value)
+	literal := reCast.ReplaceAllString(value, "")
+
+	if len(literal) >= 2 && literal[0] == '\'' && literal[len(literal)-1] == '\'' {
+		return strings.ReplaceAll(literal[1:len(literal)-1], "''", "'")
+	}
+
+	if strings.EqualFold(literal, "null") {
+		return nil
+	}
+
+	if b, err := strconv.ParseBool(literal); err == nil && !strings.ContainsAny(literal, "01") {
+		return b
+	}
+
+	if i, err := strconv.Atoi(literal); err == nil {
+		return i
+	}
+
+	if f, err := strconv.ParseFloat(literal, 64); err == nil {
+		return f
+	}
+
+	return rel.Raw(value)
+}
+
+// KeyColumn is a column of a key, used to build introspected keys.
+type KeyColumn struct {
+	Name      string
+	Type      rel.KeyType
+	Column    string
+	RefTable  string
+	RefColumn string
+	OnUpdate  string
+	OnDelete  string
+}
+
+// IntrospectKeys groups key columns into keys by its type and name.
+// Name of primary key is omitted, as well as the default NO ACTION referential action.
+func IntrospectKeys(columns []KeyColumn) []rel.Key {
+	var (
+		keys  []rel.Key
+		index = make(map[string]int)
+	)
+
+	for _, kc := range columns {
+		id := string(kc.Type) + ":" + kc.Name
+		if i, ok := index[id]; ok {
+			keys[i].Columns = append(keys[i].Columns, kc.Column)
+			if kc.RefColumn != "" {
+				keys[i].Reference.Columns = append(keys[i].Reference.Columns, kc.RefColumn)
+			}
+
+			continue
+		}
+
+		key := rel.Key{
+			Op:      rel.SchemaCreate,
+			Name:    kc.Name,
+			Type:    kc.Type,
+			Columns: []string{kc.Column},
+		}
+
+		switch kc.Type {
+		case rel.PrimaryKey:
+			key.Name = ""
+		case rel.ForeignKey:
+			key.Reference = rel.ForeignKeyReference{
+				Table:    kc.RefTable,
+				Columns:  []string{kc.RefColumn},
+				OnUpdate: referentialAction(kc.OnUpdate),
+				OnDelete: referentialAction(kc.OnDelete),
+			}
+		}
+
+		index[id] = len(keys)
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func referentialAction(action string) string {
+	action = strings.ToUpper(strings.TrimSpace(action))
+	if action == "NO ACTION" || action == "NONE" {
+		return ""
+	}
+
+	return action
+}
+
+// IndexColumn is a column of an index, used to build introspected indexes.
+type IndexColumn struct {
+	Name   string
+	Unique bool
+	Column string
+}
+
+// IntrospectIndexes groups index columns into indexes by its name.
+func IntrospectIndexes(table string, columns []IndexColumn) []rel.Index {
+	var (
+		indexes []rel.Index
+		index   = make(map[string]int)
+	)
+
+	for _, ic := range columns {
+		if i, ok := index[ic.Name]; ok {
+			indexes[i].Columns = append(indexes[i].Columns, ic.Column)
+			continue
+		}
+
+		index[ic.Name] = len(indexes)
+		indexes = append(indexes, rel.Index{
+			Op:      rel.SchemaCreate,
+			Table:   table,
+			Name:    ic.Name,
+			Unique:  ic.Unique,
+			Columns: []string{ic.Column},
+		})
+	}
+
+	return indexes
+}
+
+// IntrospectTable builds table definition using the columns and keys.
+// Primary key of a single ID column is omitted, since it's already part of the column definition.
+func IntrospectTable(name string, columns []rel.Column, keys []rel.Key) rel.Table {
+	var (
+		table = rel.Table{
+			Op:          rel.SchemaCreate,
+			Name:        name,
+			Definitions: make([]rel.TableDefinition, 0, len(columns)+len(keys)),
+		}
+		ids = make(map[string]bool)
+	)
+
+	for i := range columns {
+		if columns[i].Type == rel.ID {
+			ids[columns[i].Name] = true
+			columns[i].Required = false
+			columns[i].Default = nil
+			columns[i].Unsigned = false
+		}
+
+		table.Definitions = append(table.Definitions, columns[i])
+	}
+
+	for i := range keys {
+		if keys[i].Type == rel.PrimaryKey && len(keys[i].Columns) == 1 && ids[keys[i].Columns[0]] {
+			continue
+		}
+
+		table.Definitions = append(table.Definitions, keys[i])
+	}
+
+	return table
+}
@@ -0,0 +1,188 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdapter_QueryStrings(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		adapter = open(t)
+	)
+
+	defer adapter.Close()
+
+	_, _, err := adapter.Exec(ctx, "INSERT INTO names (id, name) VALUES (1000, 'query strings'), (1001, NULL);", nil)
+	assert.Nil(t, err)
+
+	rows, err := adapter.QueryStrings(ctx, "SELECT id, name FROM names WHERE id >= ? ORDER BY id;", []interface{}{1000})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]sql.NullString{
+		{{String: "1000", Valid: true}, {String: "query strings", Valid: true}},
+		{{String: "1001", Valid: true}, {}},
+	}, rows)
+
+	_, err = adapter.QueryStrings(ctx, "SELECT * FROM unknown_table;", nil)
+	assert.NotNil(t, err)
+}
+
+func TestIntrospectColumn(t *testing.T) {
+	tests := []struct {
+		typ    string
+		column rel.Column
+	}{
+		{typ: "BOOL", column: rel.Column{Type: rel.Bool}},
+		{typ: "boolean", column: rel.Column{Type: rel.Bool}},
+		{typ: "tinyint(1)", column: rel.Column{Type: rel.Bool}},
+		{typ: "tinyint(4)", column: rel.Column{Type: rel.Int}},
+		{typ: "INTEGER", column: rel.Column{Type: rel.Int}},
+		{typ: "int(11) unsigned", column: rel.Column{Type: rel.Int, Unsigned: true}},
+		{typ: "unsigned bigint", column: rel.Column{Type: rel.BigInt, Unsigned: true}},
+		{typ: "bigint(20)", column: rel.Column{Type: rel.BigInt}},
+		{typ: "double precision", column: rel.Column{Type: rel.Float}},
+		{typ: "REAL", column: rel.Column{Type: rel.Float}},
+		{typ: "DECIMAL(10,2)", column: rel.Column{Type: rel.Decimal, Precision: 10, Scale: 2}},
+		{typ: "numeric", column: rel.Column{Type: rel.Decimal}},
+		{typ: "VARCHAR(255)", column: rel.Column{Type: rel.String, Limit: 255}},
+		{typ: "character varying(30)", column: rel.Column{Type: rel.String, Limit: 30}},
+		{typ: "TEXT", column: rel.Column{Type: rel.Text}},
+		{typ: "longtext", column: rel.Column{Type: rel.Text}},
+		{typ: "BLOB", column: rel.Column{Type: rel.Binary}},
+		{typ: "bytea", column: rel.Column{Type: rel.Binary}},
+		{typ: "DATE", column: rel.Column{Type: rel.Date}},
+		{typ: "DATETIME", column: rel.Column{Type: rel.DateTime}},
+		{typ: "timestamp with time zone", column: rel.Column{Type: rel.DateTime}},
+		{typ: "TIME", column: rel.Column{Type: rel.Time}},
+		{typ: "timestamp without time zone", column: rel.Column{Type: rel.Timestamp}},
+		{typ: "jsonb", column: rel.Column{Type: rel.ColumnType("JSONB")}},
+		{typ: "enum('a','b')", column: rel.Column{Type: rel.ColumnType("ENUM('A','B')")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.typ, func(t *testing.T) {
+			test.column.Op = rel.SchemaCreate
+			test.column.Name = "column"
+			assert.Equal(t, test.column, IntrospectColumn("column", test.typ, false, sql.NullString{}))
+		})
+	}
+}
+
+func TestIntrospectColumn_requiredDefault(t *testing.T) {
+	assert.Equal(t, rel.Column{
+		Op:       rel.SchemaCreate,
+		Name:     "age",
+		Type:     rel.Int,
+		Required: true,
+		Default:  0,
+	}, IntrospectColumn("age", "INTEGER", true, sql.NullString{String: "0", Valid: true}))
+
+	assert.Equal(t, rel.Column{
+		Op:       rel.SchemaCreate,
+		Name:     "active",
+		Type:     rel.Bool,
+		Required: true,
+		Default:  false,
+	}, IntrospectColumn("active", "tinyint(1)", true, sql.NullString{String: "0", Valid: true}))
+}
+
+func TestIntrospectDefault(t *testing.T) {
+	assert.Equal(t, "", IntrospectDefault("''"))
+	assert.Equal(t, "it's", IntrospectDefault("'it''s'"))
+	assert.Equal(t, "draft", IntrospectDefault("'draft'::character varying"))
+	assert.Equal(t, nil, IntrospectDefault("NULL"))
+	assert.Equal(t, true, IntrospectDefault("true"))
+	assert.Equal(t, false, IntrospectDefault("FALSE"))
+	assert.Equal(t, 0, IntrospectDefault("0"))
+	assert.Equal(t, 1, IntrospectDefault("1"))
+	assert.Equal(t, -10, IntrospectDefault("-10"))
+	assert.Equal(t, 1.5, IntrospectDefault("1.5"))
+	assert.Equal(t, rel.Raw("CURRENT_TIMESTAMP"), IntrospectDefault("CURRENT_TIMESTAMP"))
+	assert.Equal(t, rel.Raw("now()"), IntrospectDefault("now()"))
+	assert.Equal(t, rel.Raw("uuid_generate_v4()"), IntrospectDefault("uuid_generate_v4()"))
+	assert.Equal(t, rel.Raw("('now'::text)::date"), IntrospectDefault("('now'::text)::date"))
+}
+
+func TestIntrospectKeys(t *testing.T) {
+	assert.Equal(t, []rel.Key{
+		{Op: rel.SchemaCreate, Type: rel.PrimaryKey, Columns: []string{"id", "tenant_id"}},
+		{Op: rel.SchemaCreate, Name: "users_slug_key", Type: rel.UniqueKey, Columns: []string{"slug"}},
+		{
+			Op:      rel.SchemaCreate,
+			Name:    "users_team_fk",
+			Type:    rel.ForeignKey,
+			Columns: []string{"team_id", "tenant_id"},
+			Reference: rel.ForeignKeyReference{
+				Table:    "teams",
+				Columns:  []string{"id", "tenant_id"},
+				OnDelete: "CASCADE",
+			},
+		},
+	}, IntrospectKeys([]KeyColumn{
+		{Name: "PRIMARY", Type: rel.PrimaryKey, Column: "id"},
+		{Name: "PRIMARY", Type: rel.PrimaryKey, Column: "tenant_id"},
+		{Name: "users_slug_key", Type: rel.UniqueKey, Column: "slug"},
+		{Name: "users_team_fk", Type: rel.ForeignKey, Column: "team_id", RefTable: "teams", RefColumn: "id", OnUpdate: "NO ACTION", OnDelete: "cascade"},
+		{Name: "users_team_fk", Type: rel.ForeignKey, Column: "tenant_id", RefTable: "teams", RefColumn: "tenant_id", OnUpdate: "NO ACTION", OnDelete: "cascade"},
+	}))
+}
+
+func TestIntrospectIndexes(t *testing.T) {
+	assert.Equal(t, []rel.Index{
+		{Op: rel.SchemaCreate, Table: "users", Name: "users_name_idx", Columns: []string{"first_name", "last_name"}},
+		{Op: rel.SchemaCreate, Table: "users", Name: "users_email_idx", Unique: true, Columns: []string{"email"}},
+	}, IntrospectIndexes("users", []IndexColumn{
+		{Name: "users_name_idx", Column: "first_name"},
+		{Name: "users_name_idx", Column: "last_name"},
+		{Name: "users_email_idx", Unique: true, Column: "email"},
+	}))
+}
+
+func TestIntrospectTable(t *testing.T) {
+	var (
+		columns = []rel.Column{
+			{Op: rel.SchemaCreate, Name: "id", Type: rel.ID, Required: true, Unsigned: true, Default: 0},
+			{Op: rel.SchemaCreate, Name: "name", Type: rel.String},
+		}
+		keys = []rel.Key{
+			{Op: rel.SchemaCreate, Type: rel.PrimaryKey, Columns: []string{"id"}},
+			{Op: rel.SchemaCreate, Type: rel.UniqueKey, Columns: []string{"name"}},
+		}
+	)
+
+	assert.Equal(t, rel.Table{
+		Op:   rel.SchemaCreate,
+		Name: "users",
+		Definitions: []rel.TableDefinition{
+			rel.Column{Op: rel.SchemaCreate, Name: "id", Type: rel.ID},
+			rel.Column{Op: rel.SchemaCreate, Name: "name", Type: rel.String},
+			rel.Key{Op: rel.SchemaCreate, Type: rel.UniqueKey, Columns: []string{"name"}},
+		},
+	}, IntrospectTable("users", columns, keys))
+}
+
+func TestIntrospectTable_compositePrimaryKey(t *testing.T) {
+	var (
+		columns = []rel.Column{
+			{Op: rel.SchemaCreate, Name: "user_id", Type: rel.Int, Required: true},
+			{Op: rel.SchemaCreate, Name: "role_id", Type: rel.Int, Required: true},
+		}
+		keys = []rel.Key{
+			{Op: rel.SchemaCreate, Type: rel.PrimaryKey, Columns: []string{"user_id", "role_id"}},
+		}
+	)
+
+	assert.Equal(t, rel.Table{
+		Op:   rel.SchemaCreate,
+		Name: "user_roles",
+		Definitions: []rel.TableDefinition{
+			columns[0],
+			columns[1],
+			keys[0],
+		},
+	}, IntrospectTable("user_roles", columns, keys))
+}
@@ -39,6 +39,8 @@ func Escape(config Config, field string) string {
 		escapedField = field[1:]
 	} else if start, end := strings.IndexRune(field, '('), strings.IndexRune(field, ')'); start >= 0 && end >= 0 && end > start {
 		escapedField = field[:start+1] + Escape(config, field[start+1:end]) + field[end:]
+	} else if i := strings.Index(field, " AS "); i >= 0 {
+		escapedField = Escape(config, field[:i]) + " AS " + config.EscapeChar + field[i+4:] + config.EscapeChar
 	} else if strings.HasSuffix(field, "*") {
 		escapedField = config.EscapeChar + strings.Replace(field, ".", config.EscapeChar+".", 1)
 	} else {
@@ -0,0 +1,174 @@
+package sqlite3
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/adapter/sql"
+)
+
+var _ rel.Introspector = (*Adapter)(nil)
+
+// Tables returns definition of every tables in the database.
+func (a *Adapter) Tables(ctx context.Context) ([]rel.Table, error) {
+	rows, err := a.QueryStrings(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name;", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]rel.Table, len(rows))
+	for i := range rows {
+		if tables[i], err = a.table(ctx, rows[i][0].String); err != nil {
+			return nil, err
+		}
+	}
+
+	return tables, nil
+}
+
+func (a *Adapter) table(ctx context.Context, name string) (rel.Table, error) {
+	// cid, name, type, notnull, dflt_value, pk
+	rows, err := a.QueryStrings(ctx, "PRAGMA table_info("+sql.Escape(a.Config, name)+");", nil)
+	if err != nil {
+		return rel.Table{}, err
+	}
+
+	var (
+		columns = make([]rel.Column, len(rows))
+		primary = make([]string, len(rows))
+		rowid   = -1
+		count   = 0
+	)
+
+	for i, row := range rows {
+		columns[i] = sql.IntrospectColumn(row[1].String, row[2].String, row[3].String == "1", row[4])
+
+		// pk is the position of the column in primary key.
+		if pk, _ := strconv.Atoi(row[5].String); pk > 0 && pk <= len(rows) {
+			primary[pk-1] = row[1].String
+			count++
+
+			// integer primary key is an alias of rowid, which is auto increment.
+			if strings.EqualFold(row[2].String, "INTEGER") {
+				rowid = i
+			}
+		}
+	}
+
+	primary = primary[:count]
+	if count == 1 && rowid >= 0 {
+		columns[rowid].Type = rel.ID
+	}
+
+	keys, err := a.keys(ctx, name)
+	if err != nil {
+		return rel.Table{}, err
+	}
+
+	if count > 0 {
+		keys = append([]rel.Key{{Op: rel.SchemaCreate, Type: rel.PrimaryKey, Columns: primary}}, keys...)
+	}
+
+	return sql.IntrospectTable(name, columns, keys), nil
+}
+
+func (a *Adapter) keys(ctx context.Context, table string) ([]rel.Key, error) {
+	var (
+		keyColumns []sql.KeyColumn
+	)
+
+	// seq, name, unique, origin, partial
+	indexes, err := a.QueryStrings(ctx, "PRAGMA index_list("+sql.Escape(a.Config, table)+");", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(indexes) - 1; i >= 0; i-- {
+		if indexes[i][3].String != "u" {
+			continue
+		}
+
+		columns, err := a.indexColumns(ctx, indexes[i][1].String)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, column := range columns {
+			keyColumns = append(keyColumns, sql.KeyColumn{Type: rel.UniqueKey, Name: indexes[i][1].String, Column: column})
+		}
+	}
+
+	// id, seq, table, from, to, on_update, on_delete, match
+	foreignKeys, err := a.QueryStrings(ctx, "PRAGMA foreign_key_list("+sql.Escape(a.Config, table)+");", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range foreignKeys {
+		keyColumns = append(keyColumns, sql.KeyColumn{
+			Type:      rel.ForeignKey,
+			Name:      row[0].String,
+			Column:    row[3].String,
+			RefTable:  row[2].String,
+			RefColumn: row[4].String,
+			OnUpdate:  row[5].String,
+			OnDelete:  row[6].String,
+		})
+	}
+
+	keys := sql.IntrospectKeys(keyColumns)
+	for i := range keys {
+		// sqlite doesn't support naming constraint, the names are generated.
+		keys[i].Name = ""
+	}
+
+	return keys, nil
+}
+
+// Indexes returns indexes of the table, excluding indexes of primary and unique keys.
+func (a *Adapter) Indexes(ctx context.Context, table string) ([]rel.Index, error) {
+	// seq, name, unique, origin, partial
+	rows, err := a.QueryStrings(ctx, "PRAGMA index_list("+sql.Escape(a.Config, table)+");", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		indexColumns []sql.IndexColumn
+	)
+
+	// index_list is ordered by creation descending.
+	for i := len(rows) - 1; i >= 0; i-- {
+		if rows[i][3].String != "c" {
+			continue
+		}
+
+		columns, err := a.indexColumns(ctx, rows[i][1].String)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, column := range columns {
+			indexColumns = append(indexColumns, sql.IndexColumn{Name: rows[i][1].String, Unique: rows[i][2].String == "1", Column: column})
+		}
+	}
+
+	return sql.IntrospectIndexes(table, indexColumns), nil
+}
+
+func (a *Adapter) indexColumns(ctx context.Context, index string) ([]string, error) {
+	// seqno, cid, name
+	rows, err := a.QueryStrings(ctx, "PRAGMA index_info("+sql.Escape(a.Config, index)+");", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, len(rows))
+	for i := range rows {
+		columns[i] = rows[i][2].String
+	}
+
+	return columns, nil
+}
@@ -33,6 +33,7 @@ var (
 		Placeholder:         "?",
 		EscapeChar:          "`",
 		InsertDefaultValues: true,
+		WindowFunction:      true,
 		IncrementFunc:       incrementFunc,
 		ErrorFunc:           errorFunc,
 		MapColumnFunc:       mapColumnFunc,
@@ -34,10 +34,16 @@ func TestAdapter_specs(t *testing.T) {
 
 	// Migration Specs
 	specs.Migrate(t, repo, specs.SkipDropColumn)
+	specs.Introspect(t, repo)
 
 	// Query Specs
 	specs.Query(t, repo)
 	specs.QueryJoin(t, repo)
+	specs.QuerySubQuery(t, repo)
+	specs.QueryWith(t, repo)
+	specs.QuerySelectExpr(t, repo)
+	specs.QueryPaginate(t, repo)
+	specs.QueryIterate(t, repo)
 	specs.QueryNotFound(t, repo)
 
 	// Preload specs
@@ -50,22 +56,31 @@ func TestAdapter_specs(t *testing.T) {
 	specs.PreloadBelongsTo(t, repo)
 	specs.PreloadBelongsToWithQuery(t, repo)
 	specs.PreloadBelongsToSlice(t, repo)
+	specs.PreloadManyToMany(t, repo)
+	specs.PreloadNested(t, repo)
+	specs.PreloadJoin(t, repo)
+	specs.PreloadLimitPerParent(t, repo)
 
 	// Aggregate Specs
 	specs.Aggregate(t, repo)
+	specs.AggregateFloat(t, repo)
+	specs.AggregateGroup(t, repo)
 
 	// Insert Specs
 	specs.Insert(t, repo)
 	specs.InsertHasMany(t, repo)
 	specs.InsertHasOne(t, repo)
 	specs.InsertBelongsTo(t, repo)
+	specs.InsertManyToMany(t, repo)
 	specs.Inserts(t, repo)
 	specs.InsertAll(t, repo)
 	// specs.InsertAllPartialCustomPrimary(t, repo) - not supported
+	specs.Upsert(t, repo)
 
 	// Update Specs
 	specs.Update(t, repo)
 	specs.UpdateNotFound(t, repo)
+	specs.UpdateStaleRecord(t, repo)
 	specs.UpdateHasManyInsert(t, repo)
 	specs.UpdateHasManyUpdate(t, repo)
 	specs.UpdateHasManyReplace(t, repo)
@@ -73,6 +88,7 @@ func TestAdapter_specs(t *testing.T) {
 	specs.UpdateHasOneUpdate(t, repo)
 	specs.UpdateBelongsToInsert(t, repo)
 	specs.UpdateBelongsToUpdate(t, repo)
+	specs.UpdateManyToMany(t, repo)
 	specs.UpdateAtomic(t, repo)
 	specs.Updates(t, repo)
 	specs.UpdateAll(t, repo)
@@ -82,6 +98,8 @@ func TestAdapter_specs(t *testing.T) {
 	specs.DeleteBelongsTo(t, repo)
 	specs.DeleteHasOne(t, repo)
 	specs.DeleteHasMany(t, repo)
+	specs.DeleteManyToMany(t, repo)
+	specs.DeleteStaleRecord(t, repo)
 	specs.DeleteAll(t, repo)
 
 	// Constraint specs
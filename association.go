@@ -2,6 +2,7 @@ package rel
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/serenize/snaker"
@@ -17,6 +18,8 @@ const (
 	HasOne
 	// HasMany association.
 	HasMany
+	// ManyToMany association.
+	ManyToMany
 )
 
 type associationKey struct {
@@ -28,9 +31,15 @@ type associationData struct {
 	typ             AssociationType
 	targetIndex     []int
 	referenceColumn string
-	referenceIndex  int
+	referenceIndex  []int
 	foreignField    string
-	foreignIndex    int
+	foreignIndex    []int
+	through         string
+	throughRef      string
+	throughFk       string
+	typeField       string
+	typeValue       string
+	typeIndex       []int
 }
 
 var associationCache sync.Map
@@ -107,6 +116,15 @@ func (a Association) IsZero() bool {
 	return isDeepZero(reflect.Indirect(rv), 1)
 }
 
+// reset association target to its zero value.
+func (a Association) reset() {
+	var (
+		rv = a.rv.FieldByIndex(a.data.targetIndex)
+	)
+
+	rv.Set(reflect.Zero(rv.Type()))
+}
+
 // ReferenceField of the association.
 func (a Association) ReferenceField() string {
 	return a.data.referenceColumn
@@ -114,7 +132,7 @@ func (a Association) ReferenceField() string {
 
 // ReferenceValue of the association.
 func (a Association) ReferenceValue() interface{} {
-	return indirect(a.rv.Field(a.data.referenceIndex))
+	return indirect(reflectValueFieldByIndex(a.rv, a.data.referenceIndex, false))
 }
 
 // ForeignField of the association.
@@ -123,10 +141,10 @@ func (a Association) ForeignField() string {
 }
 
 // ForeignValue of the association.
-// It'll panic if association type is has many.
+// It'll panic if association type is has many or many to many.
 func (a Association) ForeignValue() interface{} {
-	if a.Type() == HasMany {
-		panic("cannot infer foreign value for has many association")
+	if a.Type() == HasMany || a.Type() == ManyToMany {
+		panic("cannot infer foreign value for has many or many to many association")
 	}
 
 	var (
@@ -137,7 +155,46 @@ func (a Association) ForeignValue() interface{} {
 		rv = rv.Elem()
 	}
 
-	return indirect(rv.Field(a.data.foreignIndex))
+	return indirect(reflectValueFieldByIndex(rv, a.data.foreignIndex, false))
+}
+
+// Through returns name of the join table used by many to many association.
+func (a Association) Through() string {
+	return a.data.through
+}
+
+// ThroughReferenceField returns column in join table that references this association's reference field.
+func (a Association) ThroughReferenceField() string {
+	return a.data.throughRef
+}
+
+// ThroughForeignField returns column in join table that references this association's foreign field.
+func (a Association) ThroughForeignField() string {
+	return a.data.throughFk
+}
+
+// PolymorphicField returns column that stores owner type of polymorphic association.
+// It returns empty string if association is not polymorphic.
+func (a Association) PolymorphicField() string {
+	return a.data.typeField
+}
+
+// PolymorphicValue returns owner type of polymorphic association.
+func (a Association) PolymorphicValue() string {
+	return a.data.typeValue
+}
+
+// polymorphicMatch returns false when polymorphic belongs to association is owned by other type.
+func (a Association) polymorphicMatch() bool {
+	if a.data.typeField == "" || a.data.typ != BelongsTo {
+		return true
+	}
+
+	var (
+		rv = reflect.Indirect(reflectValueFieldByIndex(a.rv, a.data.typeIndex, false))
+	)
+
+	return rv.IsValid() && rv.Kind() == reflect.String && rv.String() == a.data.typeValue
 }
 
 func newAssociation(rv reflect.Value, index int) Association {
@@ -183,8 +240,43 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 		fkDocData  = extractDocumentData(ft, true)
 	)
 
-	// Try to guess ref and fk if not defined.
-	if ref == "" || fk == "" {
+	if polymorphic := sf.Tag.Get("polymorphic"); polymorphic != "" {
+		extractPolymorphicData(&assocData, polymorphic, rt, ft, refDocData, fkDocData)
+
+		if assocData.typeIndex != nil {
+			// belongs to, the owner id is stored in this struct.
+			if ref == "" {
+				ref = strings.TrimSuffix(assocData.typeField, "_type") + "_id"
+			}
+
+			if fk == "" {
+				fk = "id"
+			}
+		} else {
+			if ref == "" {
+				ref = "id"
+			}
+
+			if fk == "" {
+				fk = strings.TrimSuffix(assocData.typeField, "_type") + "_id"
+			}
+		}
+	}
+
+	if through := sf.Tag.Get("through"); through != "" {
+		assocData.typ = ManyToMany
+		extractThroughData(&assocData, through, rt, ft)
+
+		// many to many association references primary key on both side by default.
+		if ref == "" {
+			ref = "id"
+		}
+
+		if fk == "" {
+			fk = "id"
+		}
+	} else if assocData.typeField == "" && (ref == "" || fk == "") {
+		// Try to guess ref and fk if not defined.
 		if _, isBelongsTo := refDocData.index[fName+"_id"]; isBelongsTo {
 			ref = fName + "_id"
 			fk = "id"
@@ -209,7 +301,21 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 	}
 
 	// guess assoc type
-	if sf.Type.Kind() == reflect.Slice ||
+	if assocData.typeField != "" {
+		if assocData.typeIndex != nil {
+			assocData.typ = BelongsTo
+		} else if sf.Type.Kind() == reflect.Slice ||
+			(sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Slice) {
+			assocData.typ = HasMany
+		} else {
+			assocData.typ = HasOne
+		}
+	} else if assocData.typ == ManyToMany {
+		if sf.Type.Kind() != reflect.Slice &&
+			(sf.Type.Kind() != reflect.Ptr || sf.Type.Elem().Kind() != reflect.Slice) {
+			panic("rel: many to many association (" + fName + ") must be a slice")
+		}
+	} else if sf.Type.Kind() == reflect.Slice ||
 		(sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Slice) {
 		assocData.typ = HasMany
 	} else {
@@ -224,3 +330,50 @@ func extractAssociationData(rt reflect.Type, index int) associationData {
 
 	return assocData
 }
+
+// extractThroughData parses through tag with format `table[,reference_column[,foreign_column]]`.
+// Join table columns defaults to snake cased struct name suffixed by _id, for example: post_id and tag_id.
+func extractThroughData(assocData *associationData, through string, rt reflect.Type, ft reflect.Type) {
+	var (
+		parts = strings.Split(through, ",")
+	)
+
+	assocData.through = parts[0]
+	assocData.throughRef = snaker.CamelToSnake(rt.Name()) + "_id"
+	assocData.throughFk = snaker.CamelToSnake(ft.Name()) + "_id"
+
+	if len(parts) > 1 && parts[1] != "" {
+		assocData.throughRef = parts[1]
+	}
+
+	if len(parts) > 2 && parts[2] != "" {
+		assocData.throughFk = parts[2]
+	}
+}
+
+// extractPolymorphicData parses polymorphic tag with format `name[,type]`.
+// Owner id and type are stored in name_id and name_type columns, the type defaults to table name of the owner.
+// Association is considered as belongs to if name_type column exists in the struct, otherwise it's has one or has many.
+func extractPolymorphicData(assocData *associationData, polymorphic string, rt reflect.Type, ft reflect.Type, refDocData documentData, fkDocData documentData) {
+	var (
+		parts = strings.Split(polymorphic, ",")
+		owner = rt
+	)
+
+	assocData.typeField = parts[0] + "_type"
+
+	if index, isBelongsTo := refDocData.index[assocData.typeField]; isBelongsTo {
+		assocData.typeIndex = index
+		owner = ft
+	} else if _, exist := fkDocData.index[assocData.typeField]; !exist {
+		panic("rel: polymorphic type (" + assocData.typeField + ") field not found")
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		assocData.typeValue = parts[1]
+	} else if tn, ok := reflect.New(owner).Interface().(table); ok {
+		assocData.typeValue = tn.Table()
+	} else {
+		assocData.typeValue = tableName(owner)
+	}
+}
@@ -129,7 +129,7 @@ func TestAssociation_Document(t *testing.T) {
 			assert.Equal(t, test.referenceValue, assoc.ReferenceValue())
 			assert.Equal(t, test.foreignField, assoc.ForeignField())
 
-			if test.typ == HasMany {
+			if test.typ == HasMany || test.typ == ManyToMany {
 				assert.Panics(t, func() {
 					assert.Equal(t, test.foreignValue, assoc.ForeignValue())
 				})
@@ -148,6 +148,8 @@ func TestAssociation_Collection(t *testing.T) {
 		user        = &User{ID: 2}
 		address     = &Address{ID: 3}
 		userLoaded  = &User{ID: 2, Address: *address, Transactions: []Transaction{*transaction}}
+		post        = &Post{ID: 4}
+		postLoaded  = &Post{ID: 4, Tags: []Tag{{ID: 5}}}
 	)
 
 	tests := []struct {
@@ -189,6 +191,32 @@ func TestAssociation_Collection(t *testing.T) {
 			foreignField:   "user_id",
 			foreignValue:   nil,
 		},
+		{
+			record:         "Post",
+			field:          "Tags",
+			data:           post,
+			typ:            ManyToMany,
+			col:            NewCollection(&post.Tags),
+			loaded:         false,
+			isZero:         true,
+			referenceField: "id",
+			referenceValue: post.ID,
+			foreignField:   "id",
+			foreignValue:   nil,
+		},
+		{
+			record:         "Post",
+			field:          "Tags",
+			data:           postLoaded,
+			typ:            ManyToMany,
+			col:            NewCollection(&postLoaded.Tags),
+			loaded:         true,
+			isZero:         false,
+			referenceField: "id",
+			referenceValue: postLoaded.ID,
+			foreignField:   "id",
+			foreignValue:   nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -208,7 +236,7 @@ func TestAssociation_Collection(t *testing.T) {
 			assert.Equal(t, test.referenceValue, assoc.ReferenceValue())
 			assert.Equal(t, test.foreignField, assoc.ForeignField())
 
-			if test.typ == HasMany {
+			if test.typ == HasMany || test.typ == ManyToMany {
 				assert.Panics(t, func() {
 					assert.Equal(t, test.foreignValue, assoc.ForeignValue())
 				})
@@ -250,3 +278,104 @@ func TestAssociation_fkNotFound(t *testing.T) {
 		NewDocument(&Beta{})
 	})
 }
+
+func TestAssociation_through(t *testing.T) {
+	type Group struct {
+		ID   int
+		Code string
+	}
+
+	type Member struct {
+		ID     int
+		Groups []Group `through:"memberships"`
+		Admins []Group `through:"administrators,member_code,group_code" ref:"id" fk:"code"`
+	}
+
+	var (
+		doc    = NewDocument(&Member{ID: 1})
+		groups = doc.Association("groups")
+		admins = doc.Association("admins")
+	)
+
+	assert.Equal(t, []string{"groups", "admins"}, doc.ManyToMany())
+
+	assert.Equal(t, AssociationType(ManyToMany), groups.Type())
+	assert.Equal(t, "memberships", groups.Through())
+	assert.Equal(t, "member_id", groups.ThroughReferenceField())
+	assert.Equal(t, "group_id", groups.ThroughForeignField())
+	assert.Equal(t, "id", groups.ReferenceField())
+	assert.Equal(t, "id", groups.ForeignField())
+
+	assert.Equal(t, AssociationType(ManyToMany), admins.Type())
+	assert.Equal(t, "administrators", admins.Through())
+	assert.Equal(t, "member_code", admins.ThroughReferenceField())
+	assert.Equal(t, "group_code", admins.ThroughForeignField())
+	assert.Equal(t, "id", admins.ReferenceField())
+	assert.Equal(t, "code", admins.ForeignField())
+}
+
+func TestAssociation_throughNotSlice(t *testing.T) {
+	type Alpha struct {
+		ID int
+	}
+
+	type Beta struct {
+		ID    int
+		Alpha Alpha `through:"alpha_betas"`
+	}
+
+	assert.Panics(t, func() {
+		NewDocument(&Beta{})
+	})
+}
+
+func TestAssociation_polymorphic(t *testing.T) {
+	var (
+		article = NewDocument(&Article{ID: 1}).Association("remarks")
+		photo   = NewDocument(&Photo{ID: 2}).Association("remark")
+		remark  = &Remark{ID: 3, RemarkableID: 1, RemarkableType: "articles"}
+		owner   = NewDocument(remark).Association("article")
+		other   = NewDocument(remark).Association("photo")
+	)
+
+	assert.Equal(t, AssociationType(HasMany), article.Type())
+	assert.Equal(t, "id", article.ReferenceField())
+	assert.Equal(t, "remarkable_id", article.ForeignField())
+	assert.Equal(t, "remarkable_type", article.PolymorphicField())
+	assert.Equal(t, "articles", article.PolymorphicValue())
+	assert.True(t, article.polymorphicMatch())
+
+	assert.Equal(t, AssociationType(HasOne), photo.Type())
+	assert.Equal(t, "id", photo.ReferenceField())
+	assert.Equal(t, "remarkable_id", photo.ForeignField())
+	assert.Equal(t, "remarkable_type", photo.PolymorphicField())
+	assert.Equal(t, "image", photo.PolymorphicValue())
+
+	assert.Equal(t, AssociationType(BelongsTo), owner.Type())
+	assert.Equal(t, "remarkable_id", owner.ReferenceField())
+	assert.Equal(t, 1, owner.ReferenceValue())
+	assert.Equal(t, "id", owner.ForeignField())
+	assert.Equal(t, "remarkable_type", owner.PolymorphicField())
+	assert.Equal(t, "articles", owner.PolymorphicValue())
+	assert.True(t, owner.polymorphicMatch())
+
+	assert.Equal(t, AssociationType(BelongsTo), other.Type())
+	assert.Equal(t, "image", other.PolymorphicValue())
+	assert.False(t, other.polymorphicMatch())
+}
+
+func TestAssociation_polymorphicTypeNotFound(t *testing.T) {
+	type Alpha struct {
+		ID      int
+		OwnerID int
+	}
+
+	type Beta struct {
+		ID     int
+		Alphas []Alpha `polymorphic:"owner"`
+	}
+
+	assert.Panics(t, func() {
+		NewDocument(&Beta{})
+	})
+}
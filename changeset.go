@@ -86,6 +86,10 @@ func (c Changeset) Apply(doc *Document, mut *Mutation) {
 		for _, field := range doc.HasMany() {
 			c.applyAssocMany(field, mut)
 		}
+
+		for _, field := range doc.ManyToMany() {
+			c.applyAssocManyToMany(field, mut)
+		}
 	}
 }
 
@@ -152,6 +156,52 @@ func (c Changeset) applyAssocMany(field string, mut *Mutation) {
 	}
 }
 
+// applyAssocManyToMany builds mutation for every record in the association, so it can be linked by the repository.
+// Existing records that are already linked results in an empty mutation, and unlinked records are stored as deleted ids.
+func (c Changeset) applyAssocManyToMany(field string, mut *Mutation) {
+	if chs, ok := c.assocMany[field]; ok {
+		var (
+			assoc      = c.doc.Association(field)
+			col, _     = assoc.Collection()
+			changed    = false
+			muts       = make([]Mutation, col.Len())
+			linkedIDs  = make(map[interface{}]struct{})
+			deletedIDs = []interface{}{}
+		)
+
+		for i := 0; i < col.Len(); i++ {
+			var (
+				doc    = col.Get(i)
+				pValue = doc.PrimaryValue()
+			)
+
+			if _, ok := chs[pValue]; ok {
+				linkedIDs[pValue] = struct{}{}
+				muts[i] = Mutation{Cascade: true}
+			} else {
+				muts[i] = Apply(doc, newStructset(doc, false))
+				changed = true
+			}
+		}
+
+		// leftover snapshot.
+		if len(linkedIDs) != len(chs) {
+			for id := range chs {
+				if _, ok := linkedIDs[id]; !ok {
+					deletedIDs = append(deletedIDs, id)
+				}
+			}
+		}
+
+		if changed || len(deletedIDs) > 0 {
+			mut.SetAssoc(field, muts...)
+			mut.SetDeletedIDs(field, deletedIDs)
+		}
+	} else {
+		newStructset(c.doc, false).buildAssocMany(field, mut)
+	}
+}
+
 // NewChangeset returns new changeset mutator for given record.
 func NewChangeset(record interface{}) Changeset {
 	return newChangeset(NewDocument(record))
@@ -181,6 +231,10 @@ func newChangeset(doc *Document) Changeset {
 		initChangesetAssocMany(doc, c.assocMany, field)
 	}
 
+	for _, field := range doc.ManyToMany() {
+		initChangesetAssocMany(doc, c.assocMany, field)
+	}
+
 	return c
 }
 
@@ -260,6 +314,10 @@ func buildChanges(doc *Document, c Changeset) map[string]interface{} {
 		buildChangesAssocMany(changes, c, field)
 	}
 
+	for _, field := range doc.ManyToMany() {
+		buildChangesAssocMany(changes, c, field)
+	}
+
 	return changes
 }
 
@@ -160,6 +160,37 @@ func TestChangeset(t *testing.T) {
 	})
 }
 
+func TestChangeset_embedded(t *testing.T) {
+	var (
+		comment = Comment{
+			Model: Model{ID: 1},
+			Body:  "body",
+		}
+		doc       = NewDocument(&comment)
+		changeset = NewChangeset(&comment)
+	)
+
+	comment.Body = "update"
+	comment.Audit = &Audit{UpdatedBy: "admin"}
+
+	assert.Equal(t, map[string]interface{}{
+		"body":       pair{"body", "update"},
+		"created_by": pair{nil, ""},
+		"updated_by": pair{nil, "admin"},
+	}, changeset.Changes())
+
+	assert.Equal(t, Mutation{
+		Cascade: true,
+		Mutates: map[string]Mutate{
+			"body":       Set("body", "update"),
+			"created_by": Set("created_by", ""),
+			"updated_by": Set("updated_by", "admin"),
+			"updated_at": Set("updated_at", now()),
+		},
+	}, Apply(doc, changeset))
+	assert.Equal(t, now(), comment.UpdatedAt)
+}
+
 func TestChangeset_ptr(t *testing.T) {
 	var (
 		userID  = 2
@@ -527,3 +558,54 @@ func TestChangeset_hasMany(t *testing.T) {
 		}, Apply(doc, changeset))
 	})
 }
+
+func TestChangeset_manyToMany(t *testing.T) {
+	var (
+		post = Post{
+			ID: 1,
+			Tags: []Tag{
+				{ID: 11, Name: "go"},
+				{ID: 12, Name: "sql"},
+			},
+		}
+		doc       = NewDocument(&post)
+		changeset = NewChangeset(&post)
+	)
+
+	t.Run("snapshot", func(t *testing.T) {
+		tagch := changeset.assocMany["tags"]
+
+		assert.Equal(t, []interface{}{11, "go"}, tagch[11].snapshot)
+		assert.Equal(t, []interface{}{12, "sql"}, tagch[12].snapshot)
+
+		assert.Empty(t, changeset.Changes())
+	})
+
+	t.Run("apply clean", func(t *testing.T) {
+		assert.Equal(t, Mutation{
+			Cascade: true,
+		}, Apply(doc, changeset))
+	})
+
+	t.Run("apply changeset", func(t *testing.T) {
+		post.Tags[1] = Tag{Name: "rel"}
+
+		assert.Equal(t, Mutation{
+			Assoc: map[string]AssocMutation{
+				"tags": {
+					Mutations: []Mutation{
+						{Cascade: true},
+						{
+							Mutates: map[string]Mutate{
+								"name": Set("name", "rel"),
+							},
+							Cascade: true,
+						},
+					},
+					DeletedIDs: []interface{}{12},
+				},
+			},
+			Cascade: true,
+		}, Apply(doc, changeset))
+	})
+}
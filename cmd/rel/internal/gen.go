@@ -0,0 +1,509 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/serenize/snaker"
+)
+
+const accessorTemplate = `// Code generated by rel gen accessor. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .Time}}
+	"time"
+{{end}}
+	"github.com/Fs02/rel"
+)
+{{range $model := .Models}}
+{{- $r := $model.Receiver}}
+{{- if $model.Primary}}
+// PrimaryFields of {{$model.Name}}.
+func ({{$r}} {{$model.Name}}) PrimaryFields() []string {
+	return []string{ {{- range $i, $f := $model.Primary}}{{if $i}}, {{end}}"{{$f.Column}}"{{end -}} }
+}
+
+// PrimaryValues of {{$model.Name}}.
+func ({{$r}} {{$model.Name}}) PrimaryValues() []interface{} {
+	return []interface{}{ {{- range $i, $f := $model.Primary}}{{if $i}}, {{end}}{{$r}}.{{$f.Name}}{{end -}} }
+}
+{{end}}
+// FieldScanner of {{$model.Name}}.
+func ({{$r}} *{{$model.Name}}) FieldScanner(field string) (interface{}, bool) {
+	switch field {
+{{- range $model.Fields}}
+	case "{{.Column}}":
+{{- if .Pointer}}
+		return &{{$r}}.{{.Name}}, true
+{{- else}}
+		return rel.Nullable(&{{$r}}.{{.Name}}), true
+{{- end}}
+{{- end}}
+	}
+
+	return nil, false
+}
+
+// FieldValue of {{$model.Name}}.
+func ({{$r}} *{{$model.Name}}) FieldValue(field string) (interface{}, bool) {
+	switch field {
+{{- range $model.Fields}}
+	case "{{.Column}}":
+{{- if .Pointer}}
+		if {{$r}}.{{.Name}} == nil {
+			return nil, true
+		}
+
+		return *{{$r}}.{{.Name}}, true
+{{- else}}
+		return {{$r}}.{{.Name}}, true
+{{- end}}
+{{- end}}
+	}
+
+	return nil, false
+}
+
+// SetFieldValue of {{$model.Name}}.
+func ({{$r}} *{{$model.Name}}) SetFieldValue(field string, value interface{}) bool {
+	switch field {
+{{- range $model.Fields}}
+	case "{{.Column}}":
+		if val, ok := value.({{.Type}}); ok {
+{{- if .Pointer}}
+			if {{$r}}.{{.Name}} == nil {
+				{{$r}}.{{.Name}} = new({{.Type}})
+			}
+
+			*{{$r}}.{{.Name}} = val
+{{- else}}
+			{{$r}}.{{.Name}} = val
+{{- end}}
+			return true
+		}
+{{- end}}
+	}
+
+	return false
+}
+{{end}}`
+
+var predeclaredTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+type accessorField struct {
+	Name    string
+	Column  string
+	Type    string
+	Pointer bool
+}
+
+type accessorModel struct {
+	Name     string
+	Receiver string
+	Primary  []accessorField
+	Fields   []accessorField
+}
+
+// ExecGen command.
+// assumes args already validated.
+func ExecGen(ctx context.Context, args []string) error {
+	if len(args) < 3 {
+		return errors.New("rel: missing generator, available generator: accessor, migration, models")
+	}
+
+	switch args[2] {
+	case "accessor":
+		return execGenAccessor(args)
+	case "migration":
+		return execGenMigration(ctx, args)
+	case "models":
+		return execGenModels(ctx, args)
+	default:
+		return errors.New("rel: unknown generator: " + args[2])
+	}
+}
+
+func execGenAccessor(args []string) error {
+	var (
+		fs     = flag.NewFlagSet(args[2], flag.ExitOnError)
+		dir    = fs.String("dir", ".", "Path to directory containing the models")
+		types  = fs.String("type", "", "Comma separated list of type names, default to every struct with primary key")
+		output = fs.String("output", "rel_accessor_gen.go", "Output file name, relative to dir")
+		tests  = fs.Bool("tests", false, "Include models declared in test files")
+	)
+
+	fs.Parse(args[3:])
+
+	var typeNames []string
+	if *types != "" {
+		typeNames = strings.Split(*types, ",")
+	}
+
+	src, err := generateAccessor(*dir, typeNames, filepath.Base(*output), *tests)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(*dir, *output), src, 0644)
+}
+
+func generateAccessor(dir string, typeNames []string, output string, tests bool) ([]byte, error) {
+	var (
+		fset   = token.NewFileSet()
+		filter = func(fi os.FileInfo) bool {
+			return fi.Name() != output && (tests || !strings.HasSuffix(fi.Name(), "_test.go"))
+		}
+	)
+
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := selectPackage(pkgs, typeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		decls   = collectTypes(pkg)
+		models  []accessorModel
+		useTime bool
+	)
+
+	if len(typeNames) == 0 {
+		typeNames = modelTypes(pkg, decls)
+	}
+
+	for _, name := range typeNames {
+		st, ok := decls[name].(*ast.StructType)
+		if !ok {
+			return nil, errors.New("rel: struct type not found: " + name)
+		}
+
+		model := buildAccessorModel(name, st, decls, hasMethod(pkg, name, "PrimaryFields", "PrimaryValues"))
+		for _, field := range model.Fields {
+			useTime = useTime || strings.HasPrefix(field.Type, "time.")
+		}
+
+		models = append(models, model)
+	}
+
+	var (
+		buf  bytes.Buffer
+		tmpl = template.Must(template.New("accessor").Parse(accessorTemplate))
+	)
+
+	err = tmpl.Execute(&buf, struct {
+		Package string
+		Time    bool
+		Models  []accessorModel
+	}{
+		Package: pkg.Name,
+		Time:    useTime,
+		Models:  models,
+	})
+	check(err)
+
+	return format.Source(buf.Bytes())
+}
+
+func selectPackage(pkgs map[string]*ast.Package, typeNames []string) (*ast.Package, error) {
+	var names []string
+	for name := range pkgs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		pkg := pkgs[name]
+		if len(typeNames) == 0 {
+			if !strings.HasSuffix(name, "_test") {
+				return pkg, nil
+			}
+
+			continue
+		}
+
+		if _, ok := collectTypes(pkg)[typeNames[0]]; ok {
+			return pkg, nil
+		}
+	}
+
+	if len(names) > 0 && len(typeNames) == 0 {
+		return pkgs[names[0]], nil
+	}
+
+	return nil, errors.New("rel: no package containing the models found")
+}
+
+func collectTypes(pkg *ast.Package) map[string]ast.Expr {
+	decls := make(map[string]ast.Expr)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					decls[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+
+	return decls
+}
+
+// modelTypes returns sorted names of exported struct with primary key.
+// embedded struct is skipped, otherwise its methods will be promoted to the embedding struct.
+func modelTypes(pkg *ast.Package, decls map[string]ast.Expr) []string {
+	var (
+		typeNames []string
+		embedded  = embeddedTypes(decls)
+	)
+
+	for name, decl := range decls {
+		if st, ok := decl.(*ast.StructType); ok && ast.IsExported(name) && !embedded[name] &&
+			(hasPrimary(st, decls) || hasMethod(pkg, name, "PrimaryFields")) {
+			typeNames = append(typeNames, name)
+		}
+	}
+
+	sort.Strings(typeNames)
+	return typeNames
+}
+
+func embeddedTypes(decls map[string]ast.Expr) map[string]bool {
+	embedded := make(map[string]bool)
+	for _, decl := range decls {
+		st, ok := decl.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		for _, field := range st.Fields.List {
+			if len(field.Names) != 0 {
+				continue
+			}
+
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+
+			if ident, ok := typ.(*ast.Ident); ok {
+				embedded[ident.Name] = true
+			}
+		}
+	}
+
+	return embedded
+}
+
+func hasMethod(pkg *ast.Package, typeName string, methods ...string) bool {
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 {
+				continue
+			}
+
+			recv := fd.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+
+			if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+				for _, method := range methods {
+					if fd.Name.Name == method {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+func hasPrimary(st *ast.StructType, decls map[string]ast.Expr) bool {
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+
+			if ident, ok := typ.(*ast.Ident); ok {
+				if embedded, ok := decls[ident.Name].(*ast.StructType); ok && hasPrimary(embedded, decls) {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		if hasTagOption(fieldTag(field), "primary") {
+			return true
+		}
+
+		for _, name := range field.Names {
+			if strings.EqualFold(name.Name, "id") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func buildAccessorModel(name string, st *ast.StructType, decls map[string]ast.Expr, customPrimary bool) accessorModel {
+	var (
+		model = accessorModel{
+			Name:     name,
+			Receiver: strings.ToLower(name[:1]),
+		}
+		primary  []accessorField
+		fallback []accessorField
+		embedded bool
+	)
+
+	for _, field := range st.Fields.List {
+		// embedded fields are left to reflection.
+		if len(field.Names) == 0 {
+			embedded = true
+			continue
+		}
+
+		var (
+			tag                  = fieldTag(field)
+			typ, pointer, simple = accessorType(field.Type, decls)
+		)
+
+		for _, ident := range field.Names {
+			column := columnName(ident.Name, tag)
+			if !ident.IsExported() || column == "" {
+				continue
+			}
+
+			f := accessorField{
+				Name:    ident.Name,
+				Column:  column,
+				Type:    typ,
+				Pointer: pointer,
+			}
+
+			if hasTagOption(tag, "primary") {
+				primary = append(primary, f)
+			} else if strings.EqualFold(ident.Name, "id") {
+				fallback = []accessorField{f}
+			}
+
+			if simple {
+				model.Fields = append(model.Fields, f)
+			}
+		}
+	}
+
+	if len(primary) == 0 {
+		primary = fallback
+	}
+
+	// primary key might be promoted from embedded struct, or already declared.
+	if !embedded && !customPrimary {
+		model.Primary = primary
+	}
+
+	return model
+}
+
+func fieldTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	return reflect.StructTag(tag).Get("db")
+}
+
+// columnName follows the same rule as rel uses to name a field.
+func columnName(name string, tag string) string {
+	if tag != "" {
+		column := strings.Split(tag, ",")[0]
+
+		if column == "-" {
+			return ""
+		}
+
+		if column != "" {
+			return column
+		}
+	}
+
+	return snaker.CamelToSnake(name)
+}
+
+// hasTagOption follows the same rule as rel uses to parse field tag options.
+func hasTagOption(tag string, option string) bool {
+	options := strings.Split(tag, ",")
+	for i := 1; i < len(options); i++ {
+		if strings.TrimSpace(options[i]) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// accessorType returns type name of the field if it can be accessed without reflection.
+func accessorType(expr ast.Expr, decls map[string]ast.Expr) (string, bool, bool) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		typ, pointer, simple := accessorType(star.X, decls)
+		return typ, true, simple && !pointer
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if predeclaredTypes[t.Name] {
+			return t.Name, false, true
+		}
+
+		// named type within the same package with predeclared underlying type.
+		if underlying, ok := decls[t.Name].(*ast.Ident); ok && predeclaredTypes[underlying.Name] {
+			return t.Name, false, true
+		}
+
+		return t.Name, false, false
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return "time.Time", false, true
+		}
+	case *ast.ArrayType:
+		if elt, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && elt.Name == "byte" {
+			return "[]byte", false, true
+		}
+	}
+
+	return "", false, false
+}
@@ -0,0 +1,191 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/serenize/snaker"
+)
+
+const migrationFileTemplate = `package migrations
+
+import "github.com/Fs02/rel"
+
+// Migrate{{.Name}} definition
+func Migrate{{.Name}}(schema *rel.Schema) {
+{{- if .Table}}
+	schema.CreateTable("{{.Table}}", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+	})
+{{- end}}
+}
+
+// Rollback{{.Name}} definition
+func Rollback{{.Name}}(schema *rel.Schema) {
+{{- if .Table}}
+	schema.DropTable("{{.Table}}")
+{{- end}}
+}
+`
+
+const diffCommandTemplate = `migrate, rollback, err := m.Diff(ctx, {{.Drop}}{{range .Types}}, &models.{{.}}{}{{end}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(migrate.Migrations) == 0 {
+		log.Print("No difference found between models and database")
+		return
+	}
+
+	src, err := migrator.Generate({{printf "%q" .Name}}, migrate, rollback)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile({{printf "%q" .File}}, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Print("Created: ", {{printf "%q" .File}})`
+
+var (
+	reMigrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+	migrationNow    = time.Now
+)
+
+func execGenMigration(ctx context.Context, args []string) error {
+	var (
+		fs         = flag.NewFlagSet(args[2], flag.ExitOnError)
+		opts       = migratorFlags(fs)
+		fromModels = fs.String("from-models", "", "Path to directory containing the models, generates migration by comparing the models with the database")
+		drop       = fs.Bool("drop", false, "Drop columns that no longer exist in the models, only used with -from-models")
+	)
+
+	fs.Parse(args[3:])
+	if fs.NArg() == 0 {
+		return errors.New("rel: missing migration name")
+	}
+
+	// allows flags to be specified after migration name.
+	name := fs.Arg(0)
+	fs.Parse(fs.Args()[1:])
+
+	if !reMigrationName.MatchString(name) {
+		return errors.New("rel: invalid migration name: " + name)
+	}
+
+	if err := os.MkdirAll(*opts.dir, 0755); err != nil {
+		return err
+	}
+
+	file := filepath.Join(*opts.dir, migrationNow().UTC().Format("20060102150405")+"_"+name+".go")
+	if *fromModels != "" {
+		command, imports, err := getDiffCommand(*opts.module, *fromModels, snaker.SnakeToCamel(name), file, *drop)
+		if err != nil {
+			return err
+		}
+
+		return runMigrator(ctx, opts, command, false, imports)
+	}
+
+	src, err := generateMigration(name)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(file, src, 0644); err != nil {
+		return err
+	}
+
+	stdout.Write([]byte("Created: " + file + "\n"))
+	return nil
+}
+
+func generateMigration(name string) ([]byte, error) {
+	var (
+		buf   bytes.Buffer
+		table string
+		tmpl  = template.Must(template.New("migration").Parse(migrationFileTemplate))
+	)
+
+	// scaffold create table for migration named create_[table].
+	if strings.HasPrefix(name, "create_") {
+		table = strings.TrimPrefix(name, "create_")
+	}
+
+	err := tmpl.Execute(&buf, struct {
+		Name  string
+		Table string
+	}{
+		Name:  snaker.SnakeToCamel(name),
+		Table: table,
+	})
+	check(err)
+
+	return format.Source(buf.Bytes())
+}
+
+// getDiffCommand returns command that compares every models in the directory with the database and writes the migration file.
+func getDiffCommand(module string, dir string, name string, file string, drop bool) (string, []string, error) {
+	var (
+		fset   = token.NewFileSet()
+		filter = func(fi os.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}
+	)
+
+	pkgs, err := parser.ParseDir(fset, dir, filter, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pkg, err := selectPackage(pkgs, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	typeNames := modelTypes(pkg, collectTypes(pkg))
+	if len(typeNames) == 0 {
+		return "", nil, errors.New("rel: no models found in: " + dir)
+	}
+
+	var (
+		buf  strings.Builder
+		tmpl = template.Must(template.New("diff").Parse(diffCommandTemplate))
+	)
+
+	check(tmpl.Execute(&buf, struct {
+		Types []string
+		Name  string
+		File  string
+		Drop  bool
+	}{
+		Types: typeNames,
+		Name:  name,
+		File:  file,
+		Drop:  drop,
+	}))
+
+	imports := []string{
+		`"io/ioutil"`,
+		"models " + strconv.Quote(module+"/"+filepath.ToSlash(filepath.Clean(dir))),
+	}
+
+	return buf.String(), imports, nil
+}
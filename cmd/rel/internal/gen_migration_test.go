@@ -0,0 +1,239 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecGen_migration(t *testing.T) {
+	var (
+		dir  = "testdata/gen_migrations"
+		buff = &bytes.Buffer{}
+		args = []string{
+			"rel",
+			"gen",
+			"migration",
+			"create_users",
+			"-dir=" + dir,
+		}
+		file = dir + "/20200829084000_create_users.go"
+	)
+
+	migrationNow = func() time.Time { return time.Date(2020, 8, 29, 8, 40, 0, 0, time.UTC) }
+	stdout = buff
+	defer func() {
+		migrationNow = time.Now
+		stdout = os.Stdout
+		os.RemoveAll(dir)
+	}()
+
+	assert.Nil(t, ExecGen(context.TODO(), args))
+	assert.Equal(t, "Created: "+file+"\n", buff.String())
+
+	src, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Contains(t, string(src), "func MigrateCreateUsers(schema *rel.Schema) {")
+	assert.Contains(t, string(src), "func RollbackCreateUsers(schema *rel.Schema) {")
+
+	migrations, err := scanMigration(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []migration{{Version: "20200829084000", Name: "CreateUsers"}}, migrations)
+}
+
+func TestExecGen_migrationWithDigits(t *testing.T) {
+	var (
+		dir  = "testdata/gen_digit_migrations"
+		buff = &bytes.Buffer{}
+		file = dir + "/20200829084000_create_oauth2_tokens.go"
+	)
+
+	migrationNow = func() time.Time { return time.Date(2020, 8, 29, 8, 40, 0, 0, time.UTC) }
+	stdout = buff
+	defer func() {
+		migrationNow = time.Now
+		stdout = os.Stdout
+		os.RemoveAll(dir)
+	}()
+
+	assert.Nil(t, ExecGen(context.TODO(), []string{"rel", "gen", "migration", "create_oauth2_tokens", "-dir=" + dir}))
+	assert.Equal(t, "Created: "+file+"\n", buff.String())
+
+	migrations, err := scanMigration(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []migration{{Version: "20200829084000", Name: "CreateOauth2Tokens"}}, migrations)
+}
+
+func TestExecGen_migrationFromModels(t *testing.T) {
+	var (
+		dir  = "testdata/gen_models_migrations"
+		dsn  = "testdata/gen_models.db"
+		buff = &bytes.Buffer{}
+		args = []string{
+			"rel",
+			"gen",
+			"migration",
+			"create_models",
+			"-dir=" + dir,
+			"-from-models=./testdata/models",
+			"-module=github.com/Fs02/rel/cmd/rel/internal",
+			"-adapter=github.com/Fs02/rel/adapter/sqlite3",
+			"-driver=github.com/mattn/go-sqlite3",
+			"-dsn=" + dsn,
+		}
+		file = dir + "/20200829084000_create_models.go"
+	)
+
+	tempdir = "testdata"
+	migrationNow = func() time.Time { return time.Date(2020, 8, 29, 8, 40, 0, 0, time.UTC) }
+	stderr = buff
+	defer func() {
+		migrationNow = time.Now
+		stderr = os.Stderr
+		os.RemoveAll(dir)
+		os.Remove(dsn)
+	}()
+
+	assert.Nil(t, ExecGen(context.TODO(), args))
+	assert.Contains(t, buff.String(), "Created: "+file)
+
+	src, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, `package migrations
+
+import "github.com/Fs02/rel"
+
+// MigrateCreateModels definition
+func MigrateCreateModels(schema *rel.Schema) {
+	schema.CreateTable("authors", func(t *rel.Table) {
+		t.ID("id")
+		t.String("name")
+	})
+	schema.CreateTable("books", func(t *rel.Table) {
+		t.ID("id")
+		t.String("title")
+		t.String("status")
+		t.Binary("cover")
+		t.Int("author_id")
+		t.String("isbn")
+		t.DateTime("created_at")
+		t.DateTime("deleted_at")
+		t.ForeignKey("author_id", "authors", "id")
+	})
+	schema.CreateTable("categories", func(t *rel.Table) {
+		t.String("uuid")
+		t.String("name")
+		t.PrimaryKey("uuid")
+	})
+	schema.CreateTable("ratings", func(t *rel.Table) {
+		t.Int("book_id")
+		t.Int("user_id")
+		t.Float("score")
+		t.PrimaryKeys([]string{"book_id", "user_id"})
+	})
+}
+
+// RollbackCreateModels definition
+func RollbackCreateModels(schema *rel.Schema) {
+	schema.DropTable("ratings")
+	schema.DropTable("categories")
+	schema.DropTable("books")
+	schema.DropTable("authors")
+}
+`, string(src))
+
+	// the generated migration is applied, and no other migration will be generated.
+	buff.Reset()
+	migrationNow = func() time.Time { return time.Date(2020, 8, 29, 8, 41, 0, 0, time.UTC) }
+	assert.Nil(t, ExecMigrate(context.TODO(), []string{"rel", "migrate", args[4], args[6], args[7], args[8], args[9]}))
+	assert.Nil(t, ExecGen(context.TODO(), args))
+	assert.Contains(t, buff.String(), "No difference found between models and database")
+}
+
+func TestExecGen_migrationFromGeneratedModels(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		dir    = "testdata/roundtrip_migrations"
+		models = "testdata/gen_roundtrip_models"
+		dsn    = "testdata/gen_roundtrip.db"
+		buff   = &bytes.Buffer{}
+		flags  = []string{
+			"-module=github.com/Fs02/rel/cmd/rel/internal",
+			"-adapter=github.com/Fs02/rel/adapter/sqlite3",
+			"-driver=github.com/mattn/go-sqlite3",
+			"-dsn=" + dsn,
+		}
+	)
+
+	tempdir = "testdata"
+	stderr = buff
+	defer func() {
+		stderr = os.Stderr
+		os.RemoveAll(models)
+		os.Remove(dsn)
+	}()
+
+	assert.Nil(t, ExecMigrate(ctx, append([]string{"rel", "migrate", "-dir=" + dir}, flags...)))
+	assert.Nil(t, ExecGen(ctx, append([]string{"rel", "gen", "models", "-dir=" + models}, flags...)))
+
+	// models generated from the database has nothing to migrate.
+	buff.Reset()
+	assert.Nil(t, ExecGen(ctx, append([]string{"rel", "gen", "migration", "check_models", "-dir=" + dir, "-from-models=./" + models, "-drop"}, flags...)))
+	assert.Contains(t, buff.String(), "No difference found between models and database")
+}
+
+func TestExecGen_migrationFromModelsNotFound(t *testing.T) {
+	defer os.RemoveAll("testdata/gen_empty_migrations")
+
+	err := ExecGen(context.TODO(), []string{"rel", "gen", "migration", "create_models", "-dir=testdata/gen_empty_migrations", "-from-models=testdata/migrations"})
+	assert.Equal(t, errors.New("rel: no models found in: testdata/migrations"), err)
+}
+
+func TestExecGen_migrationInvalidName(t *testing.T) {
+	assert.Equal(t, errors.New("rel: missing migration name"), ExecGen(context.TODO(), []string{"rel", "gen", "migration"}))
+	assert.Equal(t, errors.New("rel: invalid migration name: CreateUsers"), ExecGen(context.TODO(), []string{"rel", "gen", "migration", "CreateUsers"}))
+}
+
+func TestGenerateMigration(t *testing.T) {
+	src, err := generateMigration("create_users")
+	assert.Nil(t, err)
+	assert.Equal(t, `package migrations
+
+import "github.com/Fs02/rel"
+
+// MigrateCreateUsers definition
+func MigrateCreateUsers(schema *rel.Schema) {
+	schema.CreateTable("users", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+	})
+}
+
+// RollbackCreateUsers definition
+func RollbackCreateUsers(schema *rel.Schema) {
+	schema.DropTable("users")
+}
+`, string(src))
+
+	src, err = generateMigration("add_email_to_users")
+	assert.Nil(t, err)
+	assert.Equal(t, `package migrations
+
+import "github.com/Fs02/rel"
+
+// MigrateAddEmailToUsers definition
+func MigrateAddEmailToUsers(schema *rel.Schema) {
+}
+
+// RollbackAddEmailToUsers definition
+func RollbackAddEmailToUsers(schema *rel.Schema) {
+}
+`, string(src))
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const modelsCommandTemplate = `introspector, ok := repo.Adapter(ctx).(rel.Introspector)
+	if !ok {
+		log.Fatal(migrator.ErrIntrospectNotSupported)
+	}
+
+	tables, err := introspector.Tables(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := migrator.GenerateModels({{printf "%q" .Package}}, tables)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile({{printf "%q" .File}}, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Print("Created: ", {{printf "%q" .File}})`
+
+func execGenModels(ctx context.Context, args []string) error {
+	var (
+		fs     = flag.NewFlagSet(args[2], flag.ExitOnError)
+		opts   = databaseFlags(fs)
+		dir    = fs.String("dir", "models", "Path to directory of the generated models")
+		pkg    = fs.String("package", "", "Package name of the generated models, default to the directory name")
+		output = fs.String("output", "models.go", "Output file name, relative to dir")
+	)
+
+	fs.Parse(args[3:])
+
+	if *pkg == "" {
+		*pkg = filepath.Base(filepath.Clean(*dir))
+	}
+
+	file := filepath.Join(*dir, *output)
+	if _, err := os.Stat(file); err == nil {
+		return errors.New("rel: file already exists: " + file)
+	}
+
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+
+	return runMigrator(ctx, opts, getModelsCommand(*pkg, file), false, []string{`"io/ioutil"`})
+}
+
+// getModelsCommand returns command that reads every tables in the database and writes the models.
+func getModelsCommand(pkg string, file string) string {
+	var (
+		buf  strings.Builder
+		tmpl = template.Must(template.New("models").Parse(modelsCommandTemplate))
+	)
+
+	check(tmpl.Execute(&buf, struct {
+		Package string
+		File    string
+	}{
+		Package: pkg,
+		File:    file,
+	}))
+
+	return buf.String()
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecGen_models(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		dir   = "testdata/gen_models"
+		dsn   = "testdata/gen_models_source.db"
+		file  = dir + "/models.go"
+		buff  = &bytes.Buffer{}
+		flags = []string{
+			"-module=github.com/Fs02/rel/cmd/rel/internal",
+			"-adapter=github.com/Fs02/rel/adapter/sqlite3",
+			"-driver=github.com/mattn/go-sqlite3",
+			"-dsn=" + dsn,
+		}
+	)
+
+	tempdir = "testdata"
+	stderr = buff
+	defer func() {
+		stderr = os.Stderr
+		os.RemoveAll(dir)
+		os.Remove(dsn)
+	}()
+
+	assert.Nil(t, ExecMigrate(ctx, append([]string{"rel", "migrate", "-dir=testdata/migrations"}, flags...)))
+	assert.Nil(t, ExecGen(ctx, append([]string{"rel", "gen", "models", "-dir=" + dir}, flags...)))
+	assert.Contains(t, buff.String(), "Created: "+file)
+
+	src, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, `package gen_models
+
+// Todo model.
+type Todo struct {
+	ID int `+"`"+`db:"id"`+"`"+`
+}
+`, string(src))
+
+	assert.Equal(t, errors.New("rel: file already exists: "+file), ExecGen(ctx, append([]string{"rel", "gen", "models", "-dir=" + dir}, flags...)))
+}
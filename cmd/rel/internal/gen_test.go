@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecGen(t *testing.T) {
+	t.Run("missing generator", func(t *testing.T) {
+		assert.Equal(t, errors.New("rel: missing generator, available generator: accessor, migration, models"), ExecGen(context.TODO(), []string{"rel", "gen"}))
+	})
+
+	t.Run("unknown generator", func(t *testing.T) {
+		assert.Equal(t, errors.New("rel: unknown generator: unknown"), ExecGen(context.TODO(), []string{"rel", "gen", "unknown"}))
+	})
+
+	t.Run("accessor", func(t *testing.T) {
+		var (
+			args = []string{
+				"rel",
+				"gen",
+				"accessor",
+				"-dir=testdata/models",
+				"-type=Rating",
+				"-output=rel_accessor_test.go",
+			}
+			output = "testdata/models/rel_accessor_test.go"
+		)
+
+		defer os.Remove(output)
+
+		assert.Nil(t, ExecGen(context.TODO(), args))
+
+		src, err := ioutil.ReadFile(output)
+		assert.Nil(t, err)
+		assert.Contains(t, string(src), "func (r *Rating) FieldScanner(field string) (interface{}, bool) {")
+		assert.NotContains(t, string(src), "func (b *Book)")
+	})
+}
+
+func TestGenerateAccessor(t *testing.T) {
+	golden, err := ioutil.ReadFile("testdata/models/rel_accessor_gen.go")
+	assert.Nil(t, err)
+
+	src, err := generateAccessor("testdata/models", nil, "rel_accessor_gen.go", false)
+	assert.Nil(t, err)
+	assert.Equal(t, string(golden), string(src))
+}
+
+func TestBuildAccessorModel_primaryTagOptions(t *testing.T) {
+	expr, err := parser.ParseExpr("struct { Code string `db:\"code,primary,omitempty\"`; ID int }")
+	assert.Nil(t, err)
+
+	var (
+		st    = expr.(*ast.StructType)
+		model = buildAccessorModel("Item", st, nil, false)
+	)
+
+	assert.True(t, hasPrimary(st, nil))
+	assert.Equal(t, []accessorField{{Name: "Code", Column: "code", Type: "string"}}, model.Primary)
+}
+
+func TestGenerateAccessor_typeNotFound(t *testing.T) {
+	_, err := generateAccessor("testdata/models", []string{"Book", "Unknown"}, "rel_accessor_gen.go", false)
+	assert.Equal(t, errors.New("rel: struct type not found: Unknown"), err)
+}
+
+func TestGenerateAccessor_packageNotFound(t *testing.T) {
+	_, err := generateAccessor("testdata/models", []string{"Unknown"}, "rel_accessor_gen.go", false)
+	assert.Equal(t, errors.New("rel: no package containing the models found"), err)
+}
+
+func TestGenerateAccessor_invalidDir(t *testing.T) {
+	_, err := generateAccessor("testdata/unknown", nil, "rel_accessor_gen.go", false)
+	assert.NotNil(t, err)
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"flag"
-	"html/template"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"strconv"
+	"text/template"
 
 	"github.com/serenize/snaker"
 )
@@ -21,13 +22,18 @@ import (
 	"log"
 	"strings"
 	"time"
+{{- range .Imports}}
+	{{.}}
+{{- end}}
 
 	_ "{{.Driver}}"
 	db "{{.Adapter}}"
 	"github.com/Fs02/rel"
 	"github.com/Fs02/rel/migrator"
+{{- if .Migrations}}
 
 	"{{.Package}}"
+{{- end}}
 )
 
 var (
@@ -64,7 +70,7 @@ func main() {
 		ctx = context.Background()
 	)
 
-	adapter, err := db.Open("{{.DSN}}")
+	adapter, err := db.Open({{printf "%q" .DSN}})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -82,7 +88,29 @@ func main() {
 	m.Register({{.Version}}, migrations.Migrate{{.Name}}, migrations.Rollback{{.Name}})
 	{{end}}
 
+	{{if .Status -}}
+	names := map[int]string{
+		{{- range .Migrations}}
+		{{.Version}}: "{{.Name}}",
+		{{- end}}
+	}
+
+	log.Printf("%-8s %-16s %s", "Status", "Version", "Name")
+	for _, s := range m.Status(ctx) {
+		name := names[s.Version]
+		if s.Missing {
+			name = "unknown"
+		}
+
+		if s.Applied {
+			log.Printf("%-8s %-16d %s (%s)", "applied", s.Version, name, s.AppliedAt.Format(time.RFC3339))
+		} else {
+			log.Printf("%-8s %-16d %s", "pending", s.Version, names[s.Version])
+		}
+	}
+	{{- else -}}
 	{{.Command}}
+	{{- end}}
 }
 `
 
@@ -96,45 +124,96 @@ var (
 // assumes args already validated.
 func ExecMigrate(ctx context.Context, args []string) error {
 	var (
-		defAdapter, defDriver, defDSN = getDatabaseInfo()
-		fs                            = flag.NewFlagSet(args[1], flag.ExitOnError)
-		command                       = getMigrateCommand(args[1])
-		dir                           = fs.String("dir", "db/migrations", "Path to directory containing migration files")
-		module                        = fs.String("module", getModule(), "Module of the main package")
-		adapter                       = fs.String("adapter", defAdapter, "Adapter package")
-		driver                        = fs.String("driver", defDriver, "Driver package")
-		dsn                           = fs.String("dsn", defDSN, "DSN for database connection")
-		verbose                       = fs.Bool("verbose", false, "Show logs from REL")
-		tmpl                          = template.Must(template.New("migration").Parse(migrationTemplate))
+		fs   = flag.NewFlagSet(args[1], flag.ExitOnError)
+		opts = migratorFlags(fs)
+		to   = fs.Int("to", 0, "Target version to migrate or rollback to")
+		step = fs.Int("step", 1, "Number of versions to rollback")
 	)
 
 	fs.Parse(args[2:])
 
-	file, err := ioutil.TempFile(tempdir, "rel-*.go")
-	check(err)
-	defer os.Remove(file.Name())
-
-	migrations, err := scanMigration(*dir)
+	command, err := getMigrateCommand(args[1], *to, *step)
 	if err != nil {
 		return err
 	}
 
+	return runMigrator(ctx, opts, command, args[1] == "status", nil)
+}
+
+type migratorOptions struct {
+	dir     *string
+	module  *string
+	adapter *string
+	driver  *string
+	dsn     *string
+	verbose *bool
+}
+
+func migratorFlags(fs *flag.FlagSet) migratorOptions {
+	opts := databaseFlags(fs)
+	opts.dir = fs.String("dir", "db/migrations", "Path to directory containing migration files")
+
+	return opts
+}
+
+// databaseFlags defines flags to connect to the database, without migration directory.
+func databaseFlags(fs *flag.FlagSet) migratorOptions {
+	var (
+		defAdapter, defDriver, defDSN = getDatabaseInfo()
+	)
+
+	return migratorOptions{
+		module:  fs.String("module", getModule(), "Module of the main package"),
+		adapter: fs.String("adapter", defAdapter, "Adapter package"),
+		driver:  fs.String("driver", defDriver, "Driver package"),
+		dsn:     fs.String("dsn", defDSN, "DSN for database connection"),
+		verbose: fs.Bool("verbose", false, "Show logs from REL"),
+	}
+}
+
+// runMigrator generates and runs program that registers every migrations and executes the command.
+// imports are written as is, thus the path needs to be quoted and can be preceded by package name.
+// Migrations are not registered when the options doesn't define migration directory.
+func runMigrator(ctx context.Context, opts migratorOptions, command string, status bool, imports []string) error {
+	var (
+		tmpl       = template.Must(template.New("migration").Parse(migrationTemplate))
+		pkg        string
+		migrations []migration
+		err        error
+	)
+
+	if opts.dir != nil {
+		if migrations, err = scanMigration(*opts.dir); err != nil {
+			return err
+		}
+
+		pkg = *opts.module + "/" + *opts.dir
+	}
+
+	file, err := ioutil.TempFile(tempdir, "rel-*.go")
+	check(err)
+	defer os.Remove(file.Name())
+
 	err = tmpl.Execute(file, struct {
 		Package    string
+		Imports    []string
 		Command    string
+		Status     bool
 		Adapter    string
 		Driver     string
 		DSN        string
 		Migrations []migration
 		Verbose    bool
 	}{
-		Package:    *module + "/" + *dir,
+		Package:    pkg,
+		Imports:    imports,
 		Command:    command,
-		Adapter:    *adapter,
-		Driver:     *driver,
-		DSN:        *dsn,
+		Status:     status,
+		Adapter:    *opts.adapter,
+		Driver:     *opts.driver,
+		DSN:        *opts.dsn,
 		Migrations: migrations,
-		Verbose:    *verbose,
+		Verbose:    *opts.verbose,
 	})
 	check(err)
 	check(file.Close())
@@ -176,11 +255,30 @@ func scanMigration(dir string) ([]migration, error) {
 	return mFiles, err
 }
 
-func getMigrateCommand(cmd string) string {
+func getMigrateCommand(cmd string, to int, step int) (string, error) {
 	switch cmd {
 	case "rollback", "down":
-		return "m.Rollback(ctx)"
+		switch {
+		case to > 0:
+			return "m.RollbackTo(ctx, " + strconv.Itoa(to) + ")", nil
+		case step > 1:
+			return "m.RollbackStep(ctx, " + strconv.Itoa(step) + ")", nil
+		case step < 1:
+			return "", errors.New("rel: invalid rollback step: " + strconv.Itoa(step))
+		default:
+			return "m.Rollback(ctx)", nil
+		}
+	case "redo":
+		return "m.Redo(ctx)", nil
+	case "reset":
+		return "m.Reset(ctx)", nil
+	case "status":
+		return "m.Status(ctx)", nil
 	default:
-		return "m.Migrate(ctx)"
+		if to > 0 {
+			return "m.MigrateTo(ctx, " + strconv.Itoa(to) + ")", nil
+		}
+
+		return "m.Migrate(ctx)", nil
 	}
 }
@@ -50,6 +50,45 @@ func TestExecMigrate(t *testing.T) {
 		assert.Contains(t, buff.String(), "Done: migrate 1 create table todos")
 		assert.Nil(t, err)
 	})
+
+	t.Run("status", func(t *testing.T) {
+		var (
+			ctx  = context.TODO()
+			args = []string{
+				"rel",
+				"status",
+				"-dir=testdata/migrations",
+				"-module=github.com/Fs02/rel/cmd/rel/internal",
+				"-adapter=github.com/Fs02/rel/adapter/sqlite3",
+				"-driver=github.com/mattn/go-sqlite3",
+				"-dsn=:memory:",
+			}
+			dir  = "testdata"
+			buff = &bytes.Buffer{}
+		)
+
+		tempdir = dir
+		stderr = buff
+		defer func() { stderr = os.Stderr }()
+
+		err := ExecMigrate(ctx, args)
+		assert.Contains(t, buff.String(), "pending  1                CreateSamples")
+		assert.Nil(t, err)
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		var (
+			ctx  = context.TODO()
+			args = []string{
+				"rel",
+				"rollback",
+				"-dir=testdata/migrations",
+				"-step=0",
+			}
+		)
+
+		assert.Equal(t, errors.New("rel: invalid rollback step: 0"), ExecMigrate(ctx, args))
+	})
 }
 
 func TestScanMigration(t *testing.T) {
@@ -91,8 +130,31 @@ func TestScanMigration(t *testing.T) {
 }
 
 func TestGetMigrateCommand(t *testing.T) {
-	assert.Equal(t, "m.Rollback(ctx)", getMigrateCommand("rollback"))
-	assert.Equal(t, "m.Rollback(ctx)", getMigrateCommand("down"))
-	assert.Equal(t, "m.Migrate(ctx)", getMigrateCommand("migrate"))
-	assert.Equal(t, "m.Migrate(ctx)", getMigrateCommand("up"))
+	tests := []struct {
+		cmd     string
+		to      int
+		step    int
+		command string
+		err     error
+	}{
+		{cmd: "rollback", step: 1, command: "m.Rollback(ctx)"},
+		{cmd: "down", step: 1, command: "m.Rollback(ctx)"},
+		{cmd: "rollback", step: 3, command: "m.RollbackStep(ctx, 3)"},
+		{cmd: "rollback", to: 20200101000000, step: 1, command: "m.RollbackTo(ctx, 20200101000000)"},
+		{cmd: "rollback", step: 0, err: errors.New("rel: invalid rollback step: 0")},
+		{cmd: "migrate", step: 1, command: "m.Migrate(ctx)"},
+		{cmd: "up", step: 1, command: "m.Migrate(ctx)"},
+		{cmd: "migrate", to: 20200101000000, step: 1, command: "m.MigrateTo(ctx, 20200101000000)"},
+		{cmd: "redo", step: 1, command: "m.Redo(ctx)"},
+		{cmd: "reset", step: 1, command: "m.Reset(ctx)"},
+		{cmd: "status", step: 1, command: "m.Status(ctx)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			command, err := getMigrateCommand(test.cmd, test.to, test.step)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.command, command)
+		})
+	}
 }
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strings"
+	"text/template"
+)
+
+const schemaDumpTemplate = `file, err := os.Create({{printf "%q" .File}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m.Dump(ctx, file)
+	if err := file.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Print("Schema dumped to: ", {{printf "%q" .File}})`
+
+const schemaLoadTemplate = `file, err := os.Open({{printf "%q" .File}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer file.Close()
+
+	m.Load(ctx, file)
+	log.Print("Schema loaded from: ", {{printf "%q" .File}})`
+
+// ExecSchema command.
+// assumes args already validated.
+func ExecSchema(ctx context.Context, args []string) error {
+	if len(args) < 3 {
+		return errors.New("rel: missing schema command, available command: dump, load")
+	}
+
+	var (
+		fs   = flag.NewFlagSet(args[2], flag.ExitOnError)
+		opts = migratorFlags(fs)
+		file = fs.String("file", "db/schema.sql", "Path to schema file")
+	)
+
+	fs.Parse(args[3:])
+
+	command, err := getSchemaCommand(args[2], *file)
+	if err != nil {
+		return err
+	}
+
+	return runMigrator(ctx, opts, command, false, []string{`"os"`})
+}
+
+func getSchemaCommand(cmd string, file string) (string, error) {
+	var (
+		buf  strings.Builder
+		tmpl *template.Template
+	)
+
+	switch cmd {
+	case "dump":
+		tmpl = template.Must(template.New("dump").Parse(schemaDumpTemplate))
+	case "load":
+		tmpl = template.Must(template.New("load").Parse(schemaLoadTemplate))
+	default:
+		return "", errors.New("rel: unknown schema command: " + cmd)
+	}
+
+	check(tmpl.Execute(&buf, struct {
+		File string
+	}{
+		File: file,
+	}))
+
+	return buf.String(), nil
+}
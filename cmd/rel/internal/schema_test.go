@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecSchema(t *testing.T) {
+	t.Run("missing command", func(t *testing.T) {
+		assert.Equal(t, errors.New("rel: missing schema command, available command: dump, load"), ExecSchema(context.TODO(), []string{"rel", "schema"}))
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		assert.Equal(t, errors.New("rel: unknown schema command: unknown"), ExecSchema(context.TODO(), []string{"rel", "schema", "unknown"}))
+	})
+
+	t.Run("dump and load", func(t *testing.T) {
+		var (
+			ctx    = context.TODO()
+			source = "testdata/schema_source.db"
+			target = "testdata/schema_target.db"
+			output = "testdata/schema.sql"
+			flags  = []string{
+				"-dir=testdata/migrations",
+				"-module=github.com/Fs02/rel/cmd/rel/internal",
+				"-adapter=github.com/Fs02/rel/adapter/sqlite3",
+				"-driver=github.com/mattn/go-sqlite3",
+				"-file=" + output,
+			}
+			buff = &bytes.Buffer{}
+		)
+
+		tempdir = "testdata"
+		stderr = buff
+		defer func() {
+			stderr = os.Stderr
+			os.Remove(source)
+			os.Remove(target)
+			os.Remove(output)
+		}()
+
+		assert.Nil(t, ExecMigrate(ctx, append([]string{"rel", "migrate", "-dsn=" + source}, flags[:4]...)))
+		assert.Nil(t, ExecSchema(ctx, append([]string{"rel", "schema", "dump", "-dsn=" + source}, flags...)))
+
+		src, err := ioutil.ReadFile(output)
+		assert.Nil(t, err)
+		assert.Equal(t, "-- Code generated by rel schema dump. DO NOT EDIT.\n-- rel:versions 1\n\nCREATE TABLE `todos` (`id` INTEGER PRIMARY KEY);\n", string(src))
+
+		assert.Nil(t, ExecSchema(ctx, append([]string{"rel", "schema", "load", "-dsn=" + target}, flags...)))
+		assert.Contains(t, buff.String(), "Schema loaded from: "+output)
+
+		buff.Reset()
+		assert.Nil(t, ExecMigrate(ctx, append([]string{"rel", "status", "-dsn=" + target}, flags[:4]...)))
+		assert.Contains(t, buff.String(), "applied  1                CreateSamples")
+	})
+}
+
+func TestGetSchemaCommand(t *testing.T) {
+	command, err := getSchemaCommand("dump", "db/schema.sql")
+	assert.Nil(t, err)
+	assert.Contains(t, command, `os.Create("db/schema.sql")`)
+	assert.Contains(t, command, "m.Dump(ctx, file)")
+
+	command, err = getSchemaCommand("load", "db/schema.sql")
+	assert.Nil(t, err)
+	assert.Contains(t, command, `os.Open("db/schema.sql")`)
+	assert.Contains(t, command, "m.Load(ctx, file)")
+}
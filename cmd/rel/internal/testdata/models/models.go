@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+type Status string
+
+type Model struct {
+	ID int
+}
+
+type Book struct {
+	ID        int
+	Title     string
+	Status    Status
+	Cover     []byte
+	AuthorID  *int
+	Author    Author
+	Secret    string `db:"-"`
+	Code      string `db:"isbn"`
+	CreatedAt time.Time
+	DeletedAt *time.Time
+	internal  string
+}
+
+type Author struct {
+	Model
+	Name  string
+	Books []Book
+}
+
+type Rating struct {
+	BookID int `db:",primary"`
+	UserID int `db:",primary"`
+	Score  float64
+}
+
+type Category struct {
+	UUID string
+	Name string
+}
+
+func (c Category) PrimaryFields() []string {
+	return []string{"uuid"}
+}
+
+func (c Category) PrimaryValues() []interface{} {
+	return []interface{}{c.UUID}
+}
+
+type options struct {
+	ID int
+}
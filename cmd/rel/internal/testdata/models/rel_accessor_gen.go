@@ -0,0 +1,267 @@
+// Code generated by rel gen accessor. DO NOT EDIT.
+
+package models
+
+import (
+	"time"
+
+	"github.com/Fs02/rel"
+)
+
+// FieldScanner of Author.
+func (a *Author) FieldScanner(field string) (interface{}, bool) {
+	switch field {
+	case "name":
+		return rel.Nullable(&a.Name), true
+	}
+
+	return nil, false
+}
+
+// FieldValue of Author.
+func (a *Author) FieldValue(field string) (interface{}, bool) {
+	switch field {
+	case "name":
+		return a.Name, true
+	}
+
+	return nil, false
+}
+
+// SetFieldValue of Author.
+func (a *Author) SetFieldValue(field string, value interface{}) bool {
+	switch field {
+	case "name":
+		if val, ok := value.(string); ok {
+			a.Name = val
+			return true
+		}
+	}
+
+	return false
+}
+
+// PrimaryFields of Book.
+func (b Book) PrimaryFields() []string {
+	return []string{"id"}
+}
+
+// PrimaryValues of Book.
+func (b Book) PrimaryValues() []interface{} {
+	return []interface{}{b.ID}
+}
+
+// FieldScanner of Book.
+func (b *Book) FieldScanner(field string) (interface{}, bool) {
+	switch field {
+	case "id":
+		return rel.Nullable(&b.ID), true
+	case "title":
+		return rel.Nullable(&b.Title), true
+	case "status":
+		return rel.Nullable(&b.Status), true
+	case "cover":
+		return rel.Nullable(&b.Cover), true
+	case "author_id":
+		return &b.AuthorID, true
+	case "isbn":
+		return rel.Nullable(&b.Code), true
+	case "created_at":
+		return rel.Nullable(&b.CreatedAt), true
+	case "deleted_at":
+		return &b.DeletedAt, true
+	}
+
+	return nil, false
+}
+
+// FieldValue of Book.
+func (b *Book) FieldValue(field string) (interface{}, bool) {
+	switch field {
+	case "id":
+		return b.ID, true
+	case "title":
+		return b.Title, true
+	case "status":
+		return b.Status, true
+	case "cover":
+		return b.Cover, true
+	case "author_id":
+		if b.AuthorID == nil {
+			return nil, true
+		}
+
+		return *b.AuthorID, true
+	case "isbn":
+		return b.Code, true
+	case "created_at":
+		return b.CreatedAt, true
+	case "deleted_at":
+		if b.DeletedAt == nil {
+			return nil, true
+		}
+
+		return *b.DeletedAt, true
+	}
+
+	return nil, false
+}
+
+// SetFieldValue of Book.
+func (b *Book) SetFieldValue(field string, value interface{}) bool {
+	switch field {
+	case "id":
+		if val, ok := value.(int); ok {
+			b.ID = val
+			return true
+		}
+	case "title":
+		if val, ok := value.(string); ok {
+			b.Title = val
+			return true
+		}
+	case "status":
+		if val, ok := value.(Status); ok {
+			b.Status = val
+			return true
+		}
+	case "cover":
+		if val, ok := value.([]byte); ok {
+			b.Cover = val
+			return true
+		}
+	case "author_id":
+		if val, ok := value.(int); ok {
+			if b.AuthorID == nil {
+				b.AuthorID = new(int)
+			}
+
+			*b.AuthorID = val
+			return true
+		}
+	case "isbn":
+		if val, ok := value.(string); ok {
+			b.Code = val
+			return true
+		}
+	case "created_at":
+		if val, ok := value.(time.Time); ok {
+			b.CreatedAt = val
+			return true
+		}
+	case "deleted_at":
+		if val, ok := value.(time.Time); ok {
+			if b.DeletedAt == nil {
+				b.DeletedAt = new(time.Time)
+			}
+
+			*b.DeletedAt = val
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldScanner of Category.
+func (c *Category) FieldScanner(field string) (interface{}, bool) {
+	switch field {
+	case "uuid":
+		return rel.Nullable(&c.UUID), true
+	case "name":
+		return rel.Nullable(&c.Name), true
+	}
+
+	return nil, false
+}
+
+// FieldValue of Category.
+func (c *Category) FieldValue(field string) (interface{}, bool) {
+	switch field {
+	case "uuid":
+		return c.UUID, true
+	case "name":
+		return c.Name, true
+	}
+
+	return nil, false
+}
+
+// SetFieldValue of Category.
+func (c *Category) SetFieldValue(field string, value interface{}) bool {
+	switch field {
+	case "uuid":
+		if val, ok := value.(string); ok {
+			c.UUID = val
+			return true
+		}
+	case "name":
+		if val, ok := value.(string); ok {
+			c.Name = val
+			return true
+		}
+	}
+
+	return false
+}
+
+// PrimaryFields of Rating.
+func (r Rating) PrimaryFields() []string {
+	return []string{"book_id", "user_id"}
+}
+
+// PrimaryValues of Rating.
+func (r Rating) PrimaryValues() []interface{} {
+	return []interface{}{r.BookID, r.UserID}
+}
+
+// FieldScanner of Rating.
+func (r *Rating) FieldScanner(field string) (interface{}, bool) {
+	switch field {
+	case "book_id":
+		return rel.Nullable(&r.BookID), true
+	case "user_id":
+		return rel.Nullable(&r.UserID), true
+	case "score":
+		return rel.Nullable(&r.Score), true
+	}
+
+	return nil, false
+}
+
+// FieldValue of Rating.
+func (r *Rating) FieldValue(field string) (interface{}, bool) {
+	switch field {
+	case "book_id":
+		return r.BookID, true
+	case "user_id":
+		return r.UserID, true
+	case "score":
+		return r.Score, true
+	}
+
+	return nil, false
+}
+
+// SetFieldValue of Rating.
+func (r *Rating) SetFieldValue(field string, value interface{}) bool {
+	switch field {
+	case "book_id":
+		if val, ok := value.(int); ok {
+			r.BookID = val
+			return true
+		}
+	case "user_id":
+		if val, ok := value.(int); ok {
+			r.UserID = val
+			return true
+		}
+	case "score":
+		if val, ok := value.(float64); ok {
+			r.Score = val
+			return true
+		}
+	}
+
+	return false
+}
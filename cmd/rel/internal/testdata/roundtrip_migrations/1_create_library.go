@@ -0,0 +1,30 @@
+package migrations
+
+import "github.com/Fs02/rel"
+
+// MigrateCreateLibrary definition
+func MigrateCreateLibrary(schema *rel.Schema) {
+	schema.CreateTable("authors", func(t *rel.Table) {
+		t.ID("id")
+		t.String("name", rel.Required(true), rel.Default(""))
+		t.DateTime("created_at")
+	})
+	schema.CreateTable("books", func(t *rel.Table) {
+		t.ID("id")
+		t.String("title")
+		t.Int("author_id")
+		t.Binary("cover")
+		t.Decimal("price", rel.Precision(8), rel.Scale(2))
+		t.Time("opening_time")
+		t.Bool("published", rel.Required(true), rel.Default(false))
+		t.Column("data", "JSON")
+		t.Column("location", "POINT")
+		t.ForeignKey("author_id", "authors", "id")
+	})
+}
+
+// RollbackCreateLibrary definition
+func RollbackCreateLibrary(schema *rel.Schema) {
+	schema.DropTable("books")
+	schema.DropTable("authors")
+}
@@ -9,7 +9,7 @@ import (
 )
 
 var (
-	reMigrationFile = regexp.MustCompile(`^(\d+)_([a-z_]+)\.go$`)
+	reMigrationFile = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.go$`)
 	reGomod         = regexp.MustCompile(`module\s(\S+)`)
 	gomod           = "go.mod"
 )
@@ -27,18 +27,22 @@ func main() {
 	)
 
 	if len(os.Args) < 2 {
-		fmt.Println("Available command are: migrate, rollback")
+		fmt.Println("Available command are: migrate, rollback, redo, reset, status, schema, gen")
 		os.Exit(1)
 	}
 
 	switch os.Args[1] {
-	case "migrate", "up", "rollback", "down":
+	case "migrate", "up", "rollback", "down", "redo", "reset", "status":
 		err = internal.ExecMigrate(ctx, os.Args)
+	case "schema":
+		err = internal.ExecSchema(ctx, os.Args)
+	case "gen":
+		err = internal.ExecGen(ctx, os.Args)
 	case "version", "-v", "-version":
 		fmt.Println("REL " + version + " (Commit: " + commit + " Date: " + date + ")")
 	case "-help":
 		fmt.Println("Usage: rel [command] -help")
-		fmt.Println("Available commands: migrate, rollback")
+		fmt.Println("Available commands: migrate, rollback, redo, reset, status, schema, gen")
 	default:
 		flag.PrintDefaults()
 		os.Exit(1)
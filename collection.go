@@ -99,7 +99,9 @@ func (c Collection) PrimaryValues() []interface{} {
 			)
 
 			for j := range values {
-				values[j] = c.rv.Index(j).Field(index[i]).Interface()
+				if fv := reflectValueFieldByIndex(c.rv.Index(j), index[i], false); fv.IsValid() {
+					values[j] = fv.Interface()
+				}
 			}
 
 			pValues[i] = values
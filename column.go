@@ -20,6 +20,8 @@ const (
 	String ColumnType = "STRING"
 	// Text ColumnType.
 	Text ColumnType = "TEXT"
+	// Binary ColumnType.
+	Binary ColumnType = "BINARY"
 	// Date ColumnType.
 	Date ColumnType = "DATE"
 	// DateTime ColumnType.
@@ -11,7 +11,29 @@ type contextWrapper struct {
 	adapter Adapter
 }
 
-var ctxKey contextKey
+var (
+	ctxKey           contextKey
+	usePrimaryCtxKey contextKey = 1
+	tenantCtxKey     contextKey = 2
+)
+
+// UsePrimary returns context that forces read operations to use primary adapter instead of replicas.
+// It's useful to read records right after it's written, since replicas might not be updated yet.
+func UsePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, usePrimaryCtxKey, true)
+}
+
+// WithTenant returns context that scopes repository operations to the given tenant.
+// The tenant field must be registered to the repository using Repository.Tenant.
+func WithTenant(ctx context.Context, value interface{}) context.Context {
+	return context.WithValue(ctx, tenantCtxKey, value)
+}
+
+// tenantValue returns tenant stored in the context if exists.
+func tenantValue(ctx context.Context) (interface{}, bool) {
+	value := ctx.Value(tenantCtxKey)
+	return value, value != nil
+}
 
 // fetchContext and use adapter passed by context if exists.
 // it stores contextData values to struct for fast repeated access.
@@ -26,6 +48,16 @@ func fetchContext(ctx context.Context, adapter Adapter) contextWrapper {
 	}
 }
 
+// fetchReadContext is similar to fetchContext, but it uses one of the replicas if available.
+// Primary adapter is used inside transaction or when it's forced by the context.
+func fetchReadContext(ctx context.Context, adapter Adapter, replicas *replicas) contextWrapper {
+	if replicas != nil && ctx.Value(ctxKey) == nil && ctx.Value(usePrimaryCtxKey) == nil {
+		adapter = replicas.adapter()
+	}
+
+	return fetchContext(ctx, adapter)
+}
+
 // wrapContext wraps adapter inside context.
 func wrapContext(ctx context.Context, adapter Adapter) contextWrapper {
 	return contextWrapper{
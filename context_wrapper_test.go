@@ -35,3 +35,29 @@ func TestContextWrapper(t *testing.T) {
 		assert.Equal(t, adapter, cw.adapter)
 	})
 }
+
+func TestFetchReadContext(t *testing.T) {
+	var (
+		primary  = &testAdapter{}
+		replica  = &testAdapter{}
+		replicas = newReplicas(RoundRobin(), []Adapter{replica})
+		ctx      = context.TODO()
+	)
+
+	t.Run("without replicas", func(t *testing.T) {
+		assert.Equal(t, primary, fetchReadContext(ctx, primary, nil).adapter)
+	})
+
+	t.Run("replica", func(t *testing.T) {
+		assert.Equal(t, replicas.adapters[0], fetchReadContext(ctx, primary, replicas).adapter)
+	})
+
+	t.Run("use primary", func(t *testing.T) {
+		assert.Equal(t, primary, fetchReadContext(UsePrimary(ctx), primary, replicas).adapter)
+	})
+
+	t.Run("transaction", func(t *testing.T) {
+		tx := &testAdapter{result: 1}
+		assert.Equal(t, tx, fetchReadContext(wrapContext(ctx, tx).ctx, primary, replicas).adapter)
+	})
+}
@@ -1,8 +1,9 @@
 package rel
 
 import (
-	"database/sql"
+	"context"
 	"reflect"
+	"strings"
 )
 
 // Cursor is interface to work with database result (used by adapter).
@@ -14,7 +15,7 @@ type Cursor interface {
 	NopScanner() interface{} // TODO: conflict with manual scanners interface
 }
 
-func scanOne(cur Cursor, doc *Document) error {
+func scanOne(cur Cursor, doc *Document, joins ...string) error {
 	defer cur.Close()
 
 	fields, err := cur.Fields()
@@ -26,14 +27,27 @@ func scanOne(cur Cursor, doc *Document) error {
 		return NotFoundError{}
 	}
 
-	var (
-		scanners = doc.Scanners(fields)
-	)
+	return scanJoin(cur, doc, fields, joins)
+}
 
-	return cur.Scan(scanners...)
+func scanAll(cur Cursor, col *Collection, joins ...string) error {
+	defer cur.Close()
+
+	fields, err := cur.Fields()
+	if err != nil {
+		return err
+	}
+
+	for cur.Next() {
+		if err := scanJoin(cur, col.Add(), fields, joins); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func scanAll(cur Cursor, col *Collection) error {
+func scanMaps(cur Cursor, rows *[]map[string]interface{}) error {
 	defer cur.Close()
 
 	fields, err := cur.Fields()
@@ -41,21 +55,81 @@ func scanAll(cur Cursor, col *Collection) error {
 		return err
 	}
 
+	var (
+		values   = make([]interface{}, len(fields))
+		scanners = make([]interface{}, len(fields))
+	)
+
+	for i := range values {
+		scanners[i] = &values[i]
+	}
+
 	for cur.Next() {
+		if err := cur.Scan(scanners...); err != nil {
+			return err
+		}
+
+		row := make(map[string]interface{}, len(fields))
+		for i, field := range fields {
+			if b, ok := values[i].([]byte); ok {
+				row[field] = string(b)
+			} else {
+				row[field] = values[i]
+			}
+		}
+
+		*rows = append(*rows, row)
+	}
+
+	return nil
+}
+
+// scanJoin scans a row into document and its associations that are preloaded using join.
+// Fields of the association are prefixed by the association name, and association is reset when the joined row is null.
+func scanJoin(cur Cursor, doc *Document, fields []string, joins []string) error {
+	var (
+		scanners = doc.Scanners(fields)
+		assocs   = make([]Association, len(joins))
+	)
+
+	for i, join := range joins {
 		var (
-			doc      = col.Add()
-			scanners = doc.Scanners(fields)
+			prefix       = join + "."
+			assoc        = doc.Association(join)
+			target, _    = assoc.Document()
+			targetFields = make([]string, len(fields))
 		)
 
-		if err := cur.Scan(scanners...); err != nil {
-			return err
+		for j, field := range fields {
+			if strings.HasPrefix(field, prefix) {
+				targetFields[j] = strings.TrimPrefix(field, prefix)
+			}
+		}
+
+		for j, scanner := range target.Scanners(targetFields) {
+			if targetFields[j] != "" {
+				scanners[j] = scanner
+			}
+		}
+
+		assocs[i] = assoc
+	}
+
+	if err := cur.Scan(scanners...); err != nil {
+		return err
+	}
+
+	for i := range assocs {
+		if _, loaded := assocs[i].Document(); !loaded {
+			assocs[i].reset()
 		}
 	}
 
 	return nil
 }
 
-func scanMulti(cur Cursor, keyField string, keyType reflect.Type, cols map[interface{}][]slice) error {
+// scanMulti scans rows into collections grouped by key field, AfterFind hook is called for every scanned record.
+func scanMulti(ctx context.Context, cur Cursor, keyField string, keyType reflect.Type, cols map[interface{}][]slice) error {
 	defer cur.Close()
 
 	fields, err := cur.Fields()
@@ -74,9 +148,8 @@ func scanMulti(cur Cursor, keyField string, keyType reflect.Type, cols map[inter
 			found = true
 			keyScanners[i] = keyValue.Interface()
 		} else {
-			// need to create distinct copies
-			// otherwise next scan result will be corrupted
-			keyScanners[i] = &sql.RawBytes{}
+			// the same row will be scanned again by the documents, avoid holding the raw memory.
+			keyScanners[i] = nopScanner{}
 		}
 	}
 
@@ -104,8 +177,20 @@ func scanMulti(cur Cursor, keyField string, keyType reflect.Type, cols map[inter
 			if err := cur.Scan(scanners...); err != nil {
 				return err
 			}
+
+			if err := afterFind(ctx, doc); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+// nopScanner discards scanned value.
+// Unlike sql.RawBytes, it allows the same row to be scanned multiple times.
+type nopScanner struct{}
+
+func (nopScanner) Scan(interface{}) error {
+	return nil
+}
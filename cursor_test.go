@@ -1,6 +1,7 @@
 package rel
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"reflect"
@@ -92,6 +93,30 @@ func TestScanOne(t *testing.T) {
 	cur.AssertExpectations(t)
 }
 
+func TestScanOne_embedded(t *testing.T) {
+	var (
+		comment Comment
+		cur     = &testCursor{}
+		doc     = NewDocument(&comment)
+		now     = time.Now()
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "body", "updated_by", "created_at"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(10, "body", "admin", now).Once()
+
+	assert.Nil(t, scanOne(cur, doc))
+	assert.Equal(t, Comment{
+		Model:      Model{ID: 10},
+		Body:       "body",
+		Audit:      &Audit{UpdatedBy: "admin"},
+		Timestamps: Timestamps{CreatedAt: now},
+	}, comment)
+
+	cur.AssertExpectations(t)
+}
+
 func TestScanOne_fieldsError(t *testing.T) {
 	var (
 		user User
@@ -141,6 +166,31 @@ func TestScanAll(t *testing.T) {
 	cur.AssertExpectations(t)
 }
 
+func TestScanAll_join(t *testing.T) {
+	var (
+		addresses []Address
+		userID    = 10
+		cur       = &testCursor{}
+		col       = NewCollection(&addresses)
+	)
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id", "street", "user.id", "user.name"}, nil).Once()
+
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(1, 10, "Grove Street", 10, "Del Piero").Once()
+	cur.MockScan(2, nil, "Main Street", nil, nil).Once()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, scanAll(cur, col, "user"))
+	assert.Equal(t, []Address{
+		{ID: 1, UserID: &userID, Street: "Grove Street", User: &User{ID: 10, Name: "Del Piero"}},
+		{ID: 2, Street: "Main Street"},
+	}, addresses)
+
+	cur.AssertExpectations(t)
+}
+
 func TestScanAll_scanError(t *testing.T) {
 	var (
 		users []User
@@ -197,7 +247,7 @@ func TestScanMulti(t *testing.T) {
 	cur.MockScan(11, "Nedved", 46, now, now).Twice()
 	cur.On("Next").Return(false).Once()
 
-	assert.Nil(t, scanMulti(cur, keyField, keyType, cols))
+	assert.Nil(t, scanMulti(context.TODO(), cur, keyField, keyType, cols))
 	assert.Len(t, users1, 1)
 	assert.Equal(t, User{
 		ID:        10,
@@ -241,7 +291,7 @@ func TestScanMulti_scanError(t *testing.T) {
 	cur.MockScan(11, "Nedved", 46, now, now).Once()
 	cur.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(err).Once()
 
-	assert.Equal(t, err, scanMulti(cur, keyField, keyType, cols))
+	assert.Equal(t, err, scanMulti(context.TODO(), cur, keyField, keyType, cols))
 	cur.AssertExpectations(t)
 }
 
@@ -263,7 +313,7 @@ func TestScanMulti_scanKeyError(t *testing.T) {
 	cur.On("Next").Return(true).Once()
 	cur.On("Scan", mock.Anything).Return(err).Once()
 
-	assert.Equal(t, err, scanMulti(cur, keyField, keyType, cols))
+	assert.Equal(t, err, scanMulti(context.TODO(), cur, keyField, keyType, cols))
 	cur.AssertExpectations(t)
 }
 
@@ -282,7 +332,7 @@ func TestScanMulti_keyFieldsNotExists(t *testing.T) {
 	cur.On("Fields").Return([]string{}, nil).Once()
 
 	assert.Panics(t, func() {
-		scanMulti(cur, keyField, keyType, cols)
+		scanMulti(context.TODO(), cur, keyField, keyType, cols)
 	})
 	cur.AssertExpectations(t)
 }
@@ -302,6 +352,6 @@ func TestScanMulti_fieldsError(t *testing.T) {
 	cur.On("Close").Return(nil).Once()
 	cur.On("Fields").Return([]string{}, err).Once()
 
-	assert.Equal(t, err, scanMulti(cur, keyField, keyType, cols))
+	assert.Equal(t, err, scanMulti(context.TODO(), cur, keyField, keyType, cols))
 	cur.AssertExpectations(t)
 }
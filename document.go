@@ -28,6 +28,10 @@ const (
 	HasUpdatedAt
 	// HasDeletedAt flag.
 	HasDeletedAt
+	// HasLockVersion flag.
+	HasLockVersion
+	// HasDefaultScope flag.
+	HasDefaultScope
 )
 
 var (
@@ -37,6 +41,7 @@ var (
 	rtTime            = reflect.TypeOf(time.Time{})
 	rtTable           = reflect.TypeOf((*table)(nil)).Elem()
 	rtPrimary         = reflect.TypeOf((*primary)(nil)).Elem()
+	rtScanner         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
 )
 
 type table interface {
@@ -48,28 +53,47 @@ type primary interface {
 	PrimaryValues() []interface{}
 }
 
+// scoped is implemented by record that defines default scope.
+// Filters of the default scope are applied to every query of the record, unless it's unscoped.
+type scoped interface {
+	DefaultScope() Query
+}
+
+// Accessor can be implemented by a record to read and write its fields without reflection.
+// Document falls back to reflection whenever any of the methods returns false.
+// The implementation can be generated using `rel gen accessor` command.
+type Accessor interface {
+	FieldScanner(field string) (interface{}, bool)
+	FieldValue(field string) (interface{}, bool)
+	SetFieldValue(field string, value interface{}) bool
+}
+
 type primaryData struct {
 	field []string
-	index []int
+	index [][]int
 }
 
 type documentData struct {
-	index        map[string]int
+	index        map[string][]int
 	fields       []string
 	belongsTo    []string
 	hasOne       []string
 	hasMany      []string
+	manyToMany   []string
 	primaryField []string
-	primaryIndex []int
+	primaryIndex [][]int
+	lockVersion  string
+	defaultScope FilterQuery
 	flag         DocumentFlag
 }
 
 // Document provides an abstraction over reflect to easily works with struct for database purpose.
 type Document struct {
-	v    interface{}
-	rv   reflect.Value
-	rt   reflect.Type
-	data documentData
+	v        interface{}
+	rv       reflect.Value
+	rt       reflect.Type
+	data     documentData
+	accessor Accessor
 }
 
 // ReflectValue of referenced document.
@@ -125,7 +149,9 @@ func (d Document) PrimaryValues() []interface{} {
 	)
 
 	for i := range pValues {
-		pValues[i] = d.rv.Field(d.data.primaryIndex[i]).Interface()
+		if fv := reflectValueFieldByIndex(d.rv, d.data.primaryIndex[i], false); fv.IsValid() {
+			pValues[i] = fv.Interface()
+		}
 	}
 
 	return pValues
@@ -157,7 +183,21 @@ func (d Document) Persisted() bool {
 }
 
 // Index returns map of column name and it's struct index.
+// Fields promoted from embedded struct are excluded, use IndexPath to include them.
 func (d Document) Index() map[string]int {
+	index := make(map[string]int, len(d.data.index))
+	for field, path := range d.data.index {
+		if len(path) == 1 {
+			index[field] = path[0]
+		}
+	}
+
+	return index
+}
+
+// IndexPath returns map of column name and it's struct index path.
+// Index path of fields promoted from embedded struct contains more than one element.
+func (d Document) IndexPath() map[string][]int {
 	return d.data.index
 }
 
@@ -170,7 +210,7 @@ func (d Document) Fields() []string {
 func (d Document) Type(field string) (reflect.Type, bool) {
 	if i, ok := d.data.index[field]; ok {
 		var (
-			ft = d.rt.Field(i).Type
+			ft = d.rt.FieldByIndex(i).Type
 		)
 
 		if ft.Kind() == reflect.Ptr {
@@ -187,14 +227,23 @@ func (d Document) Type(field string) (reflect.Type, bool) {
 
 // Value returns value of given field. if field does not exist, second returns value will be false.
 func (d Document) Value(field string) (interface{}, bool) {
+	if d.accessor != nil {
+		if value, ok := d.accessor.FieldValue(field); ok {
+			return value, true
+		}
+	}
+
 	if i, ok := d.data.index[field]; ok {
 		var (
 			value interface{}
-			fv    = d.rv.Field(i)
-			ft    = fv.Type()
+			fv    = reflectValueFieldByIndex(d.rv, i, false)
 		)
 
-		if ft.Kind() == reflect.Ptr {
+		if !fv.IsValid() {
+			return nil, true
+		}
+
+		if fv.Kind() == reflect.Ptr {
 			if !fv.IsNil() {
 				value = fv.Elem().Interface()
 			}
@@ -210,11 +259,15 @@ func (d Document) Value(field string) (interface{}, bool) {
 
 // SetValue of the field, it returns false if field does not exist, or it's not assignable.
 func (d Document) SetValue(field string, value interface{}) bool {
+	if d.accessor != nil && d.accessor.SetFieldValue(field, value) {
+		return true
+	}
+
 	if i, ok := d.data.index[field]; ok {
 		var (
 			rv reflect.Value
 			rt reflect.Type
-			fv = d.rv.Field(i)
+			fv = reflectValueFieldByIndex(d.rv, i, true)
 			ft = fv.Type()
 		)
 
@@ -281,9 +334,16 @@ func (d Document) Scanners(fields []string) []interface{} {
 	)
 
 	for index, field := range fields {
+		if d.accessor != nil {
+			if scanner, ok := d.accessor.FieldScanner(field); ok {
+				result[index] = scanner
+				continue
+			}
+		}
+
 		if structIndex, ok := d.data.index[field]; ok {
 			var (
-				fv = d.rv.Field(structIndex)
+				fv = reflectValueFieldByIndex(d.rv, structIndex, true)
 				ft = fv.Type()
 			)
 
@@ -293,7 +353,7 @@ func (d Document) Scanners(fields []string) []interface{} {
 				result[index] = Nullable(fv.Addr().Interface())
 			}
 		} else {
-			result[index] = &sql.RawBytes{}
+			result[index] = nopScanner{}
 		}
 	}
 
@@ -315,14 +375,19 @@ func (d Document) HasMany() []string {
 	return d.data.hasMany
 }
 
+// ManyToMany fields of this document.
+func (d Document) ManyToMany() []string {
+	return d.data.manyToMany
+}
+
 // Association of this document with given name.
 func (d Document) Association(name string) Association {
 	index, ok := d.data.index[name]
-	if !ok {
+	if !ok || len(index) != 1 {
 		panic("rel: no field named (" + name + ") in type " + d.rt.String() + " found ")
 	}
 
-	return newAssociation(d.rv, index)
+	return newAssociation(d.rv, index[0])
 }
 
 // Reset this document, this is a noop for compatibility with collection.
@@ -384,11 +449,14 @@ func newDocument(v interface{}, rv reflect.Value, readonly bool) *Document {
 		panic("rel: must be a struct or pointer to a struct")
 	}
 
+	accessor, _ := v.(Accessor)
+
 	return &Document{
-		v:    v,
-		rv:   rv,
-		rt:   rt,
-		data: extractDocumentData(rt, false),
+		v:        v,
+		rv:       rv,
+		rt:       rt,
+		data:     extractDocumentData(rt, false),
+		accessor: accessor,
 	}
 }
 
@@ -399,7 +467,7 @@ func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
 
 	var (
 		data = documentData{
-			index: make(map[string]int, rt.NumField()),
+			index: make(map[string][]int, rt.NumField()),
 		}
 	)
 
@@ -415,12 +483,27 @@ func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
 			continue
 		}
 
-		data.index[name] = i
+		if et, ok := embeddedType(sf); ok {
+			extractEmbeddedData(&data, et, i)
+			continue
+		}
+
+		if _, promoted := data.index[name]; promoted {
+			// field declared in the outer struct shadows the promoted field.
+			data.fields = removeField(data.fields, name)
+		}
+
+		data.index[name] = []int{i}
 
-		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Interface || typ.Kind() == reflect.Slice {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
 			typ = typ.Elem()
 		}
 
+		if isLockVersion(sf, name) {
+			data.lockVersion = name
+			data.flag |= HasLockVersion
+		}
+
 		if typ.Kind() != reflect.Struct {
 			data.fields = append(data.fields, name)
 			continue
@@ -447,12 +530,21 @@ func extractDocumentData(rt reflect.Type, skipAssoc bool) documentData {
 				data.hasOne = append(data.hasOne, name)
 			case HasMany:
 				data.hasMany = append(data.hasMany, name)
+			case ManyToMany:
+				data.manyToMany = append(data.manyToMany, name)
 			}
 		}
 	}
 
 	data.primaryField, data.primaryIndex = searchPrimary(rt)
 
+	if s, ok := reflect.New(rt).Interface().(scoped); ok {
+		if filter := s.DefaultScope().WhereQuery; !filter.None() {
+			data.defaultScope = filter
+			data.flag |= HasDefaultScope
+		}
+	}
+
 	if !skipAssoc {
 		documentDataCache.Store(rt, data)
 	}
@@ -478,6 +570,52 @@ func extractFlag(rt reflect.Type, name string) DocumentFlag {
 	return flag
 }
 
+// isLockVersion returns true for integer field named lock_version or tagged with lock option.
+func isLockVersion(sf reflect.StructField, name string) bool {
+	switch sf.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return false
+	}
+
+	return name == "lock_version" || hasTagOption(sf.Tag.Get("db"), "lock")
+}
+
+// hasTagOption returns true if the tag contains the option, the first element is the field name.
+func hasTagOption(tag string, option string) bool {
+	options := strings.Split(tag, ",")
+	for i := 1; i < len(options); i++ {
+		if strings.TrimSpace(options[i]) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockVersion returns lock version field, its current value and the incremented value.
+func lockVersion(doc *Document) (string, interface{}, interface{}, bool) {
+	if !doc.Flag(HasLockVersion) {
+		return "", nil, nil, false
+	}
+
+	var (
+		field = doc.data.lockVersion
+		fv    = reflectValueFieldByIndex(doc.rv, doc.data.index[field], true)
+		next  = reflect.New(fv.Type()).Elem()
+	)
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		next.SetInt(fv.Int() + 1)
+	default:
+		next.SetUint(fv.Uint() + 1)
+	}
+
+	return field, fv.Interface(), next.Interface(), true
+}
+
 func fieldName(sf reflect.StructField) string {
 	if tag := sf.Tag.Get("db"); tag != "" {
 		name := strings.Split(tag, ",")[0]
@@ -494,7 +632,7 @@ func fieldName(sf reflect.StructField) string {
 	return snaker.CamelToSnake(sf.Name)
 }
 
-func searchPrimary(rt reflect.Type) ([]string, []int) {
+func searchPrimary(rt reflect.Type) ([]string, [][]int) {
 	if result, cached := primariesCache.Load(rt); cached {
 		p := result.(primaryData)
 		return p.field, p.index
@@ -502,8 +640,8 @@ func searchPrimary(rt reflect.Type) ([]string, []int) {
 
 	var (
 		field         []string
-		index         []int
-		fallbackIndex = -1
+		index         [][]int
+		fallbackIndex []int
 	)
 
 	if rt.Implements(rtPrimary) {
@@ -514,25 +652,12 @@ func searchPrimary(rt reflect.Type) ([]string, []int) {
 		field = v.PrimaryFields()
 		// index kept nil to mark interface usage
 	} else {
-		for i := 0; i < rt.NumField(); i++ {
-			sf := rt.Field(i)
-
-			if tag := sf.Tag.Get("db"); strings.HasSuffix(tag, ",primary") {
-				index = append(index, i)
-				field = append(field, fieldName(sf))
-				continue
-			}
-
-			// check fallback for id field
-			if strings.EqualFold("id", sf.Name) {
-				fallbackIndex = i
-			}
-		}
+		field, index, fallbackIndex = searchPrimaryIndex(rt)
 	}
 
-	if len(field) == 0 && fallbackIndex >= 0 {
+	if len(field) == 0 && fallbackIndex != nil {
 		field = []string{"id"}
-		index = []int{fallbackIndex}
+		index = [][]int{fallbackIndex}
 	}
 
 	primariesCache.Store(rt, primaryData{
@@ -543,6 +668,131 @@ func searchPrimary(rt reflect.Type) ([]string, []int) {
 	return field, index
 }
 
+func searchPrimaryIndex(rt reflect.Type) ([]string, [][]int, []int) {
+	var (
+		field         []string
+		index         [][]int
+		fallbackIndex []int
+	)
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+
+		if et, ok := embeddedType(sf); ok {
+			eField, eIndex, eFallbackIndex := searchPrimaryIndex(et)
+			for j := range eIndex {
+				index = append(index, append([]int{i}, eIndex[j]...))
+				field = append(field, eField[j])
+			}
+
+			// field declared directly in the struct takes precedence.
+			if fallbackIndex == nil && eFallbackIndex != nil {
+				fallbackIndex = append([]int{i}, eFallbackIndex...)
+			}
+
+			continue
+		}
+
+		if tag := sf.Tag.Get("db"); hasTagOption(tag, "primary") {
+			index = append(index, []int{i})
+			field = append(field, fieldName(sf))
+			continue
+		}
+
+		// check fallback for id field
+		if strings.EqualFold("id", sf.Name) {
+			fallbackIndex = []int{i}
+		}
+	}
+
+	return field, index, fallbackIndex
+}
+
+// embeddedType returns type of embedded struct which fields should be promoted to the document.
+// Embedded struct with explicit column name, or implementing sql.Scanner is treated as a regular field.
+func embeddedType(sf reflect.StructField) (reflect.Type, bool) {
+	if !sf.Anonymous || sf.PkgPath != "" {
+		return nil, false
+	}
+
+	if tag := sf.Tag.Get("db"); strings.Split(tag, ",")[0] != "" {
+		return nil, false
+	}
+
+	var (
+		typ = sf.Type
+	)
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || typ == rtTime || reflect.PtrTo(typ).Implements(rtScanner) {
+		return nil, false
+	}
+
+	return typ, true
+}
+
+// extractEmbeddedData promotes fields of embedded struct at given index to the document data.
+// Field that already exists takes precedence over the promoted field with the same name.
+func extractEmbeddedData(data *documentData, rt reflect.Type, index int) {
+	var (
+		embedded = extractDocumentData(rt, true)
+	)
+
+	for _, field := range embedded.fields {
+		if _, exist := data.index[field]; exist {
+			continue
+		}
+
+		data.index[field] = append([]int{index}, embedded.index[field]...)
+		data.fields = append(data.fields, field)
+	}
+
+	if data.lockVersion == "" && embedded.lockVersion != "" {
+		data.lockVersion = embedded.lockVersion
+	}
+
+	data.flag |= embedded.flag
+}
+
+func removeField(fields []string, name string) []string {
+	for i := range fields {
+		if fields[i] == name {
+			return append(fields[:i], fields[i+1:]...)
+		}
+	}
+
+	return fields
+}
+
+// reflectValueFieldByIndex returns nested field by index, nil embedded pointer will be allocated when init is true.
+// Invalid value is returned when it encounter nil embedded pointer and init is false.
+func reflectValueFieldByIndex(rv reflect.Value, index []int, init bool) reflect.Value {
+	if len(index) == 1 {
+		return rv.Field(index[0])
+	}
+
+	for depth, i := range index {
+		if depth > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				if !init {
+					return reflect.Value{}
+				}
+
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(i)
+	}
+
+	return rv
+}
+
 func tableName(rt reflect.Type) string {
 	// check for cache
 	if name, cached := tablesCache.Load(rt); cached {
@@ -1,7 +1,6 @@
 package rel
 
 import (
-	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
@@ -174,9 +173,10 @@ func TestDocument_Fields(t *testing.T) {
 			C []byte     `db:",primary"`
 			D bool       `db:"D"`
 			E []*float64 `db:"-"`
+			F interface{}
 		}{}
 		doc    = NewDocument(&record)
-		fields = []string{"a", "b", "c", "D"}
+		fields = []string{"a", "b", "c", "D", "f"}
 	)
 
 	assert.Equal(t, fields, doc.Fields())
@@ -203,6 +203,72 @@ func TestDocument_Index(t *testing.T) {
 	assert.Equal(t, index, doc.Index())
 }
 
+func TestDocument_embedded(t *testing.T) {
+	var (
+		comment = Comment{Model: Model{ID: 1}, Body: "body"}
+		doc     = NewDocument(&comment)
+		index   = map[string]int{
+			"body": 1,
+		}
+		indexPath = map[string][]int{
+			"id":         {0, 0},
+			"body":       {1},
+			"created_by": {2, 0},
+			"updated_by": {2, 1},
+			"created_at": {3, 0},
+			"updated_at": {3, 1},
+		}
+	)
+
+	assert.Equal(t, "comments", doc.Table())
+	assert.Equal(t, []string{"id", "body", "created_by", "updated_by", "created_at", "updated_at"}, doc.Fields())
+	assert.Equal(t, index, doc.Index())
+	assert.Equal(t, indexPath, doc.IndexPath())
+	assert.Equal(t, "id", doc.PrimaryField())
+	assert.Equal(t, 1, doc.PrimaryValue())
+	assert.True(t, doc.Flag(HasCreatedAt))
+	assert.True(t, doc.Flag(HasUpdatedAt))
+	assert.False(t, doc.Flag(HasDeletedAt))
+
+	typ, ok := doc.Type("created_by")
+	assert.True(t, ok)
+	assert.Equal(t, reflect.TypeOf(""), typ)
+
+	// nil embedded pointer.
+	value, ok := doc.Value("created_by")
+	assert.True(t, ok)
+	assert.Nil(t, value)
+	assert.Nil(t, comment.Audit)
+
+	assert.True(t, doc.SetValue("created_by", "admin"))
+	assert.Equal(t, &Audit{CreatedBy: "admin"}, comment.Audit)
+
+	value, ok = doc.Value("created_by")
+	assert.True(t, ok)
+	assert.Equal(t, "admin", value)
+}
+
+func TestDocument_embeddedOverride(t *testing.T) {
+	var (
+		record = struct {
+			Model
+			Timestamps `db:"timestamps"`
+			ID         string
+		}{}
+		doc = NewDocument(&record)
+	)
+
+	record.ID = "outer"
+	record.Model.ID = 1
+
+	assert.Equal(t, []string{"timestamps", "id"}, doc.Fields())
+	assert.Equal(t, 2, doc.Index()["id"])
+	assert.Equal(t, []int{2}, doc.IndexPath()["id"])
+	assert.Equal(t, "id", doc.PrimaryField())
+	assert.Equal(t, "outer", doc.PrimaryValue())
+	assert.False(t, doc.Flag(HasCreatedAt))
+}
+
 func TestDocument_Types(t *testing.T) {
 	var (
 		record = struct {
@@ -359,11 +425,11 @@ func TestDocument_Scanners(t *testing.T) {
 		scanners = []interface{}{
 			Nullable(&record.Name),
 			Nullable(&record.ID),
-			&sql.RawBytes{},
+			nopScanner{},
 			Nullable(&record.Data),
 			Nullable(&record.Number),
 			&record.Address,
-			&sql.RawBytes{},
+			nopScanner{},
 		}
 	)
 
@@ -490,6 +556,67 @@ func TestDocument(t *testing.T) {
 	}
 }
 
+func TestDocument_lockVersion(t *testing.T) {
+	var (
+		invoice = Invoice{ID: 1, LockVersion: 2}
+		doc     = NewDocument(&invoice)
+	)
+
+	field, version, next, ok := lockVersion(doc)
+	assert.True(t, doc.Flag(HasLockVersion))
+	assert.True(t, ok)
+	assert.Equal(t, "lock_version", field)
+	assert.Equal(t, 2, version)
+	assert.Equal(t, 3, next)
+}
+
+func TestDocument_lockVersion_usingTag(t *testing.T) {
+	var (
+		record = struct {
+			ID      int
+			Version uint16 `db:"revision,lock"`
+		}{Version: 5}
+		doc = NewDocument(&record)
+	)
+
+	field, version, next, ok := lockVersion(doc)
+	assert.True(t, ok)
+	assert.Equal(t, "revision", field)
+	assert.Equal(t, uint16(5), version)
+	assert.Equal(t, uint16(6), next)
+}
+
+func TestDocument_lockVersion_tagOptions(t *testing.T) {
+	var (
+		record = struct {
+			ID      int
+			Version int `db:"version,lock,primary"`
+			Blocked int `db:"blocked,unlock"`
+		}{Version: 1}
+		doc = NewDocument(&record)
+	)
+
+	field, _, _, ok := lockVersion(doc)
+	assert.True(t, ok)
+	assert.Equal(t, "version", field)
+	assert.Equal(t, []string{"version"}, doc.PrimaryFields())
+}
+
+func TestDocument_lockVersion_notDefined(t *testing.T) {
+	_, _, _, ok := lockVersion(NewDocument(&User{}))
+	assert.False(t, ok)
+}
+
+func TestDocument_defaultScope(t *testing.T) {
+	var (
+		doc = NewDocument(&Ticket{})
+	)
+
+	assert.True(t, doc.Flag(HasDefaultScope))
+	assert.Equal(t, Ne("status", "archived"), doc.data.defaultScope)
+	assert.False(t, NewDocument(&User{}).Flag(HasDefaultScope))
+}
+
 func TestDocument_notPtr(t *testing.T) {
 	assert.Panics(t, func() {
 		NewDocument(User{}).Table()
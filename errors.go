@@ -1,9 +1,30 @@
 package rel
 
+import (
+	"errors"
+)
+
 var (
 	// ErrNotFound returned when records not found.
 	ErrNotFound = NotFoundError{}
 
+	// ErrInvalidCursor returned when pagination cursor is malformed or doesn't match the sort fields.
+	ErrInvalidCursor = errors.New("rel: invalid pagination cursor")
+
+	// ErrTenantNotFound returned when operation is scoped by tenant, but the context doesn't contain the tenant.
+	ErrTenantNotFound = errors.New("rel: tenant not found in context")
+
+	// ErrTenantConflictUpdate returned when conflicting insertion of tenant scoped record is set to update,
+	// since the conflicting record may belong to other tenant.
+	ErrTenantConflictUpdate = errors.New("rel: conflict update is not supported on tenant scoped record")
+
+	// ErrTenantFieldUpdate returned when update of tenant scoped record changes the tenant field.
+	ErrTenantFieldUpdate = errors.New("rel: tenant field of tenant scoped record cannot be updated")
+
+	// ErrStaleRecord is an auxiliary variable for error handling.
+	// This is only to be used when checking error with errors.Is(err, ErrStaleRecord).
+	ErrStaleRecord = StaleRecordError{}
+
 	// ErrCheckConstraint is an auxiliary variable for error handling.
 	// This is only to be used when checking error with errors.Is(err, ErrCheckConstraint).
 	ErrCheckConstraint = ConstraintError{Type: CheckConstraint}
@@ -33,6 +54,26 @@ func (nfe NotFoundError) Error() string {
 	return "Record not found"
 }
 
+// StaleRecordError returned when update or delete is performed using an outdated lock version.
+type StaleRecordError struct {
+	Table string
+}
+
+// Is returns true when target error is also a StaleRecordError.
+func (sre StaleRecordError) Is(target error) bool {
+	_, ok := target.(StaleRecordError)
+	return ok
+}
+
+// Error message.
+func (sre StaleRecordError) Error() string {
+	if sre.Table != "" {
+		return "Stale record: " + sre.Table + " has been modified"
+	}
+
+	return "Stale record"
+}
+
 // ConstraintType defines the type of constraint error.
 type ConstraintType int8
 
@@ -11,6 +11,13 @@ func TestNoResultError(t *testing.T) {
 	assert.Equal(t, "Record not found", NotFoundError{}.Error())
 }
 
+func TestStaleRecordError(t *testing.T) {
+	assert.Equal(t, "Stale record", StaleRecordError{}.Error())
+	assert.Equal(t, "Stale record: invoices has been modified", StaleRecordError{Table: "invoices"}.Error())
+	assert.True(t, errors.Is(StaleRecordError{Table: "invoices"}, ErrStaleRecord))
+	assert.False(t, errors.Is(NotFoundError{}, ErrStaleRecord))
+}
+
 func TestConstraintType(t *testing.T) {
 	assert.Equal(t, "CheckConstraint", CheckConstraint.String())
 	assert.Equal(t, "NotNullConstraint", NotNullConstraint.String())
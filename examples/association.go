@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
 )
 
@@ -40,6 +41,16 @@ type Transaction struct {
 	// contains primary key of other struct.
 	Buyer   User `ref:"buyer_id" fk:"id"`
 	BuyerID int
+
+	// many to many tags.
+	// linked using transaction_tags table which contains transaction_id and tag_id.
+	Tags []Tag `through:"transaction_tags"`
+}
+
+// Tag schema.
+type Tag struct {
+	ID   int
+	Name string
 }
 
 // Address schema.
@@ -98,6 +109,17 @@ func PreloadHasManyFilter(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// PreloadManyToMany docs example.
+func PreloadManyToMany(ctx context.Context, repo rel.Repository) error {
+	var transaction Transaction
+
+	/// [preload-many-to-many]
+	err := repo.Preload(ctx, &transaction, "tags")
+	/// [preload-many-to-many]
+
+	return err
+}
+
 // PreloadNested docs example.
 func PreloadNested(ctx context.Context, repo rel.Repository) error {
 	var transaction Transaction
@@ -109,6 +131,47 @@ func PreloadNested(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// PreloadTree docs example.
+func PreloadTree(ctx context.Context, repo rel.Repository) error {
+	var users []User
+
+	/// [preload-tree]
+	err := repo.FindAll(ctx, &users, where.Gte("age", 17), rel.Preload("transactions",
+		where.Eq("status", "paid"),
+		rel.Preload("tags"),
+	))
+	/// [preload-tree]
+
+	return err
+}
+
+// PreloadJoin docs example.
+func PreloadJoin(ctx context.Context, repo rel.Repository) error {
+	var transactions []Transaction
+
+	/// [preload-join]
+	err := repo.FindAll(ctx, &transactions, where.Eq("status", "paid"), rel.PreloadJoin("buyer",
+		rel.Preload("address"),
+	))
+	/// [preload-join]
+
+	return err
+}
+
+// PreloadLimitPerParent docs example.
+func PreloadLimitPerParent(ctx context.Context, repo rel.Repository) error {
+	var users []User
+
+	/// [preload-limit-per-parent]
+	err := repo.FindAll(ctx, &users, rel.Preload("transactions",
+		sort.Desc("id"),
+		rel.LimitPerParent(3),
+	))
+	/// [preload-limit-per-parent]
+
+	return err
+}
+
 // InsertAssociation docs example.
 func InsertAssociation(ctx context.Context, repo rel.Repository) error {
 	/// [insert-association]
@@ -168,3 +231,20 @@ func UpdateAssociationWithMap(ctx context.Context, repo rel.Repository) error {
 
 	return err
 }
+
+// UpdateManyToMany docs example.
+func UpdateManyToMany(ctx context.Context, repo rel.Repository) error {
+	var transaction Transaction
+
+	/// [update-many-to-many]
+	changeset := rel.NewChangeset(&transaction)
+
+	// Links existing tag with id 1 and creates a new tag.
+	transaction.Tags = append(transaction.Tags, Tag{ID: 1}, Tag{Name: "urgent"})
+
+	// Inserts new tag and links both tags in transaction_tags table.
+	err := repo.Update(ctx, &transaction, changeset)
+	/// [update-many-to-many]
+
+	return err
+}
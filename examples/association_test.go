@@ -6,6 +6,7 @@ import (
 
 	"github.com/Fs02/rel"
 	"github.com/Fs02/rel/reltest"
+	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
 	"github.com/stretchr/testify/assert"
 )
@@ -74,6 +75,21 @@ func TestPreloadHasManyFilter(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestPreloadManyToMany(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [preload-many-to-many]
+	tags := []Tag{{ID: 1, Name: "paid"}}
+	repo.ExpectPreload("tags").Result(tags)
+	/// [preload-many-to-many]
+
+	assert.Nil(t, PreloadManyToMany(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestPreloadNested(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -90,6 +106,56 @@ func TestPreloadNested(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestPreloadTree(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [preload-tree]
+	repo.ExpectFindAll(where.Gte("age", 17), rel.Preload("transactions",
+		where.Eq("status", "paid"),
+		rel.Preload("tags"),
+	)).Result([]User{{ID: 1, Name: "Rel"}})
+	/// [preload-tree]
+
+	assert.Nil(t, PreloadTree(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
+func TestPreloadJoin(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [preload-join]
+	repo.ExpectFindAll(where.Eq("status", "paid"), rel.PreloadJoin("buyer",
+		rel.Preload("address"),
+	)).Result([]Transaction{{ID: 1, Buyer: User{ID: 1, Name: "Rel"}}})
+	/// [preload-join]
+
+	assert.Nil(t, PreloadJoin(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
+func TestPreloadLimitPerParent(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [preload-limit-per-parent]
+	repo.ExpectFindAll(rel.Preload("transactions",
+		sort.Desc("id"),
+		rel.LimitPerParent(3),
+	)).Result([]User{{ID: 1, Name: "Rel"}})
+	/// [preload-limit-per-parent]
+
+	assert.Nil(t, PreloadLimitPerParent(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestInsertAssociation(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -138,3 +204,17 @@ func TestUpdateAssociationWithMap(t *testing.T) {
 	assert.Nil(t, UpdateAssociationWithMap(ctx, repo))
 	repo.AssertExpectations(t)
 }
+
+func TestUpdateManyToMany(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [update-many-to-many]
+	repo.ExpectUpdate().ForType("main.Transaction")
+	/// [update-many-to-many]
+
+	assert.Nil(t, UpdateManyToMany(ctx, repo))
+	repo.AssertExpectations(t)
+}
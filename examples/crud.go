@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/Fs02/rel"
 	"github.com/Fs02/rel/where"
@@ -45,6 +47,37 @@ func CrudInsertAll(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// CrudUpsert docs example.
+func CrudUpsert(ctx context.Context, repo rel.Repository) error {
+	/// [upsert]
+	book := Book{
+		ID:       1,
+		Title:    "Rel for dummies",
+		Category: "education",
+	}
+
+	// Insert or replace existing book with the same primary key.
+	err := repo.Upsert(ctx, &book)
+	/// [upsert]
+
+	return err
+}
+
+// CrudInsertOnConflict docs example.
+func CrudInsertOnConflict(ctx context.Context, repo rel.Repository) error {
+	/// [insert-on-conflict]
+	book := Book{
+		Title:    "Rel for dummies",
+		Category: "education",
+	}
+
+	// Only update category when book with the same title exists.
+	err := repo.Insert(ctx, &book, rel.OnConflictKeyUpdate("title", "category"))
+	/// [insert-on-conflict]
+
+	return err
+}
+
 // CrudFind docs example.
 func CrudFind(ctx context.Context, repo rel.Repository) error {
 	/// [find]
@@ -129,3 +162,31 @@ func CrudDeleteAll(ctx context.Context, repo rel.Repository) error {
 
 	return err
 }
+
+/// [hooks]
+
+// Article is a model that uses lifecycle hooks.
+type Article struct {
+	ID    int
+	Title string
+	Slug  string
+}
+
+// BeforeInsert generates slug from title before the article is inserted.
+func (a *Article) BeforeInsert(ctx context.Context, mutation *rel.Mutation) error {
+	if a.Title == "" {
+		return errors.New("title is required")
+	}
+
+	a.Slug = strings.ToLower(strings.ReplaceAll(a.Title, " ", "-"))
+	mutation.Add(rel.Set("slug", a.Slug))
+	return nil
+}
+
+/// [hooks]
+
+// CrudHooks docs example.
+func CrudHooks(ctx context.Context, repo rel.Repository) error {
+	article := Article{Title: "Hello World"}
+	return repo.Insert(ctx, &article)
+}
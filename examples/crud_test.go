@@ -87,6 +87,34 @@ func TestCrudInsertAll(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestCrudUpsert(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [upsert]
+	repo.ExpectUpsert().ForType("main.Book")
+	/// [upsert]
+
+	assert.Nil(t, CrudUpsert(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
+func TestCrudInsertOnConflict(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [insert-on-conflict]
+	repo.ExpectInsert(rel.OnConflictKeyUpdate("title", "category")).ForType("main.Book")
+	/// [insert-on-conflict]
+
+	assert.Nil(t, CrudInsertOnConflict(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestCrudFind(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -222,3 +250,18 @@ func TestCrudDeleteAll(t *testing.T) {
 	assert.Nil(t, CrudDeleteAll(ctx, repo))
 	repo.AssertExpectations(t)
 }
+
+func TestCrudHooks(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		article  = Article{Title: "Hello World"}
+		mutation rel.Mutation
+	)
+
+	assert.Nil(t, article.BeforeInsert(ctx, &mutation))
+	assert.Equal(t, "hello-world", article.Slug)
+	assert.Equal(t, rel.Set("slug", "hello-world"), mutation.Mutates["slug"])
+
+	article = Article{}
+	assert.Equal(t, errors.New("title is required"), article.BeforeInsert(ctx, &mutation))
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/expr"
 	"github.com/Fs02/rel/join"
 	"github.com/Fs02/rel/sort"
 	"github.com/Fs02/rel/where"
@@ -90,6 +91,26 @@ func QueriesConditionAdvancedAlias(ctx context.Context, repo rel.Repository) err
 	return err
 }
 
+// QueriesConditionSubQuery docs example.
+func QueriesConditionSubQuery(ctx context.Context, repo rel.Repository) error {
+	/// [condition-subquery]
+	var books []Book
+	err := repo.FindAll(ctx, &books, where.In("id", rel.Select("book_id").From("reviews").Where(where.Gte("rating", 4))))
+	/// [condition-subquery]
+
+	return err
+}
+
+// QueriesConditionExists docs example.
+func QueriesConditionExists(ctx context.Context, repo rel.Repository) error {
+	/// [condition-exists]
+	var books []Book
+	err := repo.FindAll(ctx, &books, where.NotExists(rel.From("reviews").Where(where.Fragment("reviews.book_id = books.id"))))
+	/// [condition-exists]
+
+	return err
+}
+
 // QueriesSorting docs example.
 func QueriesSorting(ctx context.Context, repo rel.Repository) error {
 	/// [sorting]
@@ -140,6 +161,27 @@ func QueriesSelect(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// BookRank is result of select expression example.
+type BookRank struct {
+	ID    int
+	Title string
+	Rank  int
+	Total int
+}
+
+// QueriesSelectExpr docs example.
+func QueriesSelectExpr(ctx context.Context, repo rel.Repository) error {
+	/// [select-expr]
+	var ranks []BookRank
+	err := repo.FindAll(ctx, &ranks, rel.From("books").Select("id", "title").SelectExpr(
+		expr.RowNumber().Over(expr.PartitionBy("category").SortDesc("price")).As("rank"),
+		expr.Count("*").Over(expr.PartitionBy("category")).As("total"),
+	))
+	/// [select-expr]
+
+	return err
+}
+
 // QueriesTable docs example.
 func QueriesTable(ctx context.Context, repo rel.Repository) error {
 	/// [table]
@@ -246,6 +288,36 @@ func QueriesJoinFragment(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// QueriesWith docs example.
+func QueriesWith(ctx context.Context, repo rel.Repository) error {
+	/// [with]
+	var books []Book
+	err := repo.FindAll(ctx, &books, rel.With("cheap_books", rel.From("books").Where(where.Lt("price", 50))).From("cheap_books").Where(where.Eq("available", true)))
+	/// [with]
+
+	return err
+}
+
+// Category schema.
+type Category struct {
+	ID       int
+	ParentID *int
+	Name     string
+}
+
+// QueriesWithRecursive docs example.
+func QueriesWithRecursive(ctx context.Context, repo rel.Repository) error {
+	/// [with-recursive]
+	var categories []Category
+	tree := rel.From("categories").Where(where.Eq("id", 1)).
+		UnionAll(rel.Select("categories.*").From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))
+
+	err := repo.FindAll(ctx, &categories, rel.WithRecursive("tree", tree).From("tree"))
+	/// [with-recursive]
+
+	return err
+}
+
 // QueriesLock docs example.
 func QueriesLock(ctx context.Context, repo rel.Repository) error {
 	/// [lock]
@@ -306,6 +378,38 @@ func QueriesCountWithCondition(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// QueriesAggregateFloat docs example.
+func QueriesAggregateFloat(ctx context.Context, repo rel.Repository) error {
+	/// [aggregate-float]
+	avg, err := repo.AggregateFloat(ctx, rel.From("books").Where(where.Eq("available", true)), "avg", "price")
+	/// [aggregate-float]
+
+	_ = avg
+	return err
+}
+
+// CategoryStat is result of aggregate group example.
+type CategoryStat struct {
+	Category string
+	Count    int
+	AvgPrice float64
+	MaxPrice float64
+}
+
+// QueriesAggregateGroup docs example.
+func QueriesAggregateGroup(ctx context.Context, repo rel.Repository) error {
+	/// [aggregate-group]
+	var stats []CategoryStat
+	err := repo.AggregateGroup(ctx, &stats, rel.From("books").Group("category"),
+		expr.Count("*"),
+		expr.Avg("price"),
+		expr.Max("price").As("max_price"),
+	)
+	/// [aggregate-group]
+
+	return err
+}
+
 // QueriesFindAndCountAll docs example.
 func QueriesFindAndCountAll(ctx context.Context, repo rel.Repository) error {
 	/// [find-and-count-all]
@@ -317,6 +421,26 @@ func QueriesFindAndCountAll(ctx context.Context, repo rel.Repository) error {
 	return err
 }
 
+// QueriesPaginate docs example.
+func QueriesPaginate(ctx context.Context, repo rel.Repository, cursor string) (string, error) {
+	/// [paginate]
+	var books []Book
+	page, err := rel.Paginate(10, sort.Desc("created_at"), sort.Desc("id")).After(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	if err := repo.FindAll(ctx, &books, where.Eq("available", true), page); err != nil {
+		return "", err
+	}
+
+	// cursor of the next page, empty when there's no more page.
+	next, err := page.Cursor(&books)
+	/// [paginate]
+
+	return next, err
+}
+
 // SendPromotionEmail tp demonstrate Iteration.
 func SendPromotionEmail(*User) {}
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/expr"
 	"github.com/Fs02/rel/join"
 	"github.com/Fs02/rel/reltest"
 	"github.com/Fs02/rel/sort"
@@ -150,6 +151,40 @@ func TestQueriesConditionAdvancedAlias(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestQueriesConditionSubQuery(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [condition-subquery]
+	books := []Book{
+		{ID: 1, Title: "REL for dummies", Price: 100},
+	}
+	repo.ExpectFindAll(where.In("id", rel.Select("book_id").From("reviews").Where(where.Gte("rating", 4)))).Result(books)
+	/// [condition-subquery]
+
+	assert.Nil(t, QueriesConditionSubQuery(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
+func TestQueriesConditionExists(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [condition-exists]
+	books := []Book{
+		{ID: 1, Title: "REL for dummies", Price: 100},
+	}
+	repo.ExpectFindAll(where.NotExists(rel.From("reviews").Where(where.Fragment("reviews.book_id = books.id")))).Result(books)
+	/// [condition-exists]
+
+	assert.Nil(t, QueriesConditionExists(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestQueriesSorting(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -235,6 +270,26 @@ func TestQueriesSelect(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestQueriesSelectExpr(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [select-expr]
+	ranks := []BookRank{
+		{ID: 1, Title: "REL for dummies", Rank: 1, Total: 2},
+	}
+	repo.ExpectFindAll(rel.From("books").Select("id", "title").SelectExpr(
+		expr.RowNumber().Over(expr.PartitionBy("category").SortDesc("price")).As("rank"),
+		expr.Count("*").Over(expr.PartitionBy("category")).As("total"),
+	)).Result(ranks)
+	/// [select-expr]
+
+	assert.Nil(t, QueriesSelectExpr(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestQueriesTable(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -408,6 +463,45 @@ func TestQueriesJoinFragment(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestQueriesWith(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [with]
+	books := []Book{
+		{ID: 1, Title: "REL for dummies", Price: 20},
+	}
+	repo.ExpectFindAll(rel.With("cheap_books", rel.From("books").Where(where.Lt("price", 50))).From("cheap_books").Where(where.Eq("available", true))).Result(books)
+	/// [with]
+
+	assert.Nil(t, QueriesWith(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
+func TestQueriesWithRecursive(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [with-recursive]
+	parentID := 1
+	categories := []Category{
+		{ID: 1, Name: "Programming"},
+		{ID: 2, ParentID: &parentID, Name: "Go"},
+	}
+	tree := rel.From("categories").Where(where.Eq("id", 1)).
+		UnionAll(rel.Select("categories.*").From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))
+
+	repo.ExpectFindAll(rel.WithRecursive("tree", tree).From("tree")).Result(categories)
+	/// [with-recursive]
+
+	assert.Nil(t, QueriesWithRecursive(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestQueriesLock(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -495,6 +589,40 @@ func TestQueriesCountWithCondition(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestQueriesAggregateFloat(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [aggregate-float]
+	repo.ExpectAggregateFloat(rel.From("books").Where(where.Eq("available", true)), "avg", "price").Result(12.5)
+	/// [aggregate-float]
+
+	assert.Nil(t, QueriesAggregateFloat(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
+func TestQueriesAggregateGroup(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [aggregate-group]
+	repo.ExpectAggregateGroup(rel.From("books").Group("category"),
+		expr.Count("*"),
+		expr.Avg("price"),
+		expr.Max("price").As("max_price"),
+	).Result([]CategoryStat{
+		{Category: "fiction", Count: 2, AvgPrice: 12.5, MaxPrice: 15},
+	})
+	/// [aggregate-group]
+
+	assert.Nil(t, QueriesAggregateGroup(ctx, repo))
+	repo.AssertExpectations(t)
+}
+
 func TestQueriesFindAndCountAll(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -512,6 +640,37 @@ func TestQueriesFindAndCountAll(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestQueriesPaginate(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	/// [paginate]
+	books := []Book{
+		{ID: 1, Title: "REL for dummies"},
+	}
+	repo.ExpectFindAll(where.Eq("available", true), rel.Paginate(10, sort.Desc("created_at"), sort.Desc("id"))).Result(books)
+	/// [paginate]
+
+	next, err := QueriesPaginate(ctx, repo, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "", next)
+	repo.AssertExpectations(t)
+}
+
+func TestQueriesPaginate_invalidCursor(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		repo = reltest.New()
+	)
+
+	next, err := QueriesPaginate(ctx, repo, "invalid")
+	assert.Equal(t, rel.ErrInvalidCursor, err)
+	assert.Equal(t, "", next)
+	repo.AssertExpectations(t)
+}
+
 func TestQueriesIteration(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -0,0 +1,48 @@
+// Package expr is syntatic sugar for building select expression.
+package expr
+
+import (
+	"github.com/Fs02/rel"
+)
+
+var (
+	// Field is alias for rel.NewSelectField
+	Field = rel.NewSelectField
+
+	// Aggregate is alias for rel.NewSelectAggregate
+	Aggregate = rel.NewSelectAggregate
+
+	// RowNumber is alias for rel.NewRowNumber
+	RowNumber = rel.NewRowNumber
+
+	// Case is alias for rel.NewCase
+	Case = rel.NewCase
+
+	// PartitionBy is alias for rel.NewWindow
+	PartitionBy = rel.NewWindow
+)
+
+// Count expression.
+func Count(field string) rel.SelectExpr {
+	return rel.NewSelectAggregate("count", field)
+}
+
+// Sum expression.
+func Sum(field string) rel.SelectExpr {
+	return rel.NewSelectAggregate("sum", field)
+}
+
+// Avg expression.
+func Avg(field string) rel.SelectExpr {
+	return rel.NewSelectAggregate("avg", field)
+}
+
+// Max expression.
+func Max(field string) rel.SelectExpr {
+	return rel.NewSelectAggregate("max", field)
+}
+
+// Min expression.
+func Min(field string) rel.SelectExpr {
+	return rel.NewSelectAggregate("min", field)
+}
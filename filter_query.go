@@ -46,6 +46,11 @@ const (
 
 	// FilterFragmentOp is filter type for custom filter.
 	FilterFragmentOp
+
+	// FilterExistsOp is filter type for subquery existence check.
+	FilterExistsOp
+	// FilterNotExistsOp is filter type for subquery non existence check.
+	FilterNotExistsOp
 )
 
 // FilterQuery defines details of a coundition type.
@@ -175,6 +180,16 @@ func (fq FilterQuery) AndNotLike(field string, pattern string) FilterQuery {
 	return fq.and(NotLike(field, pattern))
 }
 
+// AndExists append exists expression using and.
+func (fq FilterQuery) AndExists(query Query) FilterQuery {
+	return fq.and(Exists(query))
+}
+
+// AndNotExists append not exists expression using and.
+func (fq FilterQuery) AndNotExists(query Query) FilterQuery {
+	return fq.and(NotExists(query))
+}
+
 // AndFragment append fragment using and.
 func (fq FilterQuery) AndFragment(expr string, values ...interface{}) FilterQuery {
 	return fq.and(FilterFragment(expr, values...))
@@ -240,6 +255,16 @@ func (fq FilterQuery) OrNotLike(field string, pattern string) FilterQuery {
 	return fq.or(NotLike(field, pattern))
 }
 
+// OrExists append exists expression using or.
+func (fq FilterQuery) OrExists(query Query) FilterQuery {
+	return fq.or(Exists(query))
+}
+
+// OrNotExists append not exists expression using or.
+func (fq FilterQuery) OrNotExists(query Query) FilterQuery {
+	return fq.or(NotExists(query))
+}
+
 // OrFragment append fragment using or.
 func (fq FilterQuery) OrFragment(expr string, values ...interface{}) FilterQuery {
 	return fq.or(FilterFragment(expr, values...))
@@ -292,6 +317,8 @@ func Not(inner ...FilterQuery) FilterQuery {
 			fq.Type = FilterNinOp
 		case FilterLikeOp:
 			fq.Type = FilterNotLikeOp
+		case FilterExistsOp:
+			fq.Type = FilterNotExistsOp
 		default:
 			return FilterQuery{
 				Type:  FilterNotOp,
@@ -379,6 +406,7 @@ func NotNil(field string) FilterQuery {
 }
 
 // In check whethers value of the field is included in values.
+// Values can also be a single subquery, example: In("id", Select("user_id").From("orders")).
 func In(field string, values ...interface{}) FilterQuery {
 	return FilterQuery{
 		Type:  FilterInOp,
@@ -492,6 +520,22 @@ func NotLike(field string, pattern string) FilterQuery {
 	}
 }
 
+// Exists check whether subquery returns any rows.
+func Exists(query Query) FilterQuery {
+	return FilterQuery{
+		Type:  FilterExistsOp,
+		Value: query,
+	}
+}
+
+// NotExists check whether subquery returns no rows.
+func NotExists(query Query) FilterQuery {
+	return FilterQuery{
+		Type:  FilterNotExistsOp,
+		Value: query,
+	}
+}
+
 // FilterFragment add custom filter.
 func FilterFragment(expr string, values ...interface{}) FilterQuery {
 	return FilterQuery{
@@ -525,6 +569,39 @@ func filterDocumentPrimary(pFields []string, pValues []interface{}, op FilterOp)
 
 }
 
+// filterKeyset returns filter that matches records positioned after values in the given sort order.
+// example: sorted by a asc and b desc, after (1, 2) produces a>1 OR (a=1 AND b<2).
+func filterKeyset(sorts []SortQuery, values []interface{}) FilterQuery {
+	var (
+		filters = make([]FilterQuery, len(sorts))
+	)
+
+	for i := range sorts {
+		var (
+			op    = FilterGtOp
+			inner = make([]FilterQuery, i+1)
+		)
+
+		for j := 0; j < i; j++ {
+			inner[j] = Eq(sorts[j].Field, values[j])
+		}
+
+		if sorts[i].Desc() {
+			op = FilterLtOp
+		}
+
+		inner[i] = FilterQuery{
+			Type:  op,
+			Field: sorts[i].Field,
+			Value: values[i],
+		}
+
+		filters[i] = And(inner...)
+	}
+
+	return Or(filters...)
+}
+
 func filterCollection(col *Collection) FilterQuery {
 	var (
 		pFields = col.PrimaryFields()
@@ -584,7 +661,7 @@ func filterHasOne(assoc Association, asssocDoc *Document) (FilterQuery, error) {
 		fField = assoc.ForeignField()
 		fValue = assoc.ForeignValue()
 		rValue = assoc.ReferenceValue()
-		filter = filterDocument(asssocDoc).AndEq(fField, rValue)
+		filter = filterPolymorphic(assoc, filterDocument(asssocDoc).AndEq(fField, rValue))
 	)
 
 	if rValue != fValue {
@@ -597,3 +674,12 @@ func filterHasOne(assoc Association, asssocDoc *Document) (FilterQuery, error) {
 
 	return filter, nil
 }
+
+// filterPolymorphic adds owner type filter for polymorphic has one and has many association.
+func filterPolymorphic(assoc Association, filter FilterQuery) FilterQuery {
+	if field := assoc.PolymorphicField(); field != "" && assoc.Type() != BelongsTo {
+		filter = filter.AndEq(field, assoc.PolymorphicValue())
+	}
+
+	return filter
+}
@@ -429,6 +429,11 @@ func TestFilterQuery_Not(t *testing.T) {
 			FilterLikeOp,
 			FilterNotLikeOp,
 		},
+		{
+			`Not Exists`,
+			FilterExistsOp,
+			FilterNotExistsOp,
+		},
 		{
 			`And Op`,
 			FilterAndOp,
@@ -597,6 +602,36 @@ func TestFilterQuery_AndFragment(t *testing.T) {
 	}, FilterQuery{}.AndFragment("expr", "value"))
 }
 
+func TestFilterQuery_AndExists(t *testing.T) {
+	var (
+		query = From("orders").Where(Eq("status", "paid"))
+	)
+
+	assert.Equal(t, FilterQuery{
+		Inner: []FilterQuery{
+			{
+				Type:  FilterExistsOp,
+				Value: query,
+			},
+		},
+	}, FilterQuery{}.AndExists(query))
+}
+
+func TestFilterQuery_AndNotExists(t *testing.T) {
+	var (
+		query = From("orders").Where(Eq("status", "paid"))
+	)
+
+	assert.Equal(t, FilterQuery{
+		Inner: []FilterQuery{
+			{
+				Type:  FilterNotExistsOp,
+				Value: query,
+			},
+		},
+	}, FilterQuery{}.AndNotExists(query))
+}
+
 func TestFilterQuery_OrEq(t *testing.T) {
 	assert.Equal(t, FilterQuery{
 		Type: FilterOrOp,
@@ -826,6 +861,38 @@ func TestNotNil(t *testing.T) {
 	}, NotNil("field"))
 }
 
+func TestFilterQuery_OrExists(t *testing.T) {
+	var (
+		query = From("orders").Where(Eq("status", "paid"))
+	)
+
+	assert.Equal(t, FilterQuery{
+		Type: FilterOrOp,
+		Inner: []FilterQuery{
+			{
+				Type:  FilterExistsOp,
+				Value: query,
+			},
+		},
+	}, FilterQuery{}.OrExists(query))
+}
+
+func TestFilterQuery_OrNotExists(t *testing.T) {
+	var (
+		query = From("orders").Where(Eq("status", "paid"))
+	)
+
+	assert.Equal(t, FilterQuery{
+		Type: FilterOrOp,
+		Inner: []FilterQuery{
+			{
+				Type:  FilterNotExistsOp,
+				Value: query,
+			},
+		},
+	}, FilterQuery{}.OrNotExists(query))
+}
+
 func TestIn(t *testing.T) {
 	assert.Equal(t, FilterQuery{
 		Type:  FilterInOp,
@@ -834,6 +901,18 @@ func TestIn(t *testing.T) {
 	}, In("field", "value1", "value2"))
 }
 
+func TestIn_subQuery(t *testing.T) {
+	var (
+		query = Select("user_id").From("orders")
+	)
+
+	assert.Equal(t, FilterQuery{
+		Type:  FilterInOp,
+		Field: "id",
+		Value: []interface{}{query},
+	}, In("id", query))
+}
+
 func TestInInt(t *testing.T) {
 	assert.Equal(t, FilterQuery{
 		Type:  FilterInOp,
@@ -914,6 +993,28 @@ func TestFilterFragment(t *testing.T) {
 	}, FilterFragment("expr", "value"))
 }
 
+func TestExists(t *testing.T) {
+	var (
+		query = From("orders").Where(Eq("status", "paid"))
+	)
+
+	assert.Equal(t, FilterQuery{
+		Type:  FilterExistsOp,
+		Value: query,
+	}, Exists(query))
+}
+
+func TestNotExists(t *testing.T) {
+	var (
+		query = From("orders").Where(Eq("status", "paid"))
+	)
+
+	assert.Equal(t, FilterQuery{
+		Type:  FilterNotExistsOp,
+		Value: query,
+	}, NotExists(query))
+}
+
 func TestFilterDocument(t *testing.T) {
 	var (
 		user = User{ID: 1}
@@ -955,3 +1056,11 @@ func TestFilterCollection_compositePrimaryKey(t *testing.T) {
 
 	assert.Equal(t, Or(Eq("user_id", 1).AndEq("role_id", 2), Eq("user_id", 3).AndEq("role_id", 4)), filterCollection(col))
 }
+
+func TestFilterKeyset(t *testing.T) {
+	assert.Equal(t, Gt("id", 1), filterKeyset([]SortQuery{NewSortAsc("id")}, []interface{}{1}))
+	assert.Equal(t,
+		Or(Lt("age", 20), And(Eq("age", 20), Gt("name", "a")), And(Eq("age", 20), Eq("name", "a"), Gt("id", 1))),
+		filterKeyset([]SortQuery{NewSortDesc("age"), NewSortAsc("name"), NewSortAsc("id")}, []interface{}{20, "a", 1}),
+	)
+}
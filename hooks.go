@@ -0,0 +1,130 @@
+package rel
+
+import (
+	"context"
+)
+
+// BeforeInsertHook is implemented by records that need to be notified before it's inserted.
+// Mutation can be modified to add or remove mutates, returning an error cancels the insertion.
+type BeforeInsertHook interface {
+	BeforeInsert(ctx context.Context, mutation *Mutation) error
+}
+
+// AfterInsertHook is implemented by records that need to be notified after it's inserted.
+type AfterInsertHook interface {
+	AfterInsert(ctx context.Context) error
+}
+
+// BeforeUpdateHook is implemented by records that need to be notified before it's updated.
+// Mutation can be modified to add or remove mutates, returning an error cancels the update.
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx context.Context, mutation *Mutation) error
+}
+
+// AfterUpdateHook is implemented by records that need to be notified after it's updated.
+type AfterUpdateHook interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+// BeforeDeleteHook is implemented by records that need to be notified before it's deleted.
+// Returning an error cancels the deletion.
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+// AfterDeleteHook is implemented by records that need to be notified after it's deleted.
+type AfterDeleteHook interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// AfterFindHook is implemented by records that need to be notified after it's loaded using Find or FindAll.
+type AfterFindHook interface {
+	AfterFind(ctx context.Context) error
+}
+
+// hasHook returns true if the record implements any of the write hooks.
+// Write of such record is performed in a transaction, so error returned by after hooks rolls back the write.
+// AfterFindHook is excluded since it's never called on write.
+func hasHook(record interface{}) bool {
+	switch record.(type) {
+	case BeforeInsertHook, AfterInsertHook, BeforeUpdateHook, AfterUpdateHook, BeforeDeleteHook, AfterDeleteHook:
+		return true
+	}
+
+	return false
+}
+
+func beforeInsert(ctx context.Context, doc *Document, mutation *Mutation) error {
+	if hook, ok := doc.v.(BeforeInsertHook); ok {
+		return hook.BeforeInsert(ctx, mutation)
+	}
+
+	return nil
+}
+
+func afterInsert(ctx context.Context, doc *Document) error {
+	if hook, ok := doc.v.(AfterInsertHook); ok {
+		return hook.AfterInsert(ctx)
+	}
+
+	return nil
+}
+
+func beforeUpdate(ctx context.Context, doc *Document, mutation *Mutation) error {
+	if hook, ok := doc.v.(BeforeUpdateHook); ok {
+		return hook.BeforeUpdate(ctx, mutation)
+	}
+
+	return nil
+}
+
+func afterUpdate(ctx context.Context, doc *Document) error {
+	if hook, ok := doc.v.(AfterUpdateHook); ok {
+		return hook.AfterUpdate(ctx)
+	}
+
+	return nil
+}
+
+func beforeDelete(ctx context.Context, doc *Document) error {
+	if hook, ok := doc.v.(BeforeDeleteHook); ok {
+		return hook.BeforeDelete(ctx)
+	}
+
+	return nil
+}
+
+func afterDelete(ctx context.Context, doc *Document) error {
+	if hook, ok := doc.v.(AfterDeleteHook); ok {
+		return hook.AfterDelete(ctx)
+	}
+
+	return nil
+}
+
+func afterFind(ctx context.Context, doc *Document) error {
+	if hook, ok := doc.v.(AfterFindHook); ok {
+		return hook.AfterFind(ctx)
+	}
+
+	return nil
+}
+
+func afterFindAll(ctx context.Context, col *Collection) error {
+	// every element shares the same type, skip when the first one doesn't implement the hook.
+	if col.Len() == 0 {
+		return nil
+	}
+
+	if _, ok := col.Get(0).v.(AfterFindHook); !ok {
+		return nil
+	}
+
+	for i := 0; i < col.Len(); i++ {
+		if err := afterFind(ctx, col.Get(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
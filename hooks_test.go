@@ -0,0 +1,341 @@
+package rel
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type Hooked struct {
+	ID       int
+	Name     string
+	Slug     string
+	Calls    []string `db:"-"`
+	Err      error    `db:"-"`
+	AfterErr error    `db:"-"`
+}
+
+type HookedOwner struct {
+	ID      int
+	Hookeds []Hooked `ref:"id" fk:"id"`
+}
+
+func (h *Hooked) call(name string) error {
+	h.Calls = append(h.Calls, name)
+	return h.Err
+}
+
+func (h *Hooked) BeforeInsert(ctx context.Context, mutation *Mutation) error {
+	if err := h.call("BeforeInsert"); err != nil {
+		return err
+	}
+
+	h.Slug = strings.ToLower(h.Name)
+	mutation.Add(Set("slug", h.Slug))
+	return nil
+}
+
+func (h *Hooked) AfterInsert(ctx context.Context) error {
+	if err := h.call("AfterInsert"); err != nil {
+		return err
+	}
+
+	return h.AfterErr
+}
+
+func (h *Hooked) BeforeUpdate(ctx context.Context, mutation *Mutation) error {
+	if err := h.call("BeforeUpdate"); err != nil {
+		return err
+	}
+
+	if mut, ok := mutation.Mutates["name"]; ok {
+		h.Slug = strings.ToLower(mut.Value.(string))
+		mutation.Add(Set("slug", h.Slug))
+	}
+
+	return nil
+}
+
+func (h *Hooked) AfterUpdate(ctx context.Context) error {
+	return h.call("AfterUpdate")
+}
+
+func (h *Hooked) BeforeDelete(ctx context.Context) error {
+	return h.call("BeforeDelete")
+}
+
+func (h *Hooked) AfterDelete(ctx context.Context) error {
+	return h.call("AfterDelete")
+}
+
+func (h *Hooked) AfterFind(ctx context.Context) error {
+	return h.call("AfterFind")
+}
+
+type FindHooked struct {
+	ID   int
+	Name string
+}
+
+func (f *FindHooked) AfterFind(ctx context.Context) error {
+	return nil
+}
+
+func TestHooks_insertFindHookOnly(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		record  = FindHooked{Name: "Hello"}
+	)
+
+	// no transaction is started for record that only has read hook.
+	adapter.On("Insert", From("find_hookeds"), map[string]Mutate{"name": Set("name", "Hello")}, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &record))
+	assert.Equal(t, 1, record.ID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_insert(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		record  = Hooked{Name: "Hello"}
+		mutates = map[string]Mutate{
+			"name": Set("name", "Hello"),
+			"slug": Set("slug", "hello"),
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("hookeds"), mutates, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &record))
+	assert.Equal(t, 1, record.ID)
+	assert.Equal(t, "hello", record.Slug)
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_insertVeto(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		err     = errors.New("veto")
+		record  = Hooked{Name: "Hello", Err: err}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Insert(context.TODO(), &record))
+	assert.Equal(t, []string{"BeforeInsert"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_insertAfterError(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		err     = errors.New("after error")
+		record  = Hooked{Name: "Hello", AfterErr: err}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("hookeds"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Insert(context.TODO(), &record))
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_insertAll(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		records = []Hooked{{Name: "A"}, {Name: "B"}}
+		mutates = []map[string]Mutate{
+			{"name": Set("name", "A"), "slug": Set("slug", "a")},
+			{"name": Set("name", "B"), "slug": Set("slug", "b")},
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("InsertAll", From("hookeds"), mock.Anything, mutates, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &records))
+	assert.Equal(t, 1, records[0].ID)
+	assert.Equal(t, 2, records[1].ID)
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert"}, records[0].Calls)
+	assert.Equal(t, []string{"BeforeInsert", "AfterInsert"}, records[1].Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_update(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		record  = Hooked{ID: 1, Name: "Hello"}
+		mutates = map[string]Mutate{
+			"name": Set("name", "World"),
+			"slug": Set("slug", "world"),
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("hookeds").Where(Eq("id", 1)), mutates).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &record, Set("name", "World")))
+	assert.Equal(t, "World", record.Name)
+	assert.Equal(t, "world", record.Slug)
+	assert.Equal(t, []string{"BeforeUpdate", "AfterUpdate"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_updateVeto(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		err     = errors.New("veto")
+		record  = Hooked{ID: 1, Name: "Hello", Err: err}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Update(context.TODO(), &record, Set("name", "World")))
+	assert.Equal(t, []string{"BeforeUpdate"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_delete(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		record  = Hooked{ID: 1}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("hookeds").Where(Eq("id", 1))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &record))
+	assert.Equal(t, []string{"BeforeDelete", "AfterDelete"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_deleteVeto(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		err     = errors.New("veto")
+		record  = Hooked{ID: 1, Err: err}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Delete(context.TODO(), &record))
+	assert.Equal(t, []string{"BeforeDelete"}, record.Calls)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestHooks_find(t *testing.T) {
+	var (
+		record  Hooked
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("hookeds").Limit(1)
+		cur     = createCursor(1)
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &record, query))
+	assert.Equal(t, 10, record.ID)
+	assert.Equal(t, []string{"AfterFind"}, record.Calls)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestHooks_findAll(t *testing.T) {
+	var (
+		records []Hooked
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("hookeds")
+		cur     = createCursor(2)
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &records, query))
+	assert.Len(t, records, 2)
+	assert.Equal(t, []string{"AfterFind"}, records[0].Calls)
+	assert.Equal(t, []string{"AfterFind"}, records[1].Calls)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestHooks_preload(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		owner   = HookedOwner{ID: 10}
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("hookeds").Where(In("id", 10))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(10).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &owner, "hookeds"))
+	assert.Len(t, owner.Hookeds, 1)
+	assert.Equal(t, []string{"AfterFind"}, owner.Hookeds[0].Calls)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestHooks_iterate(t *testing.T) {
+	var (
+		record  Hooked
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("hookeds")
+		cur     = createCursor(1)
+		it      = repo.Iterate(context.TODO(), query, BatchSize(2))
+	)
+
+	adapter.On("Query", query.SortAsc("id").Limit(2)).Return(cur, nil).Once()
+
+	assert.Nil(t, it.Next(&record))
+	assert.Equal(t, []string{"AfterFind"}, record.Calls)
+	assert.Nil(t, it.Close())
+
+	adapter.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package rel
 import (
 	"context"
 	"io"
+	"reflect"
 )
 
 // Iterator alllows iterating through all record in database in batch.
@@ -49,14 +50,37 @@ func Finish(id ...interface{}) IteratorOption {
 	return finish(id)
 }
 
+type stream bool
+
+func (s stream) apply(i *iterator) {
+	i.stream = bool(s)
+}
+
+// Stream iterates all records using a single query, keeping the cursor open until the iteration is finished.
+// Records are streamed from the same result set, which preserves snapshot consistency across batches. BatchSize is ignored.
+func Stream() IteratorOption {
+	return stream(true)
+}
+
+// iteratorScope scopes the iterated query using the document of the record.
+type iteratorScope func(ddata documentData, query Query) (Query, error)
+
+func (is iteratorScope) apply(i *iterator) {
+	i.scope = is
+}
+
 type iterator struct {
 	ctx       context.Context
 	start     []interface{}
 	finish    []interface{}
 	batchSize int
 	current   int
+	stream    bool
+	keyset    bool
+	last      []interface{}
 	query     Query
 	adapter   Adapter
+	scope     iteratorScope
 	cursor    Cursor
 	fields    []string
 	closed    bool
@@ -72,7 +96,7 @@ func (i *iterator) Close() error {
 }
 
 func (i *iterator) Next(record interface{}) error {
-	if i.current%i.batchSize == 0 {
+	if i.current == 0 || (!i.stream && i.current%i.batchSize == 0) {
 		if err := i.fetch(i.ctx, record); err != nil {
 			return err
 		}
@@ -87,20 +111,47 @@ func (i *iterator) Next(record interface{}) error {
 		scanners = doc.Scanners(i.fields)
 	)
 
+	if err := i.cursor.Scan(scanners...); err != nil {
+		return err
+	}
+
+	if i.keyset {
+		i.remember(doc)
+	}
+
 	i.current++
-	return i.cursor.Scan(scanners...)
+	return afterFind(i.ctx, doc)
+}
+
+// remember sort values of the last scanned record, used as the starting point of the next batch.
+func (i *iterator) remember(doc *Document) {
+	if i.last == nil {
+		i.last = make([]interface{}, len(i.query.SortQuery))
+	}
+
+	for j := range i.query.SortQuery {
+		i.last[j], _ = doc.Value(keysetField(i.query.SortQuery[j].Field))
+	}
 }
 
 func (i *iterator) fetch(ctx context.Context, record interface{}) error {
 	if i.current == 0 {
-		i.init(record)
+		if err := i.init(record); err != nil {
+			return err
+		}
 	} else {
 		i.cursor.Close()
 	}
 
-	i.query = i.query.Limit(i.batchSize).Offset(i.current)
+	var (
+		query = i.query
+	)
+
+	if !i.stream {
+		query = i.batch()
+	}
 
-	cursor, err := i.adapter.Query(ctx, i.query)
+	cursor, err := i.adapter.Query(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -116,8 +167,26 @@ func (i *iterator) fetch(ctx context.Context, record interface{}) error {
 	return nil
 }
 
-func (i *iterator) init(record interface{}) {
+// batch returns query to fetch the next batch.
+func (i *iterator) batch() Query {
+	var (
+		query = i.query.Limit(i.batchSize)
+	)
+
+	if !i.keyset || hasNil(i.last) {
+		return query.Offset(i.current)
+	}
+
+	if i.last != nil {
+		return query.Where(filterKeyset(i.query.SortQuery, i.last))
+	}
+
+	return query
+}
+
+func (i *iterator) init(record interface{}) error {
 	var (
+		err error
 		doc = NewDocument(record)
 	)
 
@@ -125,6 +194,12 @@ func (i *iterator) init(record interface{}) {
 		i.query.Table = doc.Table()
 	}
 
+	if i.scope != nil {
+		if i.query, err = i.scope(doc.data, i.query); err != nil {
+			return err
+		}
+	}
+
 	if len(i.start) > 0 {
 		i.query = i.query.Where(filterDocumentPrimary(doc.PrimaryFields(), i.start, FilterGteOp))
 	}
@@ -134,6 +209,51 @@ func (i *iterator) init(record interface{}) {
 	}
 
 	i.query = i.query.SortAsc(doc.PrimaryFields()...)
+
+	// page using the last seen sort values when every sort field is a non null field of the record,
+	// otherwise (or when the last value is nil) fallback to offset based paging.
+	i.keyset = !i.stream
+	for _, sort := range i.query.SortQuery {
+		if !keysetable(doc, keysetField(sort.Field)) {
+			i.keyset = false
+			break
+		}
+	}
+
+	return nil
+}
+
+// keysetable returns true if the field is a primary field or can't hold null value.
+// records with null value never match the keyset filter, and would be skipped.
+func keysetable(doc *Document, field string) bool {
+	for _, primary := range doc.PrimaryFields() {
+		if primary == field {
+			return true
+		}
+	}
+
+	index, ok := doc.data.index[field]
+	if !ok {
+		return false
+	}
+
+	ft := doc.rt.FieldByIndex(index).Type
+	switch ft.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return false
+	}
+
+	return !reflect.PtrTo(ft).Implements(rtScanner)
+}
+
+func hasNil(values []interface{}) bool {
+	for i := range values {
+		if values[i] == nil {
+			return true
+		}
+	}
+
+	return false
 }
 
 func newIterator(ctx context.Context, adapter Adapter, query Query, options []IteratorOption) Iterator {
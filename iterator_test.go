@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestIterator(t *testing.T) {
@@ -21,10 +22,11 @@ func TestIterator(t *testing.T) {
 		it      = newIterator(context.TODO(), adapter, query, options)
 	)
 
+	// every mocked row has id 10.
 	query = query.From("users").SortAsc("id").Limit(5)
 	adapter.On("Query", query).Return(cur1, nil).Once()
-	adapter.On("Query", query.Offset(5)).Return(cur2, nil).Once()
-	adapter.On("Query", query.Offset(10)).Return(cur3, nil).Once()
+	adapter.On("Query", query.Where(Gt("id", 10))).Return(cur2, nil).Once()
+	adapter.On("Query", query.Where(Gt("id", 10))).Return(cur3, nil).Once()
 
 	recordsCount := 0
 	for {
@@ -52,6 +54,171 @@ func TestIterator(t *testing.T) {
 	cur3.AssertExpectations(t)
 }
 
+func TestIterator_keysetCompositeSort(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users").SortDesc("age")
+		cur1    = &testCursor{}
+		cur2    = &testCursor{}
+		options = []IteratorOption{BatchSize(2)}
+		it      = newIterator(context.TODO(), adapter, query, options)
+	)
+
+	cur1.On("Fields").Return([]string{"id", "name", "age"}, nil).Once()
+	cur1.On("Next").Return(true).Twice()
+	cur1.MockScan(1, "user 1", 20).Once()
+	cur1.MockScan(2, "user 2", 20).Once()
+	cur1.On("Close").Return(nil).Once()
+
+	cur2.On("Fields").Return([]string{"id", "name", "age"}, nil).Once()
+	cur2.On("Next").Return(true).Once()
+	cur2.MockScan(3, "user 3", 10).Once()
+	cur2.On("Next").Return(false).Once()
+	cur2.On("Close").Return(nil).Once()
+
+	query = query.SortAsc("id").Limit(2)
+	adapter.On("Query", query).Return(cur1, nil).Once()
+	adapter.On("Query", query.Where(Or(Lt("age", 20), And(Eq("age", 20), Gt("id", 2))))).Return(cur2, nil).Once()
+
+	var ids []int
+	for {
+		if err := it.Next(&user); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+
+		ids = append(ids, user.ID)
+	}
+	it.Close()
+
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	adapter.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+	cur2.AssertExpectations(t)
+}
+
+func TestIterator_offsetFallback(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users").SortAsc("score")
+		cur1    = createCursor(1)
+		cur2    = createCursor(0)
+		options = []IteratorOption{BatchSize(1)}
+		it      = newIterator(context.TODO(), adapter, query, options)
+	)
+
+	// score is not a field of user, unable to page using keyset.
+	query = query.SortAsc("id").Limit(1)
+	adapter.On("Query", query).Return(cur1, nil).Once()
+	adapter.On("Query", query.Offset(1)).Return(cur2, nil).Once()
+
+	for {
+		if err := it.Next(&user); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+	it.Close()
+
+	// the last next is not called because it's already refetched.
+	cur1.Next()
+
+	adapter.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+	cur2.AssertExpectations(t)
+}
+
+func TestIterator_offsetFallbackNullable(t *testing.T) {
+	var (
+		address Address
+		adapter = &testAdapter{}
+		query   = From("addresses").SortAsc("user_id")
+		cur1    = createCursor(1)
+		cur2    = createCursor(0)
+		options = []IteratorOption{BatchSize(1)}
+		it      = newIterator(context.TODO(), adapter, query, options)
+	)
+
+	// user_id is nullable, records with null user_id would be skipped by keyset filter.
+	query = query.SortAsc("id").Limit(1)
+	adapter.On("Query", query).Return(cur1, nil).Once()
+	adapter.On("Query", query.Offset(1)).Return(cur2, nil).Once()
+
+	for {
+		if err := it.Next(&address); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+	it.Close()
+
+	// the last next is not called because it's already refetched.
+	cur1.Next()
+
+	adapter.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+	cur2.AssertExpectations(t)
+}
+
+func TestIterator_scanError(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users")
+		cur     = &testCursor{}
+		it      = newIterator(context.TODO(), adapter, query, nil)
+	)
+
+	adapter.On("Query", query.SortAsc("id").Limit(1000)).Return(cur, nil).Once()
+	cur.On("Fields").Return([]string{"id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.On("Scan", mock.Anything).Return(errors.New("scan error")).Once()
+	cur.On("Close").Return(nil).Once()
+
+	assert.Equal(t, errors.New("scan error"), it.Next(&user))
+	assert.Nil(t, it.Close())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestIterator_stream(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		query   = From("users")
+		cur     = createCursor(13)
+		options = []IteratorOption{BatchSize(5), Start(10), Finish(20), Stream()}
+		it      = newIterator(context.TODO(), adapter, query, options)
+	)
+
+	adapter.On("Query", query.Where(Gte("id", 10).AndLte("id", 20)).SortAsc("id")).Return(cur, nil).Once()
+
+	recordsCount := 0
+	for {
+		if err := it.Next(&user); err == io.EOF {
+			break
+		} else {
+			assert.Nil(t, err)
+		}
+
+		assert.NotEqual(t, 0, user.ID)
+		recordsCount++
+	}
+	assert.Nil(t, it.Close())
+
+	assert.Equal(t, 13, recordsCount)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
 func TestIterator_setTableName(t *testing.T) {
 	var (
 		user    User
@@ -0,0 +1,346 @@
+package migrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/Fs02/rel"
+)
+
+var (
+	// ErrIntrospectNotSupported returned when adapter is not able to read the schema of the database.
+	ErrIntrospectNotSupported = errors.New("rel: adapter does not support schema introspection")
+	// ErrPendingMigration returned when comparing models to a database that has pending migrations.
+	ErrPendingMigration = errors.New("rel: pending migrations must be migrated before comparing models")
+
+	rtTime   = reflect.TypeOf(time.Time{})
+	rtValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// diffField describes how field of the model is mapped to a column.
+type diffField struct {
+	nullable bool
+	skipped  bool
+}
+
+// Diff compares tables defined by the models with the database schema.
+// It returns migration that creates missing tables, columns and indexes of foreign keys,
+// and changes columns with incompatible type or nullable field of required column, along with its rollback.
+// Columns that no longer exist in the models are only dropped when drop is true.
+// Field that can't be mapped to a column type is skipped, and its existing column is left as is.
+// Column change can't be applied using rel.Schema, thus Generate writes it as a placeholder to be altered manually.
+func (m *Migrator) Diff(ctx context.Context, drop bool, records ...interface{}) (rel.Schema, rel.Schema, error) {
+	var (
+		migrate  rel.Schema
+		rollback rel.Schema
+	)
+
+	introspector, ok := m.repo.Adapter(ctx).(rel.Introspector)
+	if !ok {
+		return migrate, rollback, ErrIntrospectNotSupported
+	}
+
+	m.sync(ctx)
+	for _, v := range m.versions {
+		if !v.applied {
+			return migrate, rollback, ErrPendingMigration
+		}
+	}
+
+	tables, err := introspector.Tables(ctx)
+	if err != nil {
+		return migrate, rollback, err
+	}
+
+	var (
+		existing = make(map[string]rel.Table, len(tables))
+		models   = make([]rel.Table, 0, len(records))
+		fields   = make(map[string]map[string]diffField, len(records))
+	)
+
+	for _, table := range tables {
+		existing[table.Name] = table
+	}
+
+	for _, record := range records {
+		table, tableFields := modelTable(record)
+		if _, ok := fields[table.Name]; !ok {
+			fields[table.Name] = tableFields
+			models = append(models, table)
+		}
+	}
+
+	for _, model := range sortTables(models) {
+		if table, ok := existing[model.Name]; ok {
+			diffTable(&migrate, &rollback, table, model, fields[model.Name], drop)
+			continue
+		}
+
+		migrate.Migrations = append(migrate.Migrations, model)
+		rollback.DropTable(model.Name)
+	}
+
+	// rollback is executed in the reverse order.
+	for i, j := 0, len(rollback.Migrations)-1; i < j; i, j = i+1, j-1 {
+		rollback.Migrations[i], rollback.Migrations[j] = rollback.Migrations[j], rollback.Migrations[i]
+	}
+
+	return migrate, rollback, nil
+}
+
+func diffTable(migrate *rel.Schema, rollback *rel.Schema, table rel.Table, model rel.Table, fields map[string]diffField, drop bool) {
+	var (
+		columns  = make(map[string]rel.Column, len(table.Definitions))
+		changes  []rel.TableDefinition
+		restores []rel.TableDefinition
+		indexes  []string
+	)
+
+	for _, def := range table.Definitions {
+		if column, ok := def.(rel.Column); ok {
+			columns[column.Name] = column
+		}
+	}
+
+	for _, def := range model.Definitions {
+		switch v := def.(type) {
+		case rel.Column:
+			column, ok := columns[v.Name]
+			if !ok {
+				changes = append(changes, v)
+				restores = append(restores, rel.Column{Op: rel.SchemaDrop, Name: v.Name})
+				continue
+			}
+
+			if changed, ok := diffColumn(column, v, fields[v.Name].nullable); ok {
+				column.Op = rel.SchemaAlter
+				changes = append(changes, changed)
+				restores = append(restores, column)
+			}
+		case rel.Key:
+			// foreign key can't be added to existing table in every database, index the column instead.
+			if _, ok := columns[v.Columns[0]]; v.Type == rel.ForeignKey && !ok {
+				indexes = append(indexes, v.Columns[0])
+			}
+		}
+	}
+
+	for _, def := range table.Definitions {
+		if column, ok := def.(rel.Column); ok && drop {
+			if _, ok := fields[column.Name]; !ok {
+				changes = append(changes, rel.Column{Op: rel.SchemaDrop, Name: column.Name})
+				restores = append(restores, column)
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	migrate.AlterTable(model.Name, func(t *rel.AlterTable) {
+		t.Definitions = changes
+	})
+
+	rollback.AlterTable(model.Name, func(t *rel.AlterTable) {
+		t.Definitions = restores
+	})
+
+	for _, column := range indexes {
+		name := model.Name + "_" + column + "_idx"
+		migrate.CreateIndex(model.Name, name, []string{column})
+		rollback.DropIndex(model.Name, name)
+	}
+}
+
+// compatibleTypes of column that can be read into a field mapped to the key.
+var compatibleTypes = map[rel.ColumnType][]rel.ColumnType{
+	rel.ID:       {rel.ID, rel.Int, rel.BigInt},
+	rel.Bool:     {rel.Bool},
+	rel.Int:      {rel.ID, rel.Int, rel.BigInt},
+	rel.BigInt:   {rel.ID, rel.Int, rel.BigInt},
+	rel.Float:    {rel.Float, rel.Decimal},
+	rel.String:   {rel.String, rel.Text, rel.Decimal, rel.Time},
+	rel.Binary:   {rel.Binary, rel.String, rel.Text},
+	rel.DateTime: {rel.DateTime, rel.Timestamp, rel.Date},
+}
+
+// diffColumn returns the changed column when the type is not compatible, or the field is nullable while the column is required.
+// Type that is not known by rel, such as json or enum, is never changed.
+func diffColumn(column rel.Column, field rel.Column, nullable bool) (rel.Column, bool) {
+	var (
+		changed = column
+		diff    = false
+	)
+
+	changed.Op = rel.SchemaAlter
+
+	if _, known := columnTypeNames[column.Type]; known && !containsType(compatibleTypes[field.Type], column.Type) {
+		changed.Type, changed.Unsigned = field.Type, field.Unsigned
+		changed.Limit, changed.Precision, changed.Scale = 0, 0, 0
+		diff = true
+	}
+
+	if nullable && column.Required {
+		changed.Required = false
+		diff = true
+	}
+
+	return changed, diff
+}
+
+func containsType(types []rel.ColumnType, typ rel.ColumnType) bool {
+	for i := range types {
+		if types[i] == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// modelTable returns table definition of the record, along with how its fields are mapped.
+// Field that can't be mapped to a column type is skipped.
+func modelTable(record interface{}) (rel.Table, map[string]diffField) {
+	var (
+		schema  rel.Schema
+		doc     = rel.NewDocument(record)
+		rt      = doc.ReflectValue().Type()
+		primary = doc.PrimaryFields()
+		fields  = make(map[string]diffField, len(doc.Fields()))
+	)
+
+	schema.CreateTable(doc.Table(), func(t *rel.Table) {
+		var (
+			id = false
+		)
+
+		for _, field := range doc.Fields() {
+			var (
+				sf                      = rt.FieldByIndex(doc.IndexPath()[field])
+				typ, unsigned, null, ok = columnType(sf.Type)
+			)
+
+			if !ok {
+				fields[field] = diffField{skipped: true}
+				continue
+			}
+
+			fields[field] = diffField{nullable: null}
+
+			// single integer primary key is an auto increment id.
+			if len(primary) == 1 && field == primary[0] && (typ == rel.Int || typ == rel.BigInt) {
+				id = true
+				t.ID(field)
+				continue
+			}
+
+			t.Column(field, typ, rel.Unsigned(unsigned))
+		}
+
+		switch {
+		case id:
+		case len(primary) == 1:
+			t.PrimaryKey(primary[0])
+		default:
+			t.PrimaryKeys(primary)
+		}
+
+		for _, name := range doc.BelongsTo() {
+			assoc := doc.Association(name)
+			if field, ok := fields[assoc.ReferenceField()]; assoc.PolymorphicField() != "" || !ok || field.skipped {
+				continue
+			}
+
+			target, _ := assoc.Document()
+			t.ForeignKey(assoc.ReferenceField(), target.Table(), assoc.ForeignField())
+		}
+	})
+
+	return schema.Migrations[0].(rel.Table), fields
+}
+
+// columnType maps go type to column type, the second return value is true for unsigned integer,
+// and the third return value is true for pointer and nullable type such as sql.NullString.
+// Type that implements driver.Valuer is mapped using its kind, or the value field of nullable struct.
+func columnType(rt reflect.Type) (rel.ColumnType, bool, bool, bool) {
+	if rt.Kind() == reflect.Ptr {
+		typ, unsigned, _, ok := columnType(rt.Elem())
+		return typ, unsigned, true, ok
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return rel.Bool, false, false, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return rel.Int, false, false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return rel.Int, true, false, true
+	case reflect.Int64:
+		return rel.BigInt, false, false, true
+	case reflect.Uint64:
+		return rel.BigInt, true, false, true
+	case reflect.Float32, reflect.Float64:
+		return rel.Float, false, false, true
+	case reflect.String:
+		return rel.String, false, false, true
+	case reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return rel.Binary, false, false, true
+		}
+	case reflect.Struct:
+		if rt.ConvertibleTo(rtTime) {
+			return rel.DateTime, false, false, true
+		}
+
+		// nullable struct such as sql.NullString, that contains the value and Valid field.
+		if valid, ok := rt.FieldByName("Valid"); ok && rt.NumField() == 2 && valid.Type.Kind() == reflect.Bool &&
+			(rt.Implements(rtValuer) || reflect.PtrTo(rt).Implements(rtValuer)) {
+			typ, unsigned, _, ok := columnType(rt.Field(1 - valid.Index[0]).Type)
+			return typ, unsigned, true, ok
+		}
+	}
+
+	return "", false, false, false
+}
+
+// sortTables so referenced tables are created before the referencing tables.
+func sortTables(tables []rel.Table) []rel.Table {
+	var (
+		sorted  = make([]rel.Table, 0, len(tables))
+		index   = make(map[string]int, len(tables))
+		visited = make(map[string]bool, len(tables))
+		visit   func(i int)
+	)
+
+	for i := range tables {
+		index[tables[i].Name] = i
+	}
+
+	visit = func(i int) {
+		if visited[tables[i].Name] {
+			return
+		}
+
+		visited[tables[i].Name] = true
+
+		for _, def := range tables[i].Definitions {
+			if key, ok := def.(rel.Key); ok && key.Type == rel.ForeignKey {
+				if j, ok := index[key.Reference.Table]; ok {
+					visit(j)
+				}
+			}
+		}
+
+		sorted = append(sorted, tables[i])
+	}
+
+	for i := range tables {
+		visit(i)
+	}
+
+	return sorted
+}
@@ -0,0 +1,374 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+type diffAuthor struct {
+	ID        int
+	Name      string
+	Email     *string
+	Age       uint8
+	Books     []diffBook `ref:"id" fk:"author_id"`
+	CreatedAt time.Time
+}
+
+func (diffAuthor) Table() string {
+	return "authors"
+}
+
+type diffBook struct {
+	ID       int64
+	Title    string
+	Price    float64
+	Tags     []string `db:"-"`
+	AuthorID int
+	Author   *diffAuthor `ref:"author_id" fk:"id"`
+}
+
+func (diffBook) Table() string {
+	return "books"
+}
+
+type diffRating struct {
+	BookID int      `db:",primary"`
+	UserID int      `db:",primary"`
+	Book   diffBook `ref:"book_id" fk:"id"`
+	Score  float32
+}
+
+func (diffRating) Table() string {
+	return "ratings"
+}
+
+type diffMeta struct {
+	Source string
+}
+
+func (diffMeta) Value() (driver.Value, error) {
+	return nil, nil
+}
+
+type diffTag struct {
+	ID     int
+	Names  []string
+	Cover  []byte
+	Note   sql.NullString
+	Count  sql.NullInt64
+	Meta   diffMeta
+	Extra  interface{}
+	Status *sql.NullBool
+}
+
+func (diffTag) Table() string {
+	return "tags"
+}
+
+func TestMigrator_Diff(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1,
+		func(schema *rel.Schema) {
+			schema.CreateTable("authors", func(t *rel.Table) {
+				t.ID("id")
+				t.String("name")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("authors")
+		},
+	)
+	m.Migrate(ctx)
+
+	migrate, rollback, err := m.Diff(ctx, false, &diffRating{}, &diffAuthor{}, &diffBook{}, &diffAuthor{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "authors",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaCreate, Name: "email", Type: rel.String},
+				rel.Column{Op: rel.SchemaCreate, Name: "age", Type: rel.Int, Unsigned: true},
+				rel.Column{Op: rel.SchemaCreate, Name: "created_at", Type: rel.DateTime},
+			},
+		},
+		rel.Table{
+			Op:   rel.SchemaCreate,
+			Name: "books",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaCreate, Name: "id", Type: rel.ID},
+				rel.Column{Op: rel.SchemaCreate, Name: "title", Type: rel.String},
+				rel.Column{Op: rel.SchemaCreate, Name: "price", Type: rel.Float},
+				rel.Column{Op: rel.SchemaCreate, Name: "author_id", Type: rel.Int},
+				rel.Key{Op: rel.SchemaCreate, Type: rel.ForeignKey, Columns: []string{"author_id"}, Reference: rel.ForeignKeyReference{Table: "authors", Columns: []string{"id"}}},
+			},
+		},
+		rel.Table{
+			Op:   rel.SchemaCreate,
+			Name: "ratings",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaCreate, Name: "book_id", Type: rel.Int},
+				rel.Column{Op: rel.SchemaCreate, Name: "user_id", Type: rel.Int},
+				rel.Column{Op: rel.SchemaCreate, Name: "score", Type: rel.Float},
+				rel.Key{Op: rel.SchemaCreate, Type: rel.PrimaryKey, Columns: []string{"book_id", "user_id"}},
+				rel.Key{Op: rel.SchemaCreate, Type: rel.ForeignKey, Columns: []string{"book_id"}, Reference: rel.ForeignKeyReference{Table: "books", Columns: []string{"id"}}},
+			},
+		},
+	}, migrate.Migrations)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{Op: rel.SchemaDrop, Name: "ratings"},
+		rel.Table{Op: rel.SchemaDrop, Name: "books"},
+		rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "authors",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaDrop, Name: "email"},
+				rel.Column{Op: rel.SchemaDrop, Name: "age"},
+				rel.Column{Op: rel.SchemaDrop, Name: "created_at"},
+			},
+		},
+	}, rollback.Migrations)
+
+	// applying the generated migration leaves nothing to diff.
+	m.Register(2,
+		func(schema *rel.Schema) { schema.Migrations = migrate.Migrations },
+		func(schema *rel.Schema) { schema.Migrations = rollback.Migrations },
+	)
+	m.Migrate(ctx)
+
+	migrate, rollback, err = m.Diff(ctx, false, &diffRating{}, &diffAuthor{}, &diffBook{})
+	assert.Nil(t, err)
+	assert.Nil(t, migrate.Migrations)
+	assert.Nil(t, rollback.Migrations)
+}
+
+func TestMigrator_Diff_foreignKeyIndex(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1,
+		func(schema *rel.Schema) {
+			schema.CreateTable("authors", func(t *rel.Table) {
+				t.ID("id")
+			})
+			schema.CreateTable("books", func(t *rel.Table) {
+				t.ID("id")
+				t.String("title")
+				t.Float("price")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("books")
+			schema.DropTable("authors")
+		},
+	)
+	m.Migrate(ctx)
+
+	migrate, rollback, err := m.Diff(ctx, false, &diffBook{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{
+			Op:          rel.SchemaAlter,
+			Name:        "books",
+			Definitions: []rel.TableDefinition{rel.Column{Op: rel.SchemaCreate, Name: "author_id", Type: rel.Int}},
+		},
+		rel.Index{Op: rel.SchemaCreate, Table: "books", Name: "books_author_id_idx", Columns: []string{"author_id"}},
+	}, migrate.Migrations)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Index{Op: rel.SchemaDrop, Table: "books", Name: "books_author_id_idx"},
+		rel.Table{
+			Op:          rel.SchemaAlter,
+			Name:        "books",
+			Definitions: []rel.TableDefinition{rel.Column{Op: rel.SchemaDrop, Name: "author_id"}},
+		},
+	}, rollback.Migrations)
+}
+
+func TestMigrator_Diff_changeAndDrop(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1,
+		func(schema *rel.Schema) {
+			schema.CreateTable("authors", func(t *rel.Table) {
+				t.ID("id")
+				t.Text("name")
+				t.String("email", rel.Required(true))
+				t.String("age")
+				t.DateTime("created_at")
+				t.String("nickname")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("authors")
+		},
+	)
+	m.Migrate(ctx)
+
+	migrate, rollback, err := m.Diff(ctx, true, &diffAuthor{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "authors",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaAlter, Name: "email", Type: rel.String, Limit: 255},
+				rel.Column{Op: rel.SchemaAlter, Name: "age", Type: rel.Int, Unsigned: true},
+				rel.Column{Op: rel.SchemaDrop, Name: "nickname"},
+			},
+		},
+	}, migrate.Migrations)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "authors",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaAlter, Name: "email", Type: rel.String, Limit: 255, Required: true},
+				rel.Column{Op: rel.SchemaAlter, Name: "age", Type: rel.String, Limit: 255},
+				rel.Column{Op: rel.SchemaCreate, Name: "nickname", Type: rel.String, Limit: 255},
+			},
+		},
+	}, rollback.Migrations)
+}
+
+func TestMigrator_Diff_dropDisabled(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1,
+		func(schema *rel.Schema) {
+			schema.CreateTable("authors", func(t *rel.Table) {
+				t.ID("id")
+				t.String("name")
+				t.String("email")
+				t.Int("age", rel.Unsigned(true))
+				t.DateTime("created_at")
+				t.String("nickname")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("authors")
+		},
+	)
+	m.Migrate(ctx)
+
+	migrate, rollback, err := m.Diff(ctx, false, &diffAuthor{})
+	assert.Nil(t, err)
+	assert.Nil(t, migrate.Migrations)
+	assert.Nil(t, rollback.Migrations)
+}
+
+func TestMigrator_Diff_unmappableField(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1,
+		func(schema *rel.Schema) {
+			schema.CreateTable("tags", func(t *rel.Table) {
+				t.ID("id")
+				t.Text("names")
+				t.String("legacy")
+			})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("tags")
+		},
+	)
+	m.Migrate(ctx)
+
+	// names is left as is, while legacy is dropped since it's not in the model.
+	migrate, rollback, err := m.Diff(ctx, true, &diffTag{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "tags",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaCreate, Name: "cover", Type: rel.Binary},
+				rel.Column{Op: rel.SchemaCreate, Name: "note", Type: rel.String},
+				rel.Column{Op: rel.SchemaCreate, Name: "count", Type: rel.BigInt},
+				rel.Column{Op: rel.SchemaCreate, Name: "status", Type: rel.Bool},
+				rel.Column{Op: rel.SchemaDrop, Name: "legacy"},
+			},
+		},
+	}, migrate.Migrations)
+
+	assert.Equal(t, []rel.Migration{
+		rel.Table{
+			Op:   rel.SchemaAlter,
+			Name: "tags",
+			Definitions: []rel.TableDefinition{
+				rel.Column{Op: rel.SchemaDrop, Name: "cover"},
+				rel.Column{Op: rel.SchemaDrop, Name: "note"},
+				rel.Column{Op: rel.SchemaDrop, Name: "count"},
+				rel.Column{Op: rel.SchemaDrop, Name: "status"},
+				rel.Column{Op: rel.SchemaCreate, Name: "legacy", Type: rel.String, Limit: 255},
+			},
+		},
+	}, rollback.Migrations)
+}
+
+func TestMigrator_Diff_pendingMigration(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1, func(schema *rel.Schema) {}, func(schema *rel.Schema) {})
+
+	_, _, err := m.Diff(ctx, false, &diffBook{})
+	assert.Equal(t, ErrPendingMigration, err)
+}
+
+func TestMigrator_Diff_notSupported(t *testing.T) {
+	var (
+		m = New(reltest.New())
+	)
+
+	_, _, err := m.Diff(context.TODO(), false, &diffBook{})
+	assert.Equal(t, ErrIntrospectNotSupported, err)
+}
@@ -0,0 +1,274 @@
+package migrator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Fs02/rel"
+)
+
+const (
+	dumpHeader   = "-- Code generated by rel schema dump. DO NOT EDIT.\n"
+	dumpVersions = "-- rel:versions "
+)
+
+var (
+	// ErrDumpNotSupported returned when adapter is not able to generate migration statement.
+	ErrDumpNotSupported = errors.New("rel: adapter does not support schema dump")
+	// ErrLoadNotEmpty returned when loading schema to a database that already contains migrated versions.
+	ErrLoadNotEmpty = errors.New("rel: schema can only be loaded to database without migrated versions")
+)
+
+type statementer interface {
+	MigrationStatement(migration rel.Migration) string
+}
+
+// Dump writes statements to create the schema of the migrated database, along with the applied versions.
+// The schema is read from the database, thus adapter must implement rel.Introspector.
+// Only tables and indexes are dumped, other objects such as views, triggers and the data are not included.
+func (m *Migrator) Dump(ctx context.Context, w io.Writer) {
+	var (
+		adapter = m.repo.Adapter(ctx)
+	)
+
+	stmt, ok := adapter.(statementer)
+	if !ok {
+		panic(ErrDumpNotSupported)
+	}
+
+	introspector, ok := adapter.(rel.Introspector)
+	if !ok {
+		panic(ErrIntrospectNotSupported)
+	}
+
+	m.sync(ctx)
+
+	tables, err := introspector.Tables(ctx)
+	check(err)
+
+	var (
+		applied []string
+		buf     = bufio.NewWriter(w)
+	)
+
+	for _, v := range m.versions {
+		if v.applied {
+			applied = append(applied, strconv.Itoa(v.Version))
+		}
+	}
+
+	buf.WriteString(dumpHeader)
+	buf.WriteString(dumpVersions + strings.Join(applied, ",") + "\n")
+
+	// versions table is created by the migrator when loading the schema.
+	for _, table := range sortTables(tables) {
+		if table.Name == versionTable {
+			continue
+		}
+
+		table.Op = rel.SchemaCreate
+		writeStatement(buf, stmt.MigrationStatement(table))
+
+		indexes, err := introspector.Indexes(ctx, table.Name)
+		check(err)
+
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+		for _, index := range indexes {
+			index.Op = rel.SchemaCreate
+			writeStatement(buf, stmt.MigrationStatement(index))
+		}
+	}
+
+	check(buf.Flush())
+}
+
+func writeStatement(buf *bufio.Writer, statement string) {
+	statement = strings.TrimSpace(statement)
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+
+	buf.WriteString("\n" + statement + "\n")
+}
+
+// Load schema written by Dump and mark the dumped versions as applied.
+// Schema and versions are loaded in a transaction, database that doesn't support transactional DDL may keep the loaded tables on failure.
+// Statements are separated by semicolon outside of quotes, comments and BEGIN ... END block,
+// so function and trigger can be added to the schema file.
+func (m *Migrator) Load(ctx context.Context, r io.Reader) {
+	data, err := ioutil.ReadAll(r)
+	check(err)
+
+	var (
+		versions versions
+	)
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, dumpVersions) {
+			continue
+		}
+
+		for _, s := range strings.Split(strings.TrimPrefix(line, dumpVersions), ",") {
+			if s == "" {
+				continue
+			}
+
+			v, err := strconv.Atoi(s)
+			check(err)
+			versions = append(versions, version{Version: v})
+		}
+	}
+
+	m.sync(ctx)
+	for _, v := range m.versions {
+		if v.applied {
+			panic(ErrLoadNotEmpty)
+		}
+	}
+
+	err = m.repo.Transaction(ctx, func(ctx context.Context) error {
+		adapter := m.repo.Adapter(ctx).(rel.Adapter)
+		for _, statement := range splitStatements(string(data)) {
+			check(adapter.Apply(ctx, rel.Raw(statement)))
+		}
+
+		if len(versions) > 0 {
+			m.repo.MustInsertAll(ctx, &versions)
+		}
+
+		return nil
+	})
+
+	check(err)
+}
+
+// splitStatements splits sql by semicolon that terminates the statement.
+// semicolon inside quotes, comments, dollar quoted string and BEGIN ... END block doesn't terminate the statement.
+// Statements that only contain comments are skipped.
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		start      int
+		depth      int
+		code       bool
+	)
+
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			code = true
+			i = skipQuoted(sql, i, string(c))
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			i = skipQuoted(sql, i, "\n")
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			i = skipQuoted(sql, i+1, "*/")
+		case c == '$':
+			code = true
+			if tag := dollarTag(sql[i:]); tag != "" {
+				i = skipQuoted(sql, i+len(tag)-1, tag)
+			}
+		case c == ';' && depth == 0:
+			if code {
+				statements = append(statements, strings.TrimSpace(sql[start:i+1]))
+			}
+
+			start, code = i+1, false
+		case isWordStart(sql, i):
+			code = true
+			var (
+				end  = wordEnd(sql, i)
+				next = nextWord(sql, end)
+			)
+
+			switch strings.ToUpper(sql[i:end]) {
+			case "BEGIN":
+				// BEGIN of transaction doesn't start a block.
+				if next != ";" && next != "TRANSACTION" && next != "WORK" && next != "DEFERRED" && next != "IMMEDIATE" && next != "EXCLUSIVE" {
+					depth++
+				}
+			case "CASE":
+				depth++
+			case "END":
+				// END IF, END LOOP, END WHILE and END REPEAT closes statement that isn't counted.
+				if depth > 0 && next != "IF" && next != "LOOP" && next != "WHILE" && next != "REPEAT" {
+					depth--
+				}
+			}
+
+			i = end - 1
+		case c > ' ':
+			code = true
+		}
+	}
+
+	if statement := strings.TrimSpace(sql[start:]); code {
+		statements = append(statements, statement)
+	}
+
+	return statements
+}
+
+// skipQuoted returns position of the last character of the closing delimiter, or the end of the sql.
+func skipQuoted(sql string, i int, end string) int {
+	if j := strings.Index(sql[i+1:], end); j >= 0 {
+		return i + j + len(end)
+	}
+
+	return len(sql) - 1
+}
+
+// dollarTag returns dollar quote tag such as $$ or $body$, the sql must starts with dollar sign.
+func dollarTag(sql string) string {
+	for i := 1; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '$':
+			return sql[:i+1]
+		case c != '_' && !isLetter(c) && (i == 1 || c < '0' || c > '9'):
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// nextWord returns the next word in upper case, or the next character if it's not a word.
+func nextWord(sql string, i int) string {
+	for i < len(sql) && (sql[i] == ' ' || sql[i] == '\t' || sql[i] == '\r' || sql[i] == '\n') {
+		i++
+	}
+
+	switch {
+	case i == len(sql):
+		return ""
+	case isLetter(sql[i]):
+		return strings.ToUpper(sql[i:wordEnd(sql, i)])
+	default:
+		return sql[i : i+1]
+	}
+}
+
+func isWordStart(sql string, i int) bool {
+	return isLetter(sql[i]) && (i == 0 || !isWordChar(sql[i-1]))
+}
+
+func wordEnd(sql string, i int) int {
+	for i < len(sql) && isWordChar(sql[i]) {
+		i++
+	}
+
+	return i
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isWordChar(c byte) bool {
+	return isLetter(c) || c == '_' || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,203 @@
+package migrator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/adapter/sql"
+	"github.com/Fs02/rel/adapter/sqlite3"
+	"github.com/Fs02/rel/reltest"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func openSqlite3(t *testing.T) (rel.Repository, *sqlite3.Adapter) {
+	adapter, err := sqlite3.Open(":memory:")
+	assert.Nil(t, err)
+
+	// every connection opens a new in memory database.
+	adapter.DB.SetMaxOpenConns(1)
+
+	repo := rel.New(adapter)
+	repo.Instrumentation(func(context.Context, string, string) func(error) { return func(error) {} })
+
+	return repo, adapter
+}
+
+func registerDumpMigrations(m *Migrator) {
+	m.Register(1,
+		func(schema *rel.Schema) {
+			schema.CreateTable("users", func(t *rel.Table) {
+				t.ID("id")
+				t.String("name")
+				t.String("title")
+			})
+			schema.CreateIndex("users", "users_name_idx", []string{"name"})
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("users")
+		},
+	)
+
+	m.Register(2,
+		func(schema *rel.Schema) {
+			schema.CreateTable("tags", func(t *rel.Table) {
+				t.ID("id")
+			})
+			schema.RenameColumn("users", "name", "username")
+			schema.AddColumn("users", "age", rel.Int, rel.Required(true), rel.Default(0))
+			schema.Exec("ALTER TABLE users ADD COLUMN bio TEXT;")
+			schema.Exec("CREATE INDEX users_bio_idx ON users (bio);")
+		},
+		func(schema *rel.Schema) {
+			schema.DropTable("tags")
+		},
+	)
+
+	m.Register(3,
+		func(schema *rel.Schema) {
+			schema.DropTable("tags")
+			schema.RenameTable("users", "people")
+		},
+		func(schema *rel.Schema) {
+			schema.RenameTable("people", "users")
+		},
+	)
+}
+
+func TestMigrator_DumpLoad(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+		buf        bytes.Buffer
+	)
+
+	defer conn.Close()
+
+	registerDumpMigrations(&m)
+	m.MigrateTo(ctx, 2)
+
+	assert.NotPanics(t, func() {
+		m.Dump(ctx, &buf)
+	})
+
+	assert.Equal(t, "-- Code generated by rel schema dump. DO NOT EDIT.\n"+
+		"-- rel:versions 1,2\n"+
+		"\n"+
+		"CREATE TABLE `tags` (`id` INTEGER PRIMARY KEY);\n"+
+		"\n"+
+		"CREATE TABLE `users` (`id` INTEGER PRIMARY KEY, `username` VARCHAR(255), `title` VARCHAR(255), `age` INTEGER NOT NULL DEFAULT 0, `bio` TEXT);\n"+
+		"\n"+
+		"CREATE INDEX `users_bio_idx` ON `users` (`bio`);\n"+
+		"\n"+
+		"CREATE INDEX `users_name_idx` ON `users` (`username`);\n", buf.String())
+
+	var (
+		loadRepo, loadConn = openSqlite3(t)
+		loaded             = New(loadRepo)
+	)
+
+	defer loadConn.Close()
+
+	registerDumpMigrations(&loaded)
+	assert.NotPanics(t, func() {
+		loaded.Load(ctx, strings.NewReader(buf.String()))
+	})
+
+	assert.Equal(t, 0, loadRepo.MustCount(ctx, "tags"))
+	assert.Equal(t, []VersionStatus{
+		{Version: 1, Applied: true, AppliedAt: loaded.versions[0].CreatedAt},
+		{Version: 2, Applied: true, AppliedAt: loaded.versions[1].CreatedAt},
+		{Version: 3},
+	}, loaded.Status(ctx))
+
+	// the remaining version can be migrated on top of loaded schema.
+	assert.NotPanics(t, func() {
+		loaded.Migrate(ctx)
+	})
+	assert.Equal(t, 0, loadRepo.MustCount(ctx, "people"))
+
+	assert.Panics(t, func() {
+		loaded.Load(ctx, strings.NewReader(buf.String()))
+	})
+}
+
+func TestMigrator_Load_rollback(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	registerDumpMigrations(&m)
+	assert.Panics(t, func() {
+		m.Load(ctx, strings.NewReader("-- rel:versions 1\n\nCREATE TABLE users (id INTEGER);\n\nCREATE TABLE;\n"))
+	})
+
+	tables, err := conn.Tables(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, versionTable, tables[0].Name)
+	assert.Equal(t, 0, repo.MustCount(ctx, versionTable))
+}
+
+func TestMigrator_Dump_notSupported(t *testing.T) {
+	var (
+		m = New(reltest.New())
+	)
+
+	assert.PanicsWithValue(t, ErrDumpNotSupported, func() {
+		m.Dump(context.TODO(), &bytes.Buffer{})
+	})
+}
+
+func TestMigrator_Dump_introspectNotSupported(t *testing.T) {
+	var (
+		m = New(rel.New(&sql.Adapter{}))
+	)
+
+	assert.PanicsWithValue(t, ErrIntrospectNotSupported, func() {
+		m.Dump(context.TODO(), &bytes.Buffer{})
+	})
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		statements []string
+	}{
+		{
+			name:       "statements",
+			sql:        "-- comment\nCREATE TABLE a (id INT);\n\nCREATE TABLE b (id INT);\n-- trailing comment\n",
+			statements: []string{"-- comment\nCREATE TABLE a (id INT);", "CREATE TABLE b (id INT);"},
+		},
+		{
+			name:       "quotes and comments",
+			sql:        "INSERT INTO a VALUES ('a;b', \"c;d\", `e;f`, 'it''s;'); /* x; */ SELECT 1 -- y;\n;",
+			statements: []string{"INSERT INTO a VALUES ('a;b', \"c;d\", `e;f`, 'it''s;');", "/* x; */ SELECT 1 -- y;\n;"},
+		},
+		{
+			name:       "dollar quoted function",
+			sql:        "CREATE FUNCTION f() RETURNS trigger AS $body$ BEGIN NEW.a := $1; RETURN NEW; END; $body$ LANGUAGE plpgsql;\nSELECT 1",
+			statements: []string{"CREATE FUNCTION f() RETURNS trigger AS $body$ BEGIN NEW.a := $1; RETURN NEW; END; $body$ LANGUAGE plpgsql;", "SELECT 1"},
+		},
+		{
+			name:       "trigger",
+			sql:        "CREATE TRIGGER t AFTER INSERT ON a BEGIN UPDATE b SET c = CASE WHEN 1 THEN 2 END; IF 1 THEN SELECT 1; END IF; END;\nBEGIN;\nCOMMIT;",
+			statements: []string{"CREATE TRIGGER t AFTER INSERT ON a BEGIN UPDATE b SET c = CASE WHEN 1 THEN 2 END; IF 1 THEN SELECT 1; END IF; END;", "BEGIN;", "COMMIT;"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.statements, splitStatements(test.sql))
+		})
+	}
+}
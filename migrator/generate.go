@@ -0,0 +1,286 @@
+package migrator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+
+	"github.com/Fs02/rel"
+)
+
+var columnTypeNames = map[rel.ColumnType]string{
+	rel.ID:        "ID",
+	rel.Bool:      "Bool",
+	rel.Int:       "Int",
+	rel.BigInt:    "BigInt",
+	rel.Float:     "Float",
+	rel.Decimal:   "Decimal",
+	rel.String:    "String",
+	rel.Text:      "Text",
+	rel.Binary:    "Binary",
+	rel.Date:      "Date",
+	rel.DateTime:  "DateTime",
+	rel.Time:      "Time",
+	rel.Timestamp: "Timestamp",
+}
+
+// Generate go source code of a migration file, the name is used as suffix of migrate and rollback function.
+// Migration using go codes can't be generated and will return an error.
+func Generate(name string, migrate rel.Schema, rollback rel.Schema) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+	)
+
+	buf.WriteString("package migrations\n\nimport \"github.com/Fs02/rel\"\n")
+
+	for _, fn := range []struct {
+		prefix string
+		schema rel.Schema
+	}{
+		{prefix: "Migrate", schema: migrate},
+		{prefix: "Rollback", schema: rollback},
+	} {
+		buf.WriteString("\n// " + fn.prefix + name + " definition\n")
+		buf.WriteString("func " + fn.prefix + name + "(schema *rel.Schema) {\n")
+
+		for _, migration := range fn.schema.Migrations {
+			if err := writeMigration(&buf, migration); err != nil {
+				return nil, err
+			}
+		}
+
+		buf.WriteString("}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func writeMigration(buf *bytes.Buffer, migration rel.Migration) error {
+	switch v := migration.(type) {
+	case rel.Table:
+		return writeTable(buf, v)
+	case rel.Index:
+		switch {
+		case v.Op == rel.SchemaDrop:
+			fmt.Fprintf(buf, "schema.DropIndex(%q, %q%s)\n", v.Table, v.Name, indexOptions(v))
+		case v.Unique:
+			fmt.Fprintf(buf, "schema.CreateUniqueIndex(%q, %q, %s%s)\n", v.Table, v.Name, stringSlice(v.Columns), indexOptions(v))
+		default:
+			fmt.Fprintf(buf, "schema.CreateIndex(%q, %q, %s%s)\n", v.Table, v.Name, stringSlice(v.Columns), indexOptions(v))
+		}
+	case rel.Raw:
+		fmt.Fprintf(buf, "schema.Exec(%q)\n", string(v))
+	default:
+		return fmt.Errorf("rel: unable to generate migration: %T", migration)
+	}
+
+	return nil
+}
+
+func writeTable(buf *bytes.Buffer, table rel.Table) error {
+	switch table.Op {
+	case rel.SchemaCreate:
+		fn := "CreateTable"
+		if table.Optional {
+			fn = "CreateTableIfNotExists"
+		}
+
+		fmt.Fprintf(buf, "schema.%s(%q, func(t *rel.Table) {\n", fn, table.Name)
+	case rel.SchemaAlter:
+		var definitions []rel.TableDefinition
+		for _, def := range table.Definitions {
+			// changing column is not supported by rel.Schema, it's written as placeholder to be altered manually.
+			if column, ok := def.(rel.Column); ok && column.Op == rel.SchemaAlter {
+				fmt.Fprintf(buf, "// TODO: change column %s.%s to %s%s\n", table.Name, column.Name, columnTypeSource(column.Type), columnOptions(column))
+				continue
+			}
+
+			definitions = append(definitions, def)
+		}
+
+		if table.Definitions = definitions; len(definitions) == 0 {
+			return nil
+		}
+
+		if len(table.Definitions) == 1 {
+			if column, ok := table.Definitions[0].(rel.Column); ok {
+				switch column.Op {
+				case rel.SchemaCreate:
+					fmt.Fprintf(buf, "schema.AddColumn(%q, %q, %s%s)\n", table.Name, column.Name, columnTypeSource(column.Type), columnOptions(column))
+					return nil
+				case rel.SchemaRename:
+					fmt.Fprintf(buf, "schema.RenameColumn(%q, %q, %q)\n", table.Name, column.Name, column.Rename)
+					return nil
+				case rel.SchemaDrop:
+					fmt.Fprintf(buf, "schema.DropColumn(%q, %q)\n", table.Name, column.Name)
+					return nil
+				}
+			}
+		}
+
+		fmt.Fprintf(buf, "schema.AlterTable(%q, func(t *rel.AlterTable) {\n", table.Name)
+	case rel.SchemaRename:
+		fmt.Fprintf(buf, "schema.RenameTable(%q, %q)\n", table.Name, table.Rename)
+		return nil
+	case rel.SchemaDrop:
+		fn := "DropTable"
+		if table.Optional {
+			fn = "DropTableIfExists"
+		}
+
+		fmt.Fprintf(buf, "schema.%s(%q)\n", fn, table.Name)
+		return nil
+	}
+
+	for _, def := range table.Definitions {
+		if err := writeTableDefinition(buf, def); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("})\n")
+	return nil
+}
+
+func writeTableDefinition(buf *bytes.Buffer, def rel.TableDefinition) error {
+	switch v := def.(type) {
+	case rel.Column:
+		switch v.Op {
+		case rel.SchemaRename:
+			fmt.Fprintf(buf, "t.RenameColumn(%q, %q)\n", v.Name, v.Rename)
+		case rel.SchemaDrop:
+			fmt.Fprintf(buf, "t.DropColumn(%q)\n", v.Name)
+		default:
+			if name, ok := columnTypeNames[v.Type]; ok {
+				fmt.Fprintf(buf, "t.%s(%q%s)\n", name, v.Name, columnOptions(v))
+			} else {
+				fmt.Fprintf(buf, "t.Column(%q, %s%s)\n", v.Name, columnTypeSource(v.Type), columnOptions(v))
+			}
+		}
+	case rel.Key:
+		switch {
+		case v.Type == rel.PrimaryKey && len(v.Columns) == 1:
+			fmt.Fprintf(buf, "t.PrimaryKey(%q%s)\n", v.Columns[0], keyOptions(v))
+		case v.Type == rel.PrimaryKey:
+			fmt.Fprintf(buf, "t.PrimaryKeys(%s%s)\n", stringSlice(v.Columns), keyOptions(v))
+		case v.Type == rel.UniqueKey:
+			fmt.Fprintf(buf, "t.Unique(%s%s)\n", stringSlice(v.Columns), keyOptions(v))
+		case v.Type == rel.ForeignKey && len(v.Columns) == 1 && len(v.Reference.Columns) == 1:
+			fmt.Fprintf(buf, "t.ForeignKey(%q, %q, %q%s)\n", v.Columns[0], v.Reference.Table, v.Reference.Columns[0], keyOptions(v))
+		default:
+			return errors.New("rel: unable to generate key: " + string(v.Type) + " (" + strings.Join(v.Columns, ", ") + ")")
+		}
+	case rel.Raw:
+		fmt.Fprintf(buf, "t.Fragment(%q)\n", string(v))
+	default:
+		return fmt.Errorf("rel: unable to generate table definition: %T", def)
+	}
+
+	return nil
+}
+
+func columnTypeSource(typ rel.ColumnType) string {
+	if name, ok := columnTypeNames[typ]; ok {
+		return "rel." + name
+	}
+
+	return "rel.ColumnType(" + strconv.Quote(string(typ)) + ")"
+}
+
+func columnOptions(column rel.Column) string {
+	var options []string
+
+	if column.Unique {
+		options = append(options, "rel.Unique(true)")
+	}
+
+	if column.Required {
+		options = append(options, "rel.Required(true)")
+	}
+
+	if column.Unsigned {
+		options = append(options, "rel.Unsigned(true)")
+	}
+
+	if column.Limit > 0 {
+		options = append(options, "rel.Limit("+strconv.Itoa(column.Limit)+")")
+	}
+
+	if column.Precision > 0 {
+		options = append(options, "rel.Precision("+strconv.Itoa(column.Precision)+")")
+	}
+
+	if column.Scale > 0 {
+		options = append(options, "rel.Scale("+strconv.Itoa(column.Scale)+")")
+	}
+
+	switch v := column.Default.(type) {
+	case nil:
+	case rel.Raw:
+		options = append(options, "rel.Default(rel.Raw("+strconv.Quote(string(v))+"))")
+	default:
+		options = append(options, fmt.Sprintf("rel.Default(%#v)", v))
+	}
+
+	if column.Options != "" {
+		options = append(options, "rel.Options("+strconv.Quote(column.Options)+")")
+	}
+
+	return joinOptions(options)
+}
+
+func keyOptions(key rel.Key) string {
+	var options []string
+
+	if key.Name != "" {
+		options = append(options, "rel.Name("+strconv.Quote(key.Name)+")")
+	}
+
+	if key.Reference.OnDelete != "" {
+		options = append(options, "rel.OnDelete("+strconv.Quote(key.Reference.OnDelete)+")")
+	}
+
+	if key.Reference.OnUpdate != "" {
+		options = append(options, "rel.OnUpdate("+strconv.Quote(key.Reference.OnUpdate)+")")
+	}
+
+	if key.Options != "" {
+		options = append(options, "rel.Options("+strconv.Quote(key.Options)+")")
+	}
+
+	return joinOptions(options)
+}
+
+func indexOptions(index rel.Index) string {
+	var options []string
+
+	if index.Optional {
+		options = append(options, "rel.Optional(true)")
+	}
+
+	if index.Options != "" {
+		options = append(options, "rel.Options("+strconv.Quote(index.Options)+")")
+	}
+
+	return joinOptions(options)
+}
+
+func joinOptions(options []string) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	return ", " + strings.Join(options, ", ")
+}
+
+func stringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i := range values {
+		quoted[i] = strconv.Quote(values[i])
+	}
+
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
@@ -0,0 +1,139 @@
+package migrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	var (
+		migrate  rel.Schema
+		rollback rel.Schema
+	)
+
+	migrate.CreateTable("books", func(t *rel.Table) {
+		t.ID("id")
+		t.String("title", rel.Limit(100), rel.Required(true))
+		t.Decimal("price", rel.Precision(10), rel.Scale(2), rel.Default(0.5))
+		t.Int("author_id", rel.Unsigned(true))
+		t.Column("data", "JSON", rel.Options("COMMENT 'data'"))
+		t.Bool("active", rel.Unique(true), rel.Default(true))
+		t.DateTime("created_at", rel.Default(rel.Raw("CURRENT_TIMESTAMP")))
+		t.ForeignKey("author_id", "authors", "id", rel.Name("books_author_fk"), rel.OnDelete("CASCADE"), rel.OnUpdate("CASCADE"))
+		t.Unique([]string{"title"})
+		t.Fragment("CHECK (price >= 0)")
+	})
+	migrate.CreateTableIfNotExists("tags", func(t *rel.Table) {
+		t.String("name")
+		t.PrimaryKey("name")
+	})
+	migrate.CreateTable("book_tags", func(t *rel.Table) {
+		t.Int("book_id")
+		t.String("tag_name")
+		t.PrimaryKeys([]string{"book_id", "tag_name"})
+	})
+	migrate.AddColumn("authors", "email", rel.String, rel.Default("-"))
+	migrate.AlterTable("authors", func(t *rel.AlterTable) {
+		t.Text("bio")
+		t.RenameColumn("name", "full_name")
+		t.DropColumn("age")
+	})
+	migrate.RenameColumn("authors", "email", "email_address")
+	migrate.RenameTable("authors", "writers")
+	migrate.CreateIndex("books", "books_title_idx", []string{"title"}, rel.Optional(true))
+	migrate.CreateUniqueIndex("books", "books_author_title_idx", []string{"author_id", "title"})
+	migrate.Exec("UPDATE books SET price = 0;")
+
+	rollback.DropIndex("books", "books_title_idx")
+	rollback.DropColumn("authors", "bio")
+	rollback.DropTableIfExists("tags")
+	rollback.DropTable("books")
+	rollback.Migrations = append(rollback.Migrations, rel.Table{
+		Op:   rel.SchemaAlter,
+		Name: "authors",
+		Definitions: []rel.TableDefinition{
+			rel.Column{Op: rel.SchemaAlter, Name: "age", Type: rel.Int, Required: true},
+			rel.Column{Op: rel.SchemaDrop, Name: "email"},
+		},
+	})
+
+	src, err := Generate("CreateBooks", migrate, rollback)
+	assert.Nil(t, err)
+	assert.Equal(t, `package migrations
+
+import "github.com/Fs02/rel"
+
+// MigrateCreateBooks definition
+func MigrateCreateBooks(schema *rel.Schema) {
+	schema.CreateTable("books", func(t *rel.Table) {
+		t.ID("id")
+		t.String("title", rel.Required(true), rel.Limit(100))
+		t.Decimal("price", rel.Precision(10), rel.Scale(2), rel.Default(0.5))
+		t.Int("author_id", rel.Unsigned(true))
+		t.Column("data", rel.ColumnType("JSON"), rel.Options("COMMENT 'data'"))
+		t.Bool("active", rel.Unique(true), rel.Default(true))
+		t.DateTime("created_at", rel.Default(rel.Raw("CURRENT_TIMESTAMP")))
+		t.ForeignKey("author_id", "authors", "id", rel.Name("books_author_fk"), rel.OnDelete("CASCADE"), rel.OnUpdate("CASCADE"))
+		t.Unique([]string{"title"})
+		t.Fragment("CHECK (price >= 0)")
+	})
+	schema.CreateTableIfNotExists("tags", func(t *rel.Table) {
+		t.String("name")
+		t.PrimaryKey("name")
+	})
+	schema.CreateTable("book_tags", func(t *rel.Table) {
+		t.Int("book_id")
+		t.String("tag_name")
+		t.PrimaryKeys([]string{"book_id", "tag_name"})
+	})
+	schema.AddColumn("authors", "email", rel.String, rel.Default("-"))
+	schema.AlterTable("authors", func(t *rel.AlterTable) {
+		t.Text("bio")
+		t.RenameColumn("name", "full_name")
+		t.DropColumn("age")
+	})
+	schema.RenameColumn("authors", "email", "email_address")
+	schema.RenameTable("authors", "writers")
+	schema.CreateIndex("books", "books_title_idx", []string{"title"}, rel.Optional(true))
+	schema.CreateUniqueIndex("books", "books_author_title_idx", []string{"author_id", "title"})
+	schema.Exec("UPDATE books SET price = 0;")
+}
+
+// RollbackCreateBooks definition
+func RollbackCreateBooks(schema *rel.Schema) {
+	schema.DropIndex("books", "books_title_idx")
+	schema.DropColumn("authors", "bio")
+	schema.DropTableIfExists("tags")
+	schema.DropTable("books")
+	// TODO: change column authors.age to rel.Int, rel.Required(true)
+	schema.DropColumn("authors", "email")
+}
+`, string(src))
+}
+
+func TestGenerate_error(t *testing.T) {
+	var (
+		migrate rel.Schema
+	)
+
+	migrate.Do(func(rel.Repository) error { return nil })
+
+	_, err := Generate("Do", migrate, rel.Schema{})
+	assert.Equal(t, errors.New("rel: unable to generate migration: rel.Do"), err)
+
+	migrate.Migrations = []rel.Migration{
+		rel.Table{
+			Op:   rel.SchemaCreate,
+			Name: "books",
+			Definitions: []rel.TableDefinition{
+				rel.Key{Type: rel.ForeignKey, Columns: []string{"author_id", "tenant_id"}, Reference: rel.ForeignKeyReference{Table: "authors", Columns: []string{"id", "tenant_id"}}},
+			},
+		},
+	}
+
+	_, err = Generate("CompositeKey", migrate, rel.Schema{})
+	assert.Equal(t, errors.New("rel: unable to generate key: FOREIGN KEY (author_id, tenant_id)"), err)
+}
@@ -77,23 +77,77 @@ func (m Migrator) buildVersionTableDefinition() rel.Table {
 }
 
 func (m *Migrator) sync(ctx context.Context) {
-	var (
-		versions versions
-		vi       int
-		adapter  = m.repo.Adapter(ctx).(rel.Adapter)
-	)
-
 	if !m.versionTableExists {
+		adapter := m.repo.Adapter(ctx).(rel.Adapter)
 		check(adapter.Apply(ctx, m.buildVersionTableDefinition()))
 		m.versionTableExists = true
 	}
 
-	m.repo.MustFindAll(ctx, &versions, rel.NewSortAsc("version"))
+	if missing := m.load(ctx, m.applied(ctx)); len(missing) > 0 {
+		panic(fmt.Sprint("rel: missing local migration: ", missing[0].Version))
+	}
+}
+
+// applied returns versions stored in version table.
+func (m *Migrator) applied(ctx context.Context) versions {
+	var (
+		applied versions
+	)
+
+	m.repo.MustFindAll(ctx, &applied, rel.NewSortAsc("version"))
+	return applied
+}
+
+// appliedIfExists returns versions stored in version table without creating the table.
+// Missing version table is treated as no version applied, adapter that can't be introspected
+// is queried directly, and failed query is treated as missing version table.
+func (m *Migrator) appliedIfExists(ctx context.Context) versions {
+	if m.versionTableExists {
+		return m.applied(ctx)
+	}
+
+	var (
+		applied versions
+	)
+
+	if introspector, ok := m.repo.Adapter(ctx).(rel.Introspector); ok {
+		tables, err := introspector.Tables(ctx)
+		check(err)
+
+		for _, table := range tables {
+			if table.Name == versionTable {
+				return m.applied(ctx)
+			}
+		}
+
+		return nil
+	}
+
+	if err := m.repo.FindAll(ctx, &applied, rel.NewSortAsc("version")); err != nil {
+		return nil
+	}
+
+	return applied
+}
+
+// load applied versions to the registered versions and returns the applied versions that aren't registered.
+func (m *Migrator) load(ctx context.Context, applied versions) versions {
+	var (
+		missing versions
+		vi      int
+	)
+
 	sort.Sort(m.versions)
 
 	for i := range m.versions {
-		if vi < len(versions) && m.versions[i].Version == versions[vi].Version {
-			m.versions[i].ID = versions[vi].ID
+		for vi < len(applied) && applied[vi].Version < m.versions[i].Version {
+			missing = append(missing, applied[vi])
+			vi++
+		}
+
+		if vi < len(applied) && m.versions[i].Version == applied[vi].Version {
+			m.versions[i].ID = applied[vi].ID
+			m.versions[i].CreatedAt = applied[vi].CreatedAt
 			m.versions[i].applied = true
 			vi++
 		} else {
@@ -101,9 +155,7 @@ func (m *Migrator) sync(ctx context.Context) {
 		}
 	}
 
-	if vi != len(versions) {
-		panic(fmt.Sprint("rel: missing local migration: ", versions[vi].Version))
-	}
+	return append(missing, applied[vi:]...)
 }
 
 // Migrate to the latest schema version.
@@ -115,43 +167,132 @@ func (m *Migrator) Migrate(ctx context.Context) {
 			continue
 		}
 
-		finish := m.instrumenter.Observe(ctx, "migrate", strconv.Itoa(v.Version)+" "+v.up.String())
+		m.migrate(ctx, v)
+	}
+}
 
-		err := m.repo.Transaction(ctx, func(ctx context.Context) error {
-			m.repo.MustInsert(ctx, &version{Version: v.Version})
-			m.run(ctx, v.up.Migrations)
-			return nil
-		})
+// MigrateTo migrates pending versions up to and including the given version.
+func (m *Migrator) MigrateTo(ctx context.Context, target int) {
+	m.sync(ctx)
 
-		finish(err)
-		check(err)
+	for _, v := range m.versions {
+		if v.Version > target {
+			break
+		}
+
+		if !v.applied {
+			m.migrate(ctx, v)
+		}
 	}
 }
 
 // Rollback migration 1 step.
 func (m *Migrator) Rollback(ctx context.Context) {
+	m.RollbackStep(ctx, 1)
+}
+
+// RollbackStep rollbacks the given number of applied versions, starting from the latest.
+func (m *Migrator) RollbackStep(ctx context.Context, step int) {
 	m.sync(ctx)
 
-	for i := range m.versions {
-		v := m.versions[len(m.versions)-i-1]
-		if !v.applied {
-			continue
+	for i := len(m.versions) - 1; i >= 0 && step > 0; i-- {
+		if v := m.versions[i]; v.applied {
+			m.rollback(ctx, v)
+			step--
 		}
+	}
+}
 
-		finish := m.instrumenter.Observe(ctx, "rollback", strconv.Itoa(v.Version)+" "+v.down.String())
+// RollbackTo rollbacks every applied versions newer than the given version.
+func (m *Migrator) RollbackTo(ctx context.Context, target int) {
+	m.sync(ctx)
 
-		err := m.repo.Transaction(ctx, func(ctx context.Context) error {
-			m.repo.MustDelete(ctx, &v)
-			m.run(ctx, v.down.Migrations)
-			return nil
-		})
+	for i := len(m.versions) - 1; i >= 0 && m.versions[i].Version > target; i-- {
+		if v := m.versions[i]; v.applied {
+			m.rollback(ctx, v)
+		}
+	}
+}
 
-		finish(err)
-		check(err)
+// Redo rollbacks the latest applied version and migrates it again.
+func (m *Migrator) Redo(ctx context.Context) {
+	m.sync(ctx)
+
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		if v := m.versions[i]; v.applied {
+			m.rollback(ctx, v)
+			m.migrate(ctx, v)
+			return
+		}
+	}
+}
+
+// Reset rollbacks all applied versions.
+func (m *Migrator) Reset(ctx context.Context) {
+	m.RollbackTo(ctx, 0)
+}
 
-		// only rollback one version.
-		return
+// VersionStatus describes whether a version is applied.
+// Missing is true when the version is applied, but it's not registered.
+type VersionStatus struct {
+	Version   int
+	Applied   bool
+	Missing   bool
+	AppliedAt time.Time
+}
+
+// Status returns status of every registered and applied versions, sorted by version.
+// Version table is not created, thus every version is reported as not applied when the table doesn't exist.
+func (m *Migrator) Status(ctx context.Context) []VersionStatus {
+	missing := m.load(ctx, m.appliedIfExists(ctx))
+
+	statuses := make([]VersionStatus, 0, len(m.versions)+len(missing))
+	for _, v := range m.versions {
+		statuses = append(statuses, VersionStatus{
+			Version:   v.Version,
+			Applied:   v.applied,
+			AppliedAt: v.CreatedAt,
+		})
 	}
+
+	for _, v := range missing {
+		statuses = append(statuses, VersionStatus{
+			Version:   v.Version,
+			Applied:   true,
+			Missing:   true,
+			AppliedAt: v.CreatedAt,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses
+}
+
+func (m *Migrator) migrate(ctx context.Context, v version) {
+	finish := m.instrumenter.Observe(ctx, "migrate", strconv.Itoa(v.Version)+" "+v.up.String())
+
+	err := m.repo.Transaction(ctx, func(ctx context.Context) error {
+		m.repo.MustInsert(ctx, &version{Version: v.Version})
+		m.run(ctx, v.up.Migrations)
+		return nil
+	})
+
+	finish(err)
+	check(err)
+}
+
+func (m *Migrator) rollback(ctx context.Context, v version) {
+	finish := m.instrumenter.Observe(ctx, "rollback", strconv.Itoa(v.Version)+" "+v.down.String())
+
+	err := m.repo.Transaction(ctx, func(ctx context.Context) error {
+		m.repo.MustDelete(ctx, &v)
+		m.run(ctx, v.down.Migrations)
+		return nil
+	})
+
+	finish(err)
+	check(err)
 }
 
 func (m *Migrator) run(ctx context.Context, migrations []rel.Migration) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Fs02/rel"
 	"github.com/Fs02/rel/reltest"
@@ -206,6 +207,171 @@ func TestMigrator_Sync(t *testing.T) {
 	}
 }
 
+func TestMigrator_Steps(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		nfn     = func(schema *rel.Schema) {}
+		now     = time.Now()
+		applied = versions{
+			{ID: 1, Version: 1, CreatedAt: now},
+			{ID: 2, Version: 2, CreatedAt: now},
+		}
+		newMigrator = func(repo *reltest.Repository) Migrator {
+			migrator := New(repo)
+			migrator.Register(1, nfn, nfn)
+			migrator.Register(2, nfn, nfn)
+			migrator.Register(3, nfn, nfn)
+			migrator.Register(4, nfn, nfn)
+
+			repo.ExpectFindAll(rel.NewSortAsc("version")).Result(applied)
+			return migrator
+		}
+	)
+
+	t.Run("MigrateTo", func(t *testing.T) {
+		var (
+			repo     = reltest.New()
+			migrator = newMigrator(repo)
+		)
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectInsert().For(&version{Version: 3})
+		})
+
+		migrator.MigrateTo(ctx, 3)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RollbackStep", func(t *testing.T) {
+		var (
+			repo     = reltest.New()
+			migrator = newMigrator(repo)
+		)
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 2, Version: 2, CreatedAt: now, applied: true})
+		})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 1, Version: 1, CreatedAt: now, applied: true})
+		})
+
+		migrator.RollbackStep(ctx, 5)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RollbackTo", func(t *testing.T) {
+		var (
+			repo     = reltest.New()
+			migrator = newMigrator(repo)
+		)
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 2, Version: 2, CreatedAt: now, applied: true})
+		})
+
+		migrator.RollbackTo(ctx, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Redo", func(t *testing.T) {
+		var (
+			repo     = reltest.New()
+			migrator = newMigrator(repo)
+		)
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 2, Version: 2, CreatedAt: now, applied: true})
+		})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectInsert().For(&version{Version: 2})
+		})
+
+		migrator.Redo(ctx)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		var (
+			repo     = reltest.New()
+			migrator = newMigrator(repo)
+		)
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 2, Version: 2, CreatedAt: now, applied: true})
+		})
+
+		repo.ExpectTransaction(func(repo *reltest.Repository) {
+			repo.ExpectDelete().For(&version{ID: 1, Version: 1, CreatedAt: now, applied: true})
+		})
+
+		migrator.Reset(ctx)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		var (
+			repo     = reltest.New()
+			migrator = newMigrator(repo)
+		)
+
+		assert.Equal(t, []VersionStatus{
+			{Version: 1, Applied: true, AppliedAt: now},
+			{Version: 2, Applied: true, AppliedAt: now},
+			{Version: 3},
+			{Version: 4},
+		}, migrator.Status(ctx))
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestMigrator_Status_missing(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		nfn      = func(schema *rel.Schema) {}
+		now      = time.Now()
+		repo     = reltest.New()
+		migrator = New(repo)
+	)
+
+	migrator.Register(2, nfn, nfn)
+	migrator.Register(4, nfn, nfn)
+
+	repo.ExpectFindAll(rel.NewSortAsc("version")).Result(versions{
+		{ID: 1, Version: 1, CreatedAt: now},
+		{ID: 2, Version: 2, CreatedAt: now},
+		{ID: 3, Version: 3, CreatedAt: now},
+	})
+
+	assert.Equal(t, []VersionStatus{
+		{Version: 1, Applied: true, Missing: true, AppliedAt: now},
+		{Version: 2, Applied: true, AppliedAt: now},
+		{Version: 3, Applied: true, Missing: true, AppliedAt: now},
+		{Version: 4},
+	}, migrator.Status(ctx))
+	repo.AssertExpectations(t)
+}
+
+func TestMigrator_Status_versionTableNotExists(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		nfn        = func(schema *rel.Schema) {}
+		repo, conn = openSqlite3(t)
+		m          = New(repo)
+	)
+
+	defer conn.Close()
+
+	m.Register(1, nfn, nfn)
+
+	assert.Equal(t, []VersionStatus{{Version: 1}}, m.Status(ctx))
+
+	tables, err := conn.Tables(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, tables, 0)
+}
+
 func TestMigrator_Instrumentation(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -0,0 +1,267 @@
+package migrator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Fs02/rel"
+	"github.com/jinzhu/inflection"
+	"github.com/serenize/snaker"
+)
+
+type modelField struct {
+	name    string
+	typ     string
+	tag     string
+	comment string
+}
+
+type model struct {
+	name   string
+	table  string
+	fields []modelField
+	used   map[string]bool
+}
+
+func (m *model) add(field modelField) bool {
+	if m.used[field.name] {
+		return false
+	}
+
+	m.used[field.name] = true
+	m.fields = append(m.fields, field)
+	return true
+}
+
+// GenerateModels generates go source code of structs that maps the tables.
+// Association is inferred from foreign key, and migration versions table is skipped.
+func GenerateModels(pkg string, tables []rel.Table) ([]byte, error) {
+	var (
+		buf     bytes.Buffer
+		models  = make([]*model, 0, len(tables))
+		index   = make(map[string]*model, len(tables))
+		useTime bool
+	)
+
+	tables = append([]rel.Table(nil), tables...)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+	for _, table := range tables {
+		if table.Name == versionTable {
+			continue
+		}
+
+		m := &model{name: modelName(table.Name), table: table.Name, used: make(map[string]bool)}
+		models = append(models, m)
+		index[table.Name] = m
+	}
+
+	for _, table := range tables {
+		m, ok := index[table.Name]
+		if !ok {
+			continue
+		}
+
+		var (
+			primary = primaryColumns(table)
+			marker  = len(primary) > 1 || (len(primary) == 1 && primary[0] != "id")
+		)
+
+		for _, def := range table.Definitions {
+			column, ok := def.(rel.Column)
+			if !ok {
+				continue
+			}
+
+			var (
+				tag       = column.Name
+				isPrimary = contains(primary, column.Name)
+				typ       = goType(column, isPrimary)
+				comment   string
+			)
+
+			if isPrimary && marker {
+				tag += ",primary"
+			}
+
+			if typ == "interface{}" {
+				comment = string(column.Type)
+			}
+
+			useTime = useTime || strings.HasSuffix(typ, "time.Time")
+			m.add(modelField{name: snaker.SnakeToCamel(column.Name), typ: typ, tag: `db:"` + tag + `"`, comment: comment})
+		}
+	}
+
+	// associations are added after every columns, so it won't shadow the column.
+	for _, table := range tables {
+		m, ok := index[table.Name]
+		if !ok {
+			continue
+		}
+
+		for _, def := range table.Definitions {
+			key, ok := def.(rel.Key)
+			if !ok || key.Type != rel.ForeignKey || len(key.Columns) != 1 || len(key.Reference.Columns) != 1 {
+				continue
+			}
+
+			target, ok := index[key.Reference.Table]
+			if !ok {
+				continue
+			}
+
+			var (
+				column    = key.Columns[0]
+				refColumn = key.Reference.Columns[0]
+				prefix    = snaker.SnakeToCamel(strings.TrimSuffix(column, "_id"))
+			)
+
+			// belongs to, named after the foreign key column.
+			belongsTo := modelField{name: target.name, typ: "*" + target.name, tag: `ref:"` + column + `" fk:"` + refColumn + `"`}
+			if strings.HasSuffix(column, "_id") {
+				belongsTo.name = prefix
+			}
+
+			if !m.add(belongsTo) {
+				belongsTo.name = prefix + target.name
+				m.add(belongsTo)
+			}
+
+			// has one when the foreign key is unique, otherwise has many.
+			field := modelField{name: inflection.Plural(m.name), typ: "[]" + m.name, tag: `ref:"` + refColumn + `" fk:"` + column + `"`}
+			if uniqueColumn(table, column) {
+				field.name, field.typ = m.name, "*"+m.name
+			}
+
+			if !target.add(field) {
+				field.name = prefix + field.name
+				target.add(field)
+			}
+		}
+	}
+
+	buf.WriteString("package " + pkg + "\n")
+	if useTime {
+		buf.WriteString("\nimport \"time\"\n")
+	}
+
+	for _, m := range models {
+		fmt.Fprintf(&buf, "\n// %s model.\ntype %s struct {\n", m.name, m.name)
+		for _, field := range m.fields {
+			fmt.Fprintf(&buf, "%s %s `%s`", field.name, field.typ, field.tag)
+			if field.comment != "" {
+				buf.WriteString(" // " + field.comment)
+			}
+
+			buf.WriteString("\n")
+		}
+
+		buf.WriteString("}\n")
+
+		// table name is inferred by pluralizing the struct name.
+		if snaker.CamelToSnake(inflection.Plural(m.name)) != m.table {
+			r := strings.ToLower(m.name[:1])
+			fmt.Fprintf(&buf, "\n// Table name of %s.\nfunc (%s %s) Table() string {\n\treturn %s\n}\n", m.name, r, m.name, strconv.Quote(m.table))
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func modelName(table string) string {
+	return snaker.SnakeToCamel(inflection.Singular(table))
+}
+
+func primaryColumns(table rel.Table) []string {
+	var id []string
+	for _, def := range table.Definitions {
+		switch v := def.(type) {
+		case rel.Key:
+			if v.Type == rel.PrimaryKey {
+				return v.Columns
+			}
+		case rel.Column:
+			if v.Type == rel.ID {
+				id = []string{v.Name}
+			}
+		}
+	}
+
+	return id
+}
+
+func uniqueColumn(table rel.Table, column string) bool {
+	for _, def := range table.Definitions {
+		switch v := def.(type) {
+		case rel.Key:
+			if v.Type == rel.UniqueKey && len(v.Columns) == 1 && v.Columns[0] == column {
+				return true
+			}
+		case rel.Column:
+			if v.Unique && v.Name == column {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// goType of the column, nullable column without default value is mapped to pointer.
+// Decimal is mapped to string to keep its precision, and type that is not known is mapped to interface{}.
+func goType(column rel.Column, primary bool) string {
+	var typ string
+
+	switch column.Type {
+	case rel.ID:
+		return "int"
+	case rel.Bool:
+		typ = "bool"
+	case rel.Int:
+		typ = "int"
+	case rel.BigInt:
+		typ = "int64"
+	case rel.Float:
+		typ = "float64"
+	case rel.Decimal, rel.String, rel.Text, rel.Time:
+		typ = "string"
+	case rel.Binary:
+		return "[]byte"
+	case rel.Date, rel.DateTime, rel.Timestamp:
+		typ = "time.Time"
+	default:
+		switch base := strings.ToLower(strings.SplitN(string(column.Type), "(", 2)[0]); base {
+		case "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "bytea", "json", "jsonb":
+			return "[]byte"
+		case "uuid", "enum", "set", "char", "nchar", "nvarchar", "clob", "citext":
+			typ = "string"
+		default:
+			return "interface{}"
+		}
+	}
+
+	if column.Unsigned && strings.HasPrefix(typ, "int") {
+		typ = "u" + typ
+	}
+
+	if !primary && !column.Required && column.Default == nil {
+		typ = "*" + typ
+	}
+
+	return typ
+}
+
+func contains(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,140 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateModels(t *testing.T) {
+	var (
+		schema rel.Schema
+	)
+
+	schema.CreateTable("users", func(t *rel.Table) {
+		t.ID("id")
+		t.String("name", rel.Default(""))
+		t.Int("age", rel.Required(true), rel.Default(0))
+		t.String("note")
+		t.DateTime("created_at")
+		t.Unique([]string{"name"})
+	})
+	schema.CreateTable("addresses", func(t *rel.Table) {
+		t.ID("id")
+		t.Int("user_id", rel.Unsigned(true))
+		t.Int("previous_user_id", rel.Unsigned(true))
+		t.ForeignKey("user_id", "users", "id")
+		t.ForeignKey("previous_user_id", "users", "id")
+	})
+	schema.CreateTable("profiles", func(t *rel.Table) {
+		t.ID("id")
+		t.Int("user_id", rel.Required(true))
+		t.Bool("active", rel.Default(false))
+		t.String("owner")
+		t.Int("owner_id")
+		t.ForeignKey("user_id", "users", "id")
+		t.ForeignKey("owner_id", "users", "id")
+		t.Unique([]string{"user_id"})
+	})
+	schema.CreateTable("categories", func(t *rel.Table) {
+		t.ID("id")
+		t.Int("parent_id")
+		t.Decimal("rate", rel.Required(true))
+		t.ForeignKey("parent_id", "categories", "id")
+	})
+	schema.CreateTable("user_roles", func(t *rel.Table) {
+		t.Int("user_id", rel.Required(true))
+		t.String("role", rel.Required(true))
+		t.PrimaryKeys([]string{"user_id", "role"})
+		t.ForeignKey("user_id", "users", "id")
+	})
+	schema.CreateTable("tbl_tag", func(t *rel.Table) {
+		t.String("name")
+		t.BigInt("count", rel.Required(true), rel.Unsigned(true))
+		t.Column("data", "JSONB", rel.Required(true))
+		t.Column("cover", "BLOB")
+		t.Column("token", "UUID", rel.Required(true))
+		t.Column("location", "POINT")
+		t.PrimaryKey("name")
+	})
+	schema.CreateTable(versionTable, func(t *rel.Table) {
+		t.ID("id")
+	})
+
+	tables := make([]rel.Table, len(schema.Migrations))
+	for i := range schema.Migrations {
+		tables[i] = schema.Migrations[i].(rel.Table)
+	}
+
+	src, err := GenerateModels("models", tables)
+	assert.Nil(t, err)
+	assert.Equal(t, "package models\n"+`
+import "time"
+
+// Address model.
+type Address struct {
+	ID             int   `+"`"+`db:"id"`+"`"+`
+	UserID         *uint `+"`"+`db:"user_id"`+"`"+`
+	PreviousUserID *uint `+"`"+`db:"previous_user_id"`+"`"+`
+	User           *User `+"`"+`ref:"user_id" fk:"id"`+"`"+`
+	PreviousUser   *User `+"`"+`ref:"previous_user_id" fk:"id"`+"`"+`
+}
+
+// Category model.
+type Category struct {
+	ID         int        `+"`"+`db:"id"`+"`"+`
+	ParentID   *int       `+"`"+`db:"parent_id"`+"`"+`
+	Rate       string     `+"`"+`db:"rate"`+"`"+`
+	Parent     *Category  `+"`"+`ref:"parent_id" fk:"id"`+"`"+`
+	Categories []Category `+"`"+`ref:"id" fk:"parent_id"`+"`"+`
+}
+
+// Profile model.
+type Profile struct {
+	ID        int     `+"`"+`db:"id"`+"`"+`
+	UserID    int     `+"`"+`db:"user_id"`+"`"+`
+	Active    bool    `+"`"+`db:"active"`+"`"+`
+	Owner     *string `+"`"+`db:"owner"`+"`"+`
+	OwnerID   *int    `+"`"+`db:"owner_id"`+"`"+`
+	User      *User   `+"`"+`ref:"user_id" fk:"id"`+"`"+`
+	OwnerUser *User   `+"`"+`ref:"owner_id" fk:"id"`+"`"+`
+}
+
+// TblTag model.
+type TblTag struct {
+	Name     string      `+"`"+`db:"name,primary"`+"`"+`
+	Count    uint64      `+"`"+`db:"count"`+"`"+`
+	Data     []byte      `+"`"+`db:"data"`+"`"+`
+	Cover    []byte      `+"`"+`db:"cover"`+"`"+`
+	Token    string      `+"`"+`db:"token"`+"`"+`
+	Location interface{} `+"`"+`db:"location"`+"`"+` // POINT
+}
+
+// Table name of TblTag.
+func (t TblTag) Table() string {
+	return "tbl_tag"
+}
+
+// UserRole model.
+type UserRole struct {
+	UserID int    `+"`"+`db:"user_id,primary"`+"`"+`
+	Role   string `+"`"+`db:"role,primary"`+"`"+`
+	User   *User  `+"`"+`ref:"user_id" fk:"id"`+"`"+`
+}
+
+// User model.
+type User struct {
+	ID                    int        `+"`"+`db:"id"`+"`"+`
+	Name                  string     `+"`"+`db:"name"`+"`"+`
+	Age                   int        `+"`"+`db:"age"`+"`"+`
+	Note                  *string    `+"`"+`db:"note"`+"`"+`
+	CreatedAt             *time.Time `+"`"+`db:"created_at"`+"`"+`
+	Addresses             []Address  `+"`"+`ref:"id" fk:"user_id"`+"`"+`
+	PreviousUserAddresses []Address  `+"`"+`ref:"id" fk:"previous_user_id"`+"`"+`
+	Profile               *Profile   `+"`"+`ref:"id" fk:"user_id"`+"`"+`
+	Profiles              []Profile  `+"`"+`ref:"id" fk:"owner_id"`+"`"+`
+	UserRoles             []UserRole `+"`"+`ref:"id" fk:"user_id"`+"`"+`
+}
+`, string(src))
+}
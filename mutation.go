@@ -23,7 +23,7 @@ func Apply(doc *Document, mutators ...Mutator) Mutation {
 
 	for i := range mutators {
 		switch mut := mutators[i].(type) {
-		case Unscoped, Reload, Cascade:
+		case Unscoped, Reload, Cascade, OnConflict:
 			optionsCount++
 			mut.Apply(doc, &mutation)
 		default:
@@ -48,12 +48,13 @@ type AssocMutation struct {
 // Mutation represents value to be inserted or updated to database.
 // It's not safe to be used multiple time. some operation my alter mutation data.
 type Mutation struct {
-	Mutates   map[string]Mutate
-	Assoc     map[string]AssocMutation
-	Unscoped  Unscoped
-	Reload    Reload
-	Cascade   Cascade
-	ErrorFunc ErrorFunc
+	Mutates    map[string]Mutate
+	Assoc      map[string]AssocMutation
+	Unscoped   Unscoped
+	Reload     Reload
+	Cascade    Cascade
+	OnConflict OnConflict
+	ErrorFunc  ErrorFunc
 }
 
 func (m *Mutation) initMutates() {
@@ -232,6 +233,75 @@ func (c Cascade) Apply(doc *Document, mutation *Mutation) {
 	mutation.Cascade = c
 }
 
+func (c Cascade) applyDelete(option *deleteOption) {
+	option.cascade = c
+}
+
+// DeleteOption configures delete behaviour, it's implemented by Cascade and Unscoped.
+type DeleteOption interface {
+	applyDelete(option *deleteOption)
+}
+
+type deleteOption struct {
+	cascade  Cascade
+	unscoped Unscoped
+}
+
+// OnConflict mutator defines how insert should behave when it conflicts with existing record.
+// Keys are the conflict target, when it's empty and the conflict will be replaced, primary fields will be used instead.
+// Fields limits the replaced fields to the given fields, otherwise all inserted fields will be replaced.
+type OnConflict struct {
+	Keys    []string
+	Ignore  bool
+	Replace bool
+	Fields  []string
+}
+
+// Apply mutation.
+func (ocf OnConflict) Apply(doc *Document, mutation *Mutation) {
+	mutation.OnConflict = ocf
+}
+
+// OnConflictIgnore insertion when conflict happens.
+func OnConflictIgnore() OnConflict {
+	return OnConflict{Ignore: true}
+}
+
+// OnConflictKeyIgnore insertion when conflict happens on specific key.
+func OnConflictKeyIgnore(key string) OnConflict {
+	return OnConflictKeysIgnore([]string{key})
+}
+
+// OnConflictKeysIgnore insertion when conflict happens on specific keys.
+func OnConflictKeysIgnore(keys []string) OnConflict {
+	return OnConflict{Keys: keys, Ignore: true}
+}
+
+// OnConflictReplace all inserted fields when conflict happens on primary key.
+func OnConflictReplace() OnConflict {
+	return OnConflict{Replace: true}
+}
+
+// OnConflictKeyReplace all inserted fields when conflict happens on specific key.
+func OnConflictKeyReplace(key string) OnConflict {
+	return OnConflictKeysReplace([]string{key})
+}
+
+// OnConflictKeysReplace all inserted fields when conflict happens on specific keys.
+func OnConflictKeysReplace(keys []string) OnConflict {
+	return OnConflict{Keys: keys, Replace: true}
+}
+
+// OnConflictKeyUpdate only the given fields when conflict happens on specific key.
+func OnConflictKeyUpdate(key string, fields ...string) OnConflict {
+	return OnConflictKeysUpdate([]string{key}, fields...)
+}
+
+// OnConflictKeysUpdate only the given fields when conflict happens on specific keys.
+func OnConflictKeysUpdate(keys []string, fields ...string) OnConflict {
+	return OnConflict{Keys: keys, Replace: true, Fields: fields}
+}
+
 // ErrorFunc allows conversion REL's error to Application custom errors.
 type ErrorFunc func(error) error
 
@@ -126,3 +126,38 @@ func TestApplyMutation_Cascade(t *testing.T) {
 	assert.Equal(t, mutation, Apply(doc, mutators...))
 	assert.Equal(t, "string", record.Field1)
 }
+
+func TestApplyMutation_OnConflict(t *testing.T) {
+	var (
+		record   = TestRecord{}
+		doc      = NewDocument(&record)
+		mutators = []Mutator{
+			Set("field1", "string"),
+			OnConflictKeysUpdate([]string{"field1"}, "field2"),
+		}
+		mutation = Mutation{
+			Mutates: map[string]Mutate{
+				"field1": Set("field1", "string"),
+			},
+			Cascade: true,
+			OnConflict: OnConflict{
+				Keys:    []string{"field1"},
+				Replace: true,
+				Fields:  []string{"field2"},
+			},
+		}
+	)
+
+	assert.Equal(t, mutation, Apply(doc, mutators...))
+	assert.Equal(t, "string", record.Field1)
+}
+
+func TestOnConflict(t *testing.T) {
+	assert.Equal(t, OnConflict{Ignore: true}, OnConflictIgnore())
+	assert.Equal(t, OnConflict{Keys: []string{"id"}, Ignore: true}, OnConflictKeyIgnore("id"))
+	assert.Equal(t, OnConflict{Keys: []string{"a", "b"}, Ignore: true}, OnConflictKeysIgnore([]string{"a", "b"}))
+	assert.Equal(t, OnConflict{Replace: true}, OnConflictReplace())
+	assert.Equal(t, OnConflict{Keys: []string{"id"}, Replace: true}, OnConflictKeyReplace("id"))
+	assert.Equal(t, OnConflict{Keys: []string{"a", "b"}, Replace: true}, OnConflictKeysReplace([]string{"a", "b"}))
+	assert.Equal(t, OnConflict{Keys: []string{"id"}, Replace: true, Fields: []string{"name"}}, OnConflictKeyUpdate("id", "name"))
+}
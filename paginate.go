@@ -0,0 +1,137 @@
+package rel
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"strings"
+	"time"
+)
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// PaginateQuery defines keyset (cursor based) pagination of the query.
+// Records are ordered by the sort fields, and the next page starts right after the last record of the current page.
+// Combination of sort fields must be unique and not null, include primary key as the last sort field to ensure it.
+type PaginateQuery struct {
+	Limit  int
+	Sort   []SortQuery
+	Values []interface{}
+}
+
+// Build query.
+// Sort of the query is replaced by the pagination sort, so the order matches the keyset filter.
+func (pq PaginateQuery) Build(query *Query) {
+	if len(pq.Values) > 0 {
+		query.WhereQuery = query.WhereQuery.And(filterKeyset(pq.Sort, pq.Values))
+	}
+
+	query.SortQuery = append([]SortQuery(nil), pq.Sort...)
+	query.LimitQuery = Limit(pq.Limit)
+}
+
+// After returns pagination query that continues after given cursor.
+// Empty cursor returns pagination query for the first page.
+func (pq PaginateQuery) After(cursor string) (PaginateQuery, error) {
+	pq.Values = nil
+	if cursor == "" {
+		return pq, nil
+	}
+
+	var (
+		payload paginateCursor
+	)
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pq, ErrInvalidCursor
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&payload); err != nil {
+		return pq, ErrInvalidCursor
+	}
+
+	if len(payload.Sort) != len(pq.Sort) || len(payload.Values) != len(pq.Sort) {
+		return pq, ErrInvalidCursor
+	}
+
+	for i := range pq.Sort {
+		if payload.Sort[i] != pq.Sort[i] {
+			return pq, ErrInvalidCursor
+		}
+	}
+
+	pq.Values = payload.Values
+	return pq, nil
+}
+
+// Cursor returns cursor that points to the last record of the page, to be used with After to fetch the next page.
+// Empty cursor is returned when records is less than the limit, which means there's no next page.
+func (pq PaginateQuery) Cursor(records interface{}) (string, error) {
+	var (
+		col = NewCollection(records, true)
+		n   = col.Len()
+	)
+
+	if n == 0 || n < pq.Limit {
+		return "", nil
+	}
+
+	var (
+		buffer  bytes.Buffer
+		doc     = col.Get(n - 1)
+		payload = paginateCursor{
+			Sort:   pq.Sort,
+			Values: make([]interface{}, len(pq.Sort)),
+		}
+	)
+
+	for i := range pq.Sort {
+		value, ok := doc.Value(keysetField(pq.Sort[i].Field))
+		if !ok {
+			return "", errors.New("rel: pagination sort field " + pq.Sort[i].Field + " is not found in " + doc.Table())
+		}
+
+		if value == nil {
+			return "", errors.New("rel: pagination sort field " + pq.Sort[i].Field + " is nil")
+		}
+
+		payload.Values[i] = value
+	}
+
+	if err := gob.NewEncoder(&buffer).Encode(payload); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+type paginateCursor struct {
+	Sort   []SortQuery
+	Values []interface{}
+}
+
+// Paginate creates keyset pagination query with given limit and sort fields.
+// Records are sorted by id when sort fields is not specified.
+func Paginate(limit int, sorts ...SortQuery) PaginateQuery {
+	if len(sorts) == 0 {
+		sorts = []SortQuery{NewSortAsc("id")}
+	}
+
+	return PaginateQuery{
+		Limit: limit,
+		Sort:  sorts,
+	}
+}
+
+// keysetField returns field name without table prefix.
+func keysetField(field string) string {
+	if i := strings.LastIndexByte(field, '.'); i >= 0 {
+		return field[i+1:]
+	}
+
+	return field
+}
@@ -0,0 +1,157 @@
+package rel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	assert.Equal(t, PaginateQuery{
+		Limit: 10,
+		Sort:  []SortQuery{NewSortAsc("id")},
+	}, Paginate(10))
+
+	assert.Equal(t, PaginateQuery{
+		Limit: 10,
+		Sort:  []SortQuery{NewSortDesc("created_at"), NewSortDesc("id")},
+	}, Paginate(10, NewSortDesc("created_at"), NewSortDesc("id")))
+}
+
+func TestPaginateQuery_Build(t *testing.T) {
+	var (
+		page = Paginate(10, NewSortDesc("created_at"), NewSortAsc("id"))
+	)
+
+	assert.Equal(t, Query{
+		Table:      "users",
+		WhereQuery: Eq("active", true),
+		SortQuery:  page.Sort,
+		LimitQuery: 10,
+	}, Build("users", Where(Eq("active", true)), page))
+
+	page.Values = []interface{}{"2020-01-01", 5}
+	assert.Equal(t, Query{
+		Table:      "users",
+		WhereQuery: And(Eq("active", true), Or(Lt("created_at", "2020-01-01"), And(Eq("created_at", "2020-01-01"), Gt("id", 5)))),
+		SortQuery:  page.Sort,
+		LimitQuery: 10,
+	}, Build("users", Where(Eq("active", true)), page))
+
+	// existing sort is replaced, so it doesn't take precedence over the keyset.
+	assert.Equal(t, Query{
+		Table:      "users",
+		WhereQuery: Or(Lt("created_at", "2020-01-01"), And(Eq("created_at", "2020-01-01"), Gt("id", 5))),
+		SortQuery:  page.Sort,
+		LimitQuery: 10,
+	}, Build("users", NewSortAsc("name"), page))
+}
+
+func TestPaginateQuery_Cursor(t *testing.T) {
+	var (
+		createdAt = time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+		page      = Paginate(2, NewSortDesc("users.created_at"), NewSortAsc("id"))
+		users     = []User{
+			{ID: 1, CreatedAt: createdAt.Add(time.Hour)},
+			{ID: 2, CreatedAt: createdAt},
+		}
+	)
+
+	cursor, err := page.Cursor(&users)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, cursor)
+
+	next, err := page.After(cursor)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{createdAt, 2}, next.Values)
+
+	first, err := next.After("")
+	assert.Nil(t, err)
+	assert.Equal(t, page, first)
+}
+
+func TestPaginateQuery_Cursor_lastPage(t *testing.T) {
+	var (
+		page  = Paginate(2)
+		users = []User{{ID: 1}}
+	)
+
+	cursor, err := page.Cursor(&users)
+	assert.Nil(t, err)
+	assert.Equal(t, "", cursor)
+
+	cursor, err = page.Cursor(&[]User{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", cursor)
+}
+
+func TestPaginateQuery_Cursor_nilValue(t *testing.T) {
+	var (
+		page      = Paginate(1, NewSortAsc("user_id"))
+		addresses = []Address{{ID: 1}}
+	)
+
+	cursor, err := page.Cursor(&addresses)
+	assert.Equal(t, "rel: pagination sort field user_id is nil", err.Error())
+	assert.Equal(t, "", cursor)
+}
+
+func TestPaginateQuery_Cursor_unknownField(t *testing.T) {
+	var (
+		page  = Paginate(1, NewSortAsc("score"))
+		users = []User{{ID: 1}}
+	)
+
+	cursor, err := page.Cursor(&users)
+	assert.Equal(t, "rel: pagination sort field score is not found in users", err.Error())
+	assert.Equal(t, "", cursor)
+}
+
+func TestPaginateQuery_After_invalid(t *testing.T) {
+	var (
+		page      = Paginate(1)
+		users     = []User{{ID: 1}}
+		cursor, _ = page.Cursor(&users)
+	)
+
+	tests := []struct {
+		name   string
+		page   PaginateQuery
+		cursor string
+	}{
+		{
+			name:   "malformed base64",
+			page:   page,
+			cursor: "!!!",
+		},
+		{
+			name:   "malformed payload",
+			page:   page,
+			cursor: "aW52YWxpZA",
+		},
+		{
+			name:   "different sort field",
+			page:   Paginate(1, NewSortAsc("name")),
+			cursor: cursor,
+		},
+		{
+			name:   "different sort direction",
+			page:   Paginate(1, NewSortDesc("id")),
+			cursor: cursor,
+		},
+		{
+			name:   "different sort length",
+			page:   Paginate(1, NewSortAsc("id"), NewSortAsc("name")),
+			cursor: cursor,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.page.After(test.cursor)
+			assert.Equal(t, ErrInvalidCursor, err)
+			assert.Nil(t, result.Values)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package rel
+
+// PartitionQuery limits the number of records returned for each distinct value of a field.
+// Records in each partition are ordered using the sort of the query.
+type PartitionQuery struct {
+	Field string
+	Limit int
+}
+
+// Build query.
+func (pq PartitionQuery) Build(query *Query) {
+	query.PartitionQuery = pq
+}
+
+// LimitPartition limits the number of records returned for each distinct value of field.
+//
+//	// latest 3 comments of each post.
+//	repo.FindAll(ctx, &comments, where.In("post_id", 1, 2), sort.Desc("created_at"), rel.LimitPartition("post_id", 3))
+func LimitPartition(field string, limit int) PartitionQuery {
+	return PartitionQuery{
+		Field: field,
+		Limit: limit,
+	}
+}
+
+// LimitPerParent limits the number of preloaded records for each parent record.
+// The partition field is populated using the foreign key of the association.
+//
+//	repo.FindAll(ctx, &posts, rel.Preload("comments", sort.Desc("created_at"), rel.LimitPerParent(3)))
+func LimitPerParent(limit int) PartitionQuery {
+	return PartitionQuery{
+		Limit: limit,
+	}
+}
@@ -0,0 +1,21 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/sort"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitPartition(t *testing.T) {
+	assert.Equal(t, rel.Query{
+		Table:          "comments",
+		SortQuery:      []rel.SortQuery{sort.Desc("created_at")},
+		PartitionQuery: rel.PartitionQuery{Field: "post_id", Limit: 3},
+	}, rel.Build("comments", sort.Desc("created_at"), rel.LimitPartition("post_id", 3)))
+}
+
+func TestLimitPerParent(t *testing.T) {
+	assert.Equal(t, rel.PartitionQuery{Limit: 3}, rel.LimitPerParent(3))
+}
@@ -0,0 +1,44 @@
+package rel
+
+// PreloadQuery defines association to be preloaded after the records are retrieved.
+// Queriers are applied when loading the association, and may include other PreloadQuery to preload the nested associations.
+// When Join is true, association is loaded together with the records using left join instead of a separate query.
+type PreloadQuery struct {
+	Field    string
+	Queriers []Querier
+	Join     bool
+}
+
+// Build query.
+func (pq PreloadQuery) Build(query *Query) {
+	query.PreloadQuery = append(query.PreloadQuery, pq)
+}
+
+// Preload association of the retrieved records using given queriers.
+//
+//	repo.FindAll(ctx, &users, rel.Preload("orders",
+//		where.Eq("status", "paid"),
+//		rel.Preload("items", sort.Asc("position")),
+//	))
+func Preload(field string, queriers ...Querier) PreloadQuery {
+	return PreloadQuery{
+		Field:    field,
+		Queriers: queriers,
+	}
+}
+
+// PreloadJoin loads belongs to or has one association of the retrieved records using left join.
+// Columns of the association are selected using association name as alias, and scanned together with the records, thus no additional query is needed.
+// Queriers are only used to preload the nested associations.
+// Association that can't be joined, or has soft delete, default scope or tenant scope, is preloaded using separate query instead.
+//
+//	repo.FindAll(ctx, &transactions, rel.PreloadJoin("buyer",
+//		rel.Preload("addresses"),
+//	))
+func PreloadJoin(field string, queriers ...Querier) PreloadQuery {
+	return PreloadQuery{
+		Field:    field,
+		Queriers: queriers,
+		Join:     true,
+	}
+}
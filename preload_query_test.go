@@ -0,0 +1,51 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/sort"
+	"github.com/Fs02/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreload(t *testing.T) {
+	var (
+		items  = rel.Preload("items", sort.Asc("position"))
+		orders = rel.Preload("orders", where.Eq("status", "paid"), items)
+	)
+
+	assert.Equal(t, rel.PreloadQuery{
+		Field:    "orders",
+		Queriers: []rel.Querier{where.Eq("status", "paid"), items},
+	}, orders)
+
+	assert.Equal(t, rel.Query{
+		Table:        "users",
+		PreloadQuery: []rel.PreloadQuery{orders},
+	}, rel.Build("users", orders))
+
+	assert.Equal(t, rel.Query{
+		Table:        "orders",
+		WhereQuery:   where.Eq("status", "paid"),
+		PreloadQuery: []rel.PreloadQuery{items},
+	}, rel.Build("orders", orders.Queriers...))
+}
+
+func TestPreloadJoin(t *testing.T) {
+	var (
+		addresses = rel.Preload("addresses")
+		buyer     = rel.PreloadJoin("buyer", addresses)
+	)
+
+	assert.Equal(t, rel.PreloadQuery{
+		Field:    "buyer",
+		Queriers: []rel.Querier{addresses},
+		Join:     true,
+	}, buyer)
+
+	assert.Equal(t, rel.Query{
+		Table:        "transactions",
+		PreloadQuery: []rel.PreloadQuery{buyer},
+	}, rel.Build("transactions", buyer))
+}
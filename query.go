@@ -40,6 +40,18 @@ func Build(table string, queriers ...Querier) Query {
 			q.Build(&query)
 		case SQLQuery:
 			q.Build(&query)
+		case WithQuery:
+			q.Build(&query)
+		case PaginateQuery:
+			q.Build(&query)
+		case Scope:
+			q.Build(&query)
+		case PreloadQuery:
+			q.Build(&query)
+		case PartitionQuery:
+			q.Build(&query)
+		case SelectExpr:
+			q.Build(&query)
 		}
 	}
 
@@ -52,19 +64,23 @@ func Build(table string, queriers ...Querier) Query {
 
 // Query defines information about query generated by query builder.
 type Query struct {
-	empty         bool // TODO: use bitmask to mark what is updated and use it when merging two queries
-	Table         string
-	SelectQuery   SelectQuery
-	JoinQuery     []JoinQuery
-	WhereQuery    FilterQuery
-	GroupQuery    GroupQuery
-	SortQuery     []SortQuery
-	OffsetQuery   Offset
-	LimitQuery    Limit
-	LockQuery     Lock
-	UnscopedQuery Unscoped
-	ReloadQuery   Reload
-	SQLQuery      SQLQuery
+	empty          bool // TODO: use bitmask to mark what is updated and use it when merging two queries
+	WithQuery      []WithQuery
+	Table          string
+	SelectQuery    SelectQuery
+	JoinQuery      []JoinQuery
+	WhereQuery     FilterQuery
+	GroupQuery     GroupQuery
+	UnionAllQuery  []Query
+	SortQuery      []SortQuery
+	OffsetQuery    Offset
+	LimitQuery     Limit
+	PartitionQuery PartitionQuery
+	LockQuery      Lock
+	UnscopedQuery  Unscoped
+	ReloadQuery    Reload
+	SQLQuery       SQLQuery
+	PreloadQuery   []PreloadQuery
 }
 
 // Build query.
@@ -73,11 +89,13 @@ func (q Query) Build(query *Query) {
 		*query = q
 	} else {
 		// manual merge
+		query.WithQuery = append(query.WithQuery, q.WithQuery...)
+
 		if q.Table != "" {
 			query.Table = q.Table
 		}
 
-		if q.SelectQuery.Fields != nil {
+		if q.SelectQuery.Fields != nil || q.SelectQuery.Exprs != nil {
 			query.SelectQuery = q.SelectQuery
 		}
 
@@ -91,6 +109,8 @@ func (q Query) Build(query *Query) {
 			query.GroupQuery = q.GroupQuery
 		}
 
+		query.UnionAllQuery = append(query.UnionAllQuery, q.UnionAllQuery...)
+
 		q.SortQuery = append(q.SortQuery, query.SortQuery...)
 
 		if q.OffsetQuery != 0 {
@@ -101,20 +121,43 @@ func (q Query) Build(query *Query) {
 			query.LimitQuery = q.LimitQuery
 		}
 
+		if q.PartitionQuery.Limit != 0 {
+			query.PartitionQuery = q.PartitionQuery
+		}
+
 		if q.LockQuery != "" {
 			query.LockQuery = q.LockQuery
 		}
 
 		query.ReloadQuery = q.ReloadQuery
+		query.PreloadQuery = append(query.PreloadQuery, q.PreloadQuery...)
 	}
 }
 
+// With defines common table expression that can be referenced by the query using given name.
+func (q Query) With(name string, query Query) Query {
+	NewWith(name, query).Build(&q)
+	return q
+}
+
+// WithRecursive defines recursive common table expression that can be referenced by the query using given name.
+func (q Query) WithRecursive(name string, query Query) Query {
+	NewWithRecursive(name, query).Build(&q)
+	return q
+}
+
 // Select filter fields to be selected from database.
 func (q Query) Select(fields ...string) Query {
 	q.SelectQuery = NewSelect(fields...)
 	return q
 }
 
+// SelectExpr adds typed expressions to be selected after the fields.
+func (q Query) SelectExpr(exprs ...SelectExpr) Query {
+	q.SelectQuery.Exprs = append(q.SelectQuery.Exprs, exprs...)
+	return q
+}
+
 // From set the table to be used for query.
 func (q Query) From(table string) Query {
 	q.Table = table
@@ -205,6 +248,19 @@ func (q Query) OrHavingf(expr string, args ...interface{}) Query {
 	return q
 }
 
+// UnionAll combines the query with other query, including duplicate rows.
+// It's meant for the recursive part of common table expression defined using WithRecursive.
+func (q Query) UnionAll(query Query) Query {
+	q.UnionAllQuery = append(q.UnionAllQuery, query)
+	return q
+}
+
+// Preload association of the retrieved records using given queriers.
+func (q Query) Preload(field string, queriers ...Querier) Query {
+	Preload(field, queriers...).Build(&q)
+	return q
+}
+
 // Sort query.
 func (q Query) Sort(fields ...string) Query {
 	return q.SortAsc(fields...)
@@ -250,6 +306,12 @@ func (q Query) Limit(limit int) Query {
 	return q
 }
 
+// LimitPartition limits the number of records returned for each distinct value of field.
+func (q Query) LimitPartition(field string, limit int) Query {
+	q.PartitionQuery = LimitPartition(field, limit)
+	return q
+}
+
 // Lock query expression.
 func (q Query) Lock(lock string) Query {
 	q.LockQuery = Lock(lock)
@@ -268,6 +330,24 @@ func (q Query) Reload() Query {
 	return q
 }
 
+// With create a query with chainable syntax, using common table expression as the starting point.
+func With(name string, query Query) Query {
+	return Query{
+		WithQuery: []WithQuery{
+			NewWith(name, query),
+		},
+	}
+}
+
+// WithRecursive create a query with chainable syntax, using recursive common table expression as the starting point.
+func WithRecursive(name string, query Query) Query {
+	return Query{
+		WithQuery: []WithQuery{
+			NewWithRecursive(name, query),
+		},
+	}
+}
+
 // Select query create a query with chainable syntax, using select as the starting point.
 func Select(fields ...string) Query {
 	return Query{
@@ -376,3 +456,21 @@ func (u Unscoped) Build(query *Query) {
 func (u Unscoped) Apply(doc *Document, mutation *Mutation) {
 	mutation.Unscoped = u
 }
+
+func (u Unscoped) applyDelete(option *deleteOption) {
+	option.unscoped = u
+}
+
+// Scope is a reusable named query that can be passed as querier.
+//
+//	func Published(query rel.Query) rel.Query {
+//		return query.Where(where.Eq("status", "published"))
+//	}
+//
+//	repo.FindAll(ctx, &books, rel.Scope(Published))
+type Scope func(query Query) Query
+
+// Build query.
+func (s Scope) Build(query *Query) {
+	*query = s(*query)
+}
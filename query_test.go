@@ -118,6 +118,19 @@ func TestQuerier(t *testing.T) {
 				UnscopedQuery: true,
 			},
 		},
+		{
+			name: "where id=1 with scope",
+			queriers: [][]rel.Querier{
+				{
+					where.Eq("id", 1), rel.Scope(func(query rel.Query) rel.Query {
+						return query.Where(where.Ne("status", "archived"))
+					}),
+				},
+			},
+			query: rel.Query{
+				WhereQuery: where.Eq("id", 1).AndNe("status", "archived"),
+			},
+		},
 		{
 			name: "where id=1 for update",
 			queriers: [][]rel.Querier{
@@ -145,6 +158,38 @@ func TestQuerier(t *testing.T) {
 				LockQuery:   "FOR UPDATE",
 			},
 		},
+		{
+			name: "with recursive tree union all",
+			queriers: [][]rel.Querier{
+				{
+					rel.WithRecursive("tree", rel.From("categories").Where(where.Eq("id", 1)).UnionAll(rel.From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))).From("tree"),
+				},
+				{
+					rel.NewWithRecursive("tree", rel.From("categories").Where(where.Eq("id", 1)).UnionAll(rel.From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))),
+					rel.From("tree"),
+				},
+				{
+					rel.From("tree"),
+					rel.NewWithRecursive("tree", rel.Build("categories", where.Eq("id", 1), rel.From("categories").UnionAll(rel.From("categories").JoinOn("tree", "tree.id", "categories.parent_id")))),
+				},
+			},
+			query: rel.Query{
+				WithQuery: []rel.WithQuery{
+					{
+						Name: "tree",
+						Query: rel.Query{
+							Table:      "categories",
+							WhereQuery: where.Eq("id", 1),
+							UnionAllQuery: []rel.Query{
+								rel.From("categories").JoinOn("tree", "tree.id", "categories.parent_id"),
+							},
+						},
+						Recursive: true,
+					},
+				},
+				Table: "tree",
+			},
+		},
 		{
 			name: "sql query",
 			queriers: [][]rel.Querier{
@@ -386,6 +431,39 @@ func TestQuery_OrWhere(t *testing.T) {
 	}
 }
 
+func TestQuery_With(t *testing.T) {
+	var (
+		paid   = rel.From("transactions").Where(where.Eq("status", "paid"))
+		result = rel.Query{
+			WithQuery: []rel.WithQuery{
+				{Name: "paid_transactions", Query: paid},
+				{Name: "tree", Query: rel.From("categories"), Recursive: true},
+			},
+			Table: "paid_transactions",
+		}
+	)
+
+	assert.Equal(t, result, rel.With("paid_transactions", paid).WithRecursive("tree", rel.From("categories")).From("paid_transactions"))
+	assert.Equal(t, result, rel.From("paid_transactions").With("paid_transactions", paid).WithRecursive("tree", rel.From("categories")))
+	assert.Equal(t, rel.Query{
+		WithQuery: []rel.WithQuery{
+			{Name: "tree", Query: rel.From("categories"), Recursive: true},
+		},
+	}, rel.WithRecursive("tree", rel.From("categories")))
+}
+
+func TestQuery_UnionAll(t *testing.T) {
+	var (
+		child  = rel.From("categories").JoinOn("tree", "tree.id", "categories.parent_id")
+		result = rel.Query{
+			Table:         "categories",
+			UnionAllQuery: []rel.Query{child},
+		}
+	)
+
+	assert.Equal(t, result, rel.From("categories").UnionAll(child))
+}
+
 func TestQuery_Group(t *testing.T) {
 	result := rel.Query{
 		Table: "users",
@@ -621,6 +699,21 @@ func TestQuery_Limit(t *testing.T) {
 	}, rel.From("users").Limit(10))
 }
 
+func TestQuery_LimitPartition(t *testing.T) {
+	assert.Equal(t, rel.Query{
+		Table:          "comments",
+		PartitionQuery: rel.PartitionQuery{Field: "post_id", Limit: 3},
+	}, rel.From("comments").LimitPartition("post_id", 3))
+}
+
+func TestQuery_LimitPartition_merge(t *testing.T) {
+	assert.Equal(t, rel.Query{
+		Table:          "comments",
+		WhereQuery:     where.Eq("status", "published"),
+		PartitionQuery: rel.PartitionQuery{Field: "post_id", Limit: 3},
+	}, rel.Build("", where.Eq("status", "published"), rel.From("comments").LimitPartition("post_id", 3)))
+}
+
 func TestQuery_Lock_outsideTransaction(t *testing.T) {
 	assert.Equal(t, rel.Query{
 		Table:     "users",
@@ -67,3 +67,107 @@ type UserRole struct {
 	UserID int `db:",primary"`
 	RoleID int `db:",primary"`
 }
+
+type Post struct {
+	ID    int
+	Title string
+	Tags  []Tag `through:"post_tags"`
+}
+
+type Invoice struct {
+	ID          int
+	Name        string
+	LockVersion int
+}
+
+type Ledger struct {
+	ID           int
+	LockVersion  int
+	Transactions []Transaction `ref:"id" fk:"user_id"`
+}
+
+type Project struct {
+	ID       int
+	Name     string
+	TenantID int
+}
+
+type Member struct {
+	ID        int
+	ProjectID int
+	Project   Project
+}
+
+type Workspace struct {
+	ID       int
+	TenantID int
+	Tasks    []Task
+}
+
+type Task struct {
+	ID          int
+	WorkspaceID int
+	TenantID    int
+}
+
+type Board struct {
+	ID      int
+	Tickets []Ticket
+}
+
+type Ticket struct {
+	ID      int
+	BoardID int
+	Status  string
+}
+
+func (Ticket) DefaultScope() Query {
+	return Where(Ne("status", "archived"))
+}
+
+type Article struct {
+	ID      int
+	Title   string
+	Remarks []Remark `polymorphic:"remarkable"`
+}
+
+type Photo struct {
+	ID     int
+	Remark *Remark `polymorphic:"remarkable,image"`
+}
+
+type Remark struct {
+	ID             int
+	Body           string
+	RemarkableID   int
+	RemarkableType string
+	Article        *Article `polymorphic:"remarkable"`
+	Photo          *Photo   `polymorphic:"remarkable,image"`
+}
+
+type Model struct {
+	ID int
+}
+
+type Audit struct {
+	CreatedBy string
+	UpdatedBy string
+}
+
+type Timestamps struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Comment struct {
+	Model
+	Body string
+	*Audit
+	Timestamps
+}
+
+type Tag struct {
+	ID    int
+	Name  string
+	Posts []Post `through:"post_tags"`
+}
@@ -1,6 +1,13 @@
 package reltest
 
-import "github.com/Fs02/rel"
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/Fs02/rel"
+	"github.com/stretchr/testify/mock"
+)
 
 // Aggregate asserts and simulate aggregate function for test.
 type Aggregate struct {
@@ -41,3 +48,87 @@ func ExpectCount(r *Repository, collection string, queriers []rel.Querier) *Aggr
 		),
 	}
 }
+
+// AggregateFloat asserts and simulate aggregate float function for test.
+type AggregateFloat struct {
+	*Expect
+}
+
+// Result sets the result of this query.
+func (af *AggregateFloat) Result(result float64) {
+	af.Return(result, nil)
+}
+
+// Error sets error to be returned.
+func (af *AggregateFloat) Error(err error) {
+	af.Return(float64(0), err)
+}
+
+// ConnectionClosed sets this error to be returned.
+func (af *AggregateFloat) ConnectionClosed() {
+	af.Error(ErrConnectionClosed)
+}
+
+// ExpectAggregateFloat to be called with given field and queries.
+func ExpectAggregateFloat(r *Repository, query rel.Query, aggregate string, field string) *AggregateFloat {
+	return &AggregateFloat{
+		Expect: newExpect(r, "AggregateFloat",
+			[]interface{}{r.ctxData, query, aggregate, field},
+			[]interface{}{float64(0), nil},
+		),
+	}
+}
+
+// AggregateDecimal asserts and simulate aggregate decimal function for test.
+type AggregateDecimal struct {
+	*Expect
+}
+
+// Result sets the result of this query.
+func (ad *AggregateDecimal) Result(result *big.Rat) {
+	ad.Return(result, nil)
+}
+
+// Error sets error to be returned.
+func (ad *AggregateDecimal) Error(err error) {
+	ad.Return((*big.Rat)(nil), err)
+}
+
+// ConnectionClosed sets this error to be returned.
+func (ad *AggregateDecimal) ConnectionClosed() {
+	ad.Error(ErrConnectionClosed)
+}
+
+// ExpectAggregateDecimal to be called with given field and queries.
+func ExpectAggregateDecimal(r *Repository, query rel.Query, aggregate string, field string) *AggregateDecimal {
+	return &AggregateDecimal{
+		Expect: newExpect(r, "AggregateDecimal",
+			[]interface{}{r.ctxData, query, aggregate, field},
+			[]interface{}{new(big.Rat), nil},
+		),
+	}
+}
+
+// AggregateGroup asserts and simulate aggregate group function for test.
+type AggregateGroup struct {
+	*Expect
+}
+
+// Result sets the result of this query.
+func (ag *AggregateGroup) Result(records interface{}) {
+	ag.Arguments[1] = mock.AnythingOfType(fmt.Sprintf("*%T", records))
+
+	ag.Run(func(args mock.Arguments) {
+		reflect.ValueOf(args[1]).Elem().Set(reflect.ValueOf(records))
+	})
+}
+
+// ExpectAggregateGroup to be called with given query and aggregates.
+func ExpectAggregateGroup(r *Repository, query rel.Query, aggregates []rel.SelectExpr) *AggregateGroup {
+	return &AggregateGroup{
+		Expect: newExpect(r, "AggregateGroup",
+			[]interface{}{r.ctxData, mock.Anything, query, aggregates},
+			[]interface{}{nil},
+		),
+	}
+}
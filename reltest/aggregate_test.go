@@ -3,6 +3,7 @@ package reltest
 import (
 	"context"
 	"database/sql"
+	"math/big"
 	"testing"
 
 	"github.com/Fs02/rel"
@@ -84,3 +85,111 @@ func TestAggregate_Count_error(t *testing.T) {
 	})
 	repo.AssertExpectations(t)
 }
+
+func TestAggregateFloat(t *testing.T) {
+	var (
+		repo = New()
+	)
+
+	repo.ExpectAggregateFloat(rel.From("books"), "avg", "price").Result(12.5)
+	avg, err := repo.AggregateFloat(context.TODO(), rel.From("books"), "avg", "price")
+	assert.Nil(t, err)
+	assert.Equal(t, 12.5, avg)
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateFloat(rel.From("books"), "avg", "price").Result(12.5)
+	assert.NotPanics(t, func() {
+		avg := repo.MustAggregateFloat(context.TODO(), rel.From("books"), "avg", "price")
+		assert.Equal(t, 12.5, avg)
+	})
+	repo.AssertExpectations(t)
+}
+
+func TestAggregateFloat_error(t *testing.T) {
+	var (
+		repo = New()
+	)
+
+	repo.ExpectAggregateFloat(rel.From("books"), "avg", "price").ConnectionClosed()
+	avg, err := repo.AggregateFloat(context.TODO(), rel.From("books"), "avg", "price")
+	assert.Equal(t, sql.ErrConnDone, err)
+	assert.Equal(t, float64(0), avg)
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateFloat(rel.From("books"), "avg", "price").ConnectionClosed()
+	assert.Panics(t, func() {
+		repo.MustAggregateFloat(context.TODO(), rel.From("books"), "avg", "price")
+	})
+	repo.AssertExpectations(t)
+}
+
+func TestAggregateDecimal(t *testing.T) {
+	var (
+		repo   = New()
+		result = big.NewRat(1234567890123456789, 100)
+	)
+
+	repo.ExpectAggregateDecimal(rel.From("books"), "sum", "price").Result(result)
+	sum, err := repo.AggregateDecimal(context.TODO(), rel.From("books"), "sum", "price")
+	assert.Nil(t, err)
+	assert.Equal(t, result, sum)
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateDecimal(rel.From("books"), "sum", "price").ConnectionClosed()
+	assert.Panics(t, func() {
+		repo.MustAggregateDecimal(context.TODO(), rel.From("books"), "sum", "price")
+	})
+	repo.AssertExpectations(t)
+}
+
+func TestAggregateGroup(t *testing.T) {
+	type BookStat struct {
+		AuthorID int
+		Count    int
+		AvgPrice float64
+	}
+
+	var (
+		repo   = New()
+		result []BookStat
+		stats  = []BookStat{
+			{AuthorID: 1, Count: 2, AvgPrice: 12.5},
+			{AuthorID: 2, Count: 1, AvgPrice: 10},
+		}
+		query      = rel.From("books").Group("author_id")
+		aggregates = []rel.SelectExpr{
+			rel.NewSelectAggregate("count", "*"),
+			rel.NewSelectAggregate("avg", "price").As("avg_price"),
+		}
+	)
+
+	repo.ExpectAggregateGroup(query, aggregates...).Result(stats)
+	assert.Nil(t, repo.AggregateGroup(context.TODO(), &result, query, aggregates...))
+	assert.Equal(t, stats, result)
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateGroup(query, aggregates...).Result(stats)
+	assert.NotPanics(t, func() {
+		repo.MustAggregateGroup(context.TODO(), &result, query, aggregates...)
+		assert.Equal(t, stats, result)
+	})
+	repo.AssertExpectations(t)
+}
+
+func TestAggregateGroup_error(t *testing.T) {
+	var (
+		repo   = New()
+		result []map[string]interface{}
+		query  = rel.From("books").Group("author_id")
+	)
+
+	repo.ExpectAggregateGroup(query, rel.NewSelectAggregate("count", "*")).ConnectionClosed()
+	assert.Equal(t, sql.ErrConnDone, repo.AggregateGroup(context.TODO(), &result, query, rel.NewSelectAggregate("count", "*")))
+	repo.AssertExpectations(t)
+
+	repo.ExpectAggregateGroup(query, rel.NewSelectAggregate("count", "*")).ConnectionClosed()
+	assert.Panics(t, func() {
+		repo.MustAggregateGroup(context.TODO(), &result, query, rel.NewSelectAggregate("count", "*"))
+	})
+	repo.AssertExpectations(t)
+}
@@ -24,8 +24,13 @@ func (d *Delete) ForType(typ string) *Delete {
 	return d.For(mock.AnythingOfType("*" + strings.TrimPrefix(typ, "*")))
 }
 
+// StaleRecord sets stale record error to be returned.
+func (d *Delete) StaleRecord() {
+	d.Error(rel.StaleRecordError{})
+}
+
 // ExpectDelete to be called.
-func ExpectDelete(r *Repository, options []rel.Cascade) *Delete {
+func ExpectDelete(r *Repository, options []rel.DeleteOption) *Delete {
 	return &Delete{
 		Expect: newExpect(r, "Delete", []interface{}{r.ctxData, mock.Anything, options}, []interface{}{nil}),
 	}
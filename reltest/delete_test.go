@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/Fs02/rel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,3 +56,13 @@ func TestDelete_error(t *testing.T) {
 	})
 	repo.AssertExpectations(t)
 }
+
+func TestDelete_staleRecord(t *testing.T) {
+	var (
+		repo = New()
+	)
+
+	repo.ExpectDelete().StaleRecord()
+	assert.Equal(t, rel.StaleRecordError{}, repo.Delete(context.TODO(), &Book{ID: 1}))
+	repo.AssertExpectations(t)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/Fs02/rel"
 	"github.com/Fs02/rel/where"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +33,20 @@ func TestFindAll(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestFindAll_preload(t *testing.T) {
+	var (
+		repo   = New()
+		result []Book
+		books  = []Book{{ID: 1, Title: "Golang for dummies"}}
+		query  = rel.From("books").Preload("ratings", rel.Preload("book"))
+	)
+
+	repo.ExpectFindAll(query, rel.Preload("author")).Result(books)
+	assert.Nil(t, repo.FindAll(context.TODO(), &result, query, rel.Preload("author")))
+	assert.Equal(t, books, result)
+	repo.AssertExpectations(t)
+}
+
 func TestFindAll_error(t *testing.T) {
 	var (
 		repo   = New()
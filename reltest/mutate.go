@@ -32,6 +32,11 @@ func (m *Mutate) NotUnique(key string) {
 	})
 }
 
+// StaleRecord sets stale record error to be returned.
+func (m *Mutate) StaleRecord() {
+	m.Error(rel.StaleRecordError{})
+}
+
 func expectMutate(r *Repository, methodName string, mutators []rel.Mutator) *Mutate {
 	mutatorsArgument := interface{}(mutators)
 	if mutators == nil {
@@ -59,13 +64,11 @@ func ExpectUpdate(r *Repository, mutators []rel.Mutator) *Mutate {
 }
 
 // ExpectInsertAll to be called.
-func ExpectInsertAll(r *Repository) *Mutate {
-	em := &Mutate{
-		Expect: newExpect(r, "InsertAll",
-			[]interface{}{r.ctxData, mock.Anything},
-			[]interface{}{nil},
-		),
-	}
+func ExpectInsertAll(r *Repository, mutators []rel.Mutator) *Mutate {
+	return expectMutate(r, "InsertAll", mutators)
+}
 
-	return em
+// ExpectUpsert to be called with given field and queries.
+func ExpectUpsert(r *Repository, mutators []rel.Mutator) *Mutate {
+	return expectMutate(r, "Upsert", mutators)
 }
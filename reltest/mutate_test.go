@@ -205,6 +205,38 @@ func TestMutate_InsertAll(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestMutate_InsertAll_onConflict(t *testing.T) {
+	var (
+		repo    = New()
+		results = []Book{
+			{ID: 1, Title: "Golang for dummies"},
+		}
+	)
+
+	repo.ExpectInsertAll(rel.OnConflictReplace())
+	assert.Nil(t, repo.InsertAll(context.TODO(), &results, rel.OnConflictReplace()))
+	repo.AssertExpectations(t)
+}
+
+func TestMutate_Upsert(t *testing.T) {
+	var (
+		repo   = New()
+		result = Book{Title: "Golang for dummies"}
+		book   = Book{ID: 1, Title: "Golang for dummies"}
+	)
+
+	repo.ExpectUpsert()
+	assert.Nil(t, repo.Upsert(context.TODO(), &result))
+	assert.Equal(t, book, result)
+	repo.AssertExpectations(t)
+
+	repo.ExpectUpsert(rel.OnConflictKeyIgnore("title")).ForType("reltest.Book").Return(errors.New("error"))
+	assert.Panics(t, func() {
+		repo.MustUpsert(context.TODO(), &result, rel.OnConflictKeyIgnore("title"))
+	})
+	repo.AssertExpectations(t)
+}
+
 func TestMutate_Update(t *testing.T) {
 	var (
 		repo   = New()
@@ -589,6 +621,20 @@ func TestMutate_Update_unknownField(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestMutate_Update_staleRecord(t *testing.T) {
+	var (
+		repo   = New()
+		result = Book{ID: 2, Title: "Golang for dummies"}
+	)
+
+	repo.ExpectUpdate(rel.Set("title", "Rel for dummies")).StaleRecord()
+	assert.Equal(t,
+		rel.StaleRecordError{},
+		repo.Update(context.TODO(), &result, rel.Set("title", "Rel for dummies")),
+	)
+	repo.AssertExpectations(t)
+}
+
 func TestMutate_Update_notUnique(t *testing.T) {
 	var (
 		repo   = New()
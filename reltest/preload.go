@@ -14,6 +14,7 @@ type Preload struct {
 }
 
 // Result sets the result of Preload query.
+// Many to many association can't be mapped by the records, hence every parents will receive all records.
 func (p *Preload) Result(records interface{}) {
 	p.Run(func(args mock.Arguments) {
 		var (
@@ -92,10 +93,11 @@ func preload(target slice, result slice, path []string) {
 
 	for len(stack) > 0 {
 		var (
-			n       = len(stack) - 1
-			top     = stack[n]
-			assocs  = top.doc.Association(path[top.index])
-			hasMany = assocs.Type() == rel.HasMany
+			n          = len(stack) - 1
+			top        = stack[n]
+			assocs     = top.doc.Association(path[top.index])
+			manyToMany = assocs.Type() == rel.ManyToMany
+			hasMany    = assocs.Type() == rel.HasMany || manyToMany
 		)
 
 		stack = stack[:n]
@@ -107,7 +109,7 @@ func preload(target slice, result slice, path []string) {
 				fField = assocs.ForeignField()
 			)
 
-			if rValue == nil {
+			if rValue == nil || !polymorphicMatch(top.doc, assocs) {
 				continue
 			}
 
@@ -119,6 +121,11 @@ func preload(target slice, result slice, path []string) {
 
 			curr.Reset()
 
+			if manyToMany {
+				curr.ReflectValue().Set(result.ReflectValue())
+				continue
+			}
+
 			if mappedResult == nil {
 				mappedResult = mapResult(result, fField, hasMany)
 			}
@@ -127,7 +134,7 @@ func preload(target slice, result slice, path []string) {
 				curr.ReflectValue().Set(rv)
 			}
 		} else {
-			if assocs.Type() == rel.HasMany {
+			if hasMany {
 				var (
 					col, loaded = assocs.Collection()
 				)
@@ -180,3 +187,14 @@ func mapResult(result slice, fField string, hasMany bool) map[interface{}]reflec
 
 	return mapResult
 }
+
+// polymorphicMatch returns false when polymorphic belongs to association is owned by other type.
+func polymorphicMatch(doc *rel.Document, assoc rel.Association) bool {
+	field := assoc.PolymorphicField()
+	if field == "" || assoc.Type() != rel.BelongsTo {
+		return true
+	}
+
+	typ, _ := doc.Value(field)
+	return typ == assoc.PolymorphicValue()
+}
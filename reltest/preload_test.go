@@ -203,3 +203,52 @@ func TestPreload_error(t *testing.T) {
 	})
 	repo.AssertExpectations(t)
 }
+
+func TestPreload_manyToMany(t *testing.T) {
+	var (
+		repo   = New()
+		result = []Genre{
+			{ID: 1, Name: "Programming"},
+			{ID: 2, Name: "Education"},
+		}
+		books = []Book{
+			{ID: 1, Title: "Golang for dummies"},
+			{ID: 2, Title: "Rel for dummies"},
+		}
+	)
+
+	repo.ExpectPreload("books").Result(books)
+	assert.Nil(t, repo.Preload(context.TODO(), &result, "books"))
+	assert.Equal(t, books, result[0].Books)
+	assert.Equal(t, books, result[1].Books)
+	repo.AssertExpectations(t)
+}
+
+func TestPreload_polymorphicBelongsTo(t *testing.T) {
+	type Photo struct {
+		ID int
+	}
+
+	type Review struct {
+		ID             int
+		ReviewableID   int
+		ReviewableType string
+		Book           *Book  `polymorphic:"reviewable"`
+		Photo          *Photo `polymorphic:"reviewable"`
+	}
+
+	var (
+		repo   = New()
+		result = []Review{
+			{ID: 1, ReviewableID: 1, ReviewableType: "books"},
+			{ID: 2, ReviewableID: 1, ReviewableType: "photos"},
+		}
+		books = []Book{{ID: 1, Title: "Rel for dummies"}}
+	)
+
+	repo.ExpectPreload("book").Result(books)
+	assert.Nil(t, repo.Preload(context.TODO(), &result, "book"))
+	assert.Equal(t, &books[0], result[0].Book)
+	assert.Nil(t, result[1].Book)
+	repo.AssertExpectations(t)
+}
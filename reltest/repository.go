@@ -2,6 +2,7 @@ package reltest
 
 import (
 	"context"
+	"math/big"
 	"runtime"
 	"testing"
 
@@ -28,6 +29,16 @@ func (r *Repository) Instrumentation(instrumenter rel.Instrumenter) {
 	r.repo.Instrumentation(instrumenter)
 }
 
+// Tenant registers field used to scope operations by tenant.
+func (r *Repository) Tenant(field string, tables ...string) {
+	r.repo.Tenant(field, tables...)
+}
+
+// DefaultScope registers default scope of the records for Count and Aggregate.
+func (r *Repository) DefaultScope(records ...interface{}) {
+	r.repo.DefaultScope(records...)
+}
+
 // Ping database.
 func (r *Repository) Ping(ctx context.Context) error {
 	return r.repo.Ping(ctx)
@@ -65,6 +76,60 @@ func (r *Repository) ExpectAggregate(query rel.Query, aggregate string, field st
 	return ExpectAggregate(r, query, aggregate, field)
 }
 
+// AggregateFloat provides a mock function with given fields: query, aggregate, field
+func (r *Repository) AggregateFloat(ctx context.Context, query rel.Query, aggregate string, field string) (float64, error) {
+	r.repo.AggregateFloat(ctx, query, aggregate, field)
+	ret := r.mock.Called(fetchContext(ctx), query, aggregate, field)
+	return ret.Get(0).(float64), ret.Error(1)
+}
+
+// MustAggregateFloat provides a mock function with given fields: query, aggregate, field
+func (r *Repository) MustAggregateFloat(ctx context.Context, query rel.Query, aggregate string, field string) float64 {
+	result, err := r.AggregateFloat(ctx, query, aggregate, field)
+	must(err)
+	return result
+}
+
+// ExpectAggregateFloat apply mocks and expectations for AggregateFloat
+func (r *Repository) ExpectAggregateFloat(query rel.Query, aggregate string, field string) *AggregateFloat {
+	return ExpectAggregateFloat(r, query, aggregate, field)
+}
+
+// AggregateDecimal provides a mock function with given fields: query, aggregate, field
+func (r *Repository) AggregateDecimal(ctx context.Context, query rel.Query, aggregate string, field string) (*big.Rat, error) {
+	r.repo.AggregateDecimal(ctx, query, aggregate, field)
+	ret := r.mock.Called(fetchContext(ctx), query, aggregate, field)
+	return ret.Get(0).(*big.Rat), ret.Error(1)
+}
+
+// MustAggregateDecimal provides a mock function with given fields: query, aggregate, field
+func (r *Repository) MustAggregateDecimal(ctx context.Context, query rel.Query, aggregate string, field string) *big.Rat {
+	result, err := r.AggregateDecimal(ctx, query, aggregate, field)
+	must(err)
+	return result
+}
+
+// ExpectAggregateDecimal apply mocks and expectations for AggregateDecimal
+func (r *Repository) ExpectAggregateDecimal(query rel.Query, aggregate string, field string) *AggregateDecimal {
+	return ExpectAggregateDecimal(r, query, aggregate, field)
+}
+
+// AggregateGroup provides a mock function with given fields: records, query, aggregates
+func (r *Repository) AggregateGroup(ctx context.Context, records interface{}, query rel.Query, aggregates ...rel.SelectExpr) error {
+	r.repo.AggregateGroup(ctx, records, query, aggregates...)
+	return r.mock.Called(fetchContext(ctx), records, query, aggregates).Error(0)
+}
+
+// MustAggregateGroup provides a mock function with given fields: records, query, aggregates
+func (r *Repository) MustAggregateGroup(ctx context.Context, records interface{}, query rel.Query, aggregates ...rel.SelectExpr) {
+	must(r.AggregateGroup(ctx, records, query, aggregates...))
+}
+
+// ExpectAggregateGroup apply mocks and expectations for AggregateGroup
+func (r *Repository) ExpectAggregateGroup(query rel.Query, aggregates ...rel.SelectExpr) *AggregateGroup {
+	return ExpectAggregateGroup(r, query, aggregates)
+}
+
 // Count provides a mock function with given fields: collection, queriers
 func (r *Repository) Count(ctx context.Context, collection string, queriers ...rel.Querier) (int, error) {
 	r.repo.Count(ctx, collection, queriers...)
@@ -86,7 +151,7 @@ func (r *Repository) ExpectCount(collection string, queriers ...rel.Querier) *Ag
 
 // Find provides a mock function with given fields: record, queriers
 func (r *Repository) Find(ctx context.Context, record interface{}, queriers ...rel.Querier) error {
-	r.repo.Find(ctx, record, queriers...)
+	r.repo.Find(ctx, record, withoutPreload(queriers)...)
 	return r.mock.Called(fetchContext(ctx), record, queriers).Error(0)
 }
 
@@ -102,7 +167,7 @@ func (r *Repository) ExpectFind(queriers ...rel.Querier) *Find {
 
 // FindAll provides a mock function with given fields: records, queriers
 func (r *Repository) FindAll(ctx context.Context, records interface{}, queriers ...rel.Querier) error {
-	r.repo.FindAll(ctx, records, queriers...)
+	r.repo.FindAll(ctx, records, withoutPreload(queriers)...)
 	return r.mock.Called(fetchContext(ctx), records, queriers).Error(0)
 }
 
@@ -118,7 +183,7 @@ func (r *Repository) ExpectFindAll(queriers ...rel.Querier) *FindAll {
 
 // FindAndCountAll provides a mock function with given fields: records, queriers
 func (r *Repository) FindAndCountAll(ctx context.Context, records interface{}, queriers ...rel.Querier) (int, error) {
-	r.repo.FindAndCountAll(ctx, records, queriers...)
+	r.repo.FindAndCountAll(ctx, records, withoutPreload(queriers)...)
 	ret := r.mock.Called(fetchContext(ctx), records, queriers)
 	return ret.Int(0), ret.Error(1)
 }
@@ -154,21 +219,39 @@ func (r *Repository) ExpectInsert(mutators ...rel.Mutator) *Mutate {
 }
 
 // InsertAll records.
-func (r *Repository) InsertAll(ctx context.Context, records interface{}) error {
-	ret := r.mock.Called(fetchContext(ctx), records)
+func (r *Repository) InsertAll(ctx context.Context, records interface{}, mutators ...rel.Mutator) error {
+	ret := r.mock.Called(fetchContext(ctx), records, mutators)
 
-	r.repo.InsertAll(ctx, records)
+	r.repo.InsertAll(ctx, records, mutators...)
 	return ret.Error(0)
 }
 
 // MustInsertAll records.
-func (r *Repository) MustInsertAll(ctx context.Context, records interface{}) {
-	must(r.InsertAll(ctx, records))
+func (r *Repository) MustInsertAll(ctx context.Context, records interface{}, mutators ...rel.Mutator) {
+	must(r.InsertAll(ctx, records, mutators...))
 }
 
 // ExpectInsertAll records.
-func (r *Repository) ExpectInsertAll() *Mutate {
-	return ExpectInsertAll(r)
+func (r *Repository) ExpectInsertAll(mutators ...rel.Mutator) *Mutate {
+	return ExpectInsertAll(r, mutators)
+}
+
+// Upsert provides a mock function with given fields: record, mutators
+func (r *Repository) Upsert(ctx context.Context, record interface{}, mutators ...rel.Mutator) error {
+	ret := r.mock.Called(fetchContext(ctx), record, mutators)
+
+	r.repo.Upsert(ctx, record, mutators...)
+	return ret.Error(0)
+}
+
+// MustUpsert provides a mock function with given fields: record, mutators
+func (r *Repository) MustUpsert(ctx context.Context, record interface{}, mutators ...rel.Mutator) {
+	must(r.Upsert(ctx, record, mutators...))
+}
+
+// ExpectUpsert apply mocks and expectations for Upsert
+func (r *Repository) ExpectUpsert(mutators ...rel.Mutator) *Mutate {
+	return ExpectUpsert(r, mutators)
 }
 
 // Update provides a mock function with given fields: record, mutators
@@ -208,17 +291,17 @@ func (r *Repository) ExpectUpdateAll(query rel.Query, mutates ...rel.Mutate) *Mu
 }
 
 // Delete provides a mock function with given fields: record
-func (r *Repository) Delete(ctx context.Context, record interface{}, options ...rel.Cascade) error {
+func (r *Repository) Delete(ctx context.Context, record interface{}, options ...rel.DeleteOption) error {
 	return r.mock.Called(fetchContext(ctx), record, options).Error(0)
 }
 
 // MustDelete provides a mock function with given fields: record
-func (r *Repository) MustDelete(ctx context.Context, record interface{}, options ...rel.Cascade) {
+func (r *Repository) MustDelete(ctx context.Context, record interface{}, options ...rel.DeleteOption) {
 	must(r.Delete(ctx, record, options...))
 }
 
 // ExpectDelete apply mocks and expectations for Delete
-func (r *Repository) ExpectDelete(options ...rel.Cascade) *Delete {
+func (r *Repository) ExpectDelete(options ...rel.DeleteOption) *Delete {
 	return ExpectDelete(r, options)
 }
 
@@ -306,3 +389,24 @@ func must(err error) {
 		panic(err)
 	}
 }
+
+// withoutPreload removes preload queries, nop adapter doesn't return any association to be preloaded.
+func withoutPreload(queriers []rel.Querier) []rel.Querier {
+	var (
+		result = make([]rel.Querier, 0, len(queriers))
+	)
+
+	for _, querier := range queriers {
+		switch q := querier.(type) {
+		case rel.PreloadQuery:
+			continue
+		case rel.Query:
+			q.PreloadQuery = nil
+			querier = q
+		}
+
+		result = append(result, querier)
+	}
+
+	return result
+}
@@ -39,6 +39,12 @@ type Book struct {
 	Views    int
 }
 
+type Genre struct {
+	ID    int
+	Name  string
+	Books []Book `through:"book_genres"`
+}
+
 func TestRepository_Adapter(t *testing.T) {
 	var (
 		ctx  = context.TODO()
@@ -54,6 +60,12 @@ func TestRepository_Instrumentation(t *testing.T) {
 	})
 }
 
+func TestRepository_Tenant(t *testing.T) {
+	assert.NotPanics(t, func() {
+		New().Tenant("tenant_id")
+	})
+}
+
 func TestRepository_Ping(t *testing.T) {
 	assert.Nil(t, New().Ping(context.TODO()))
 }
@@ -0,0 +1,122 @@
+package rel
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// ReplicaSelector selects replica to be used for read operations.
+// It receives moving average of query latency of each replica, zero latency means the replica haven't been used.
+type ReplicaSelector interface {
+	Select(latencies []time.Duration) int
+}
+
+type roundRobin struct {
+	next uint32
+}
+
+func (rr *roundRobin) Select(latencies []time.Duration) int {
+	return int((atomic.AddUint32(&rr.next, 1) - 1) % uint32(len(latencies)))
+}
+
+// RoundRobin selects replicas in turn.
+func RoundRobin() ReplicaSelector {
+	return &roundRobin{}
+}
+
+type leastLatency struct{}
+
+func (leastLatency) Select(latencies []time.Duration) int {
+	selected := 0
+	for i := range latencies {
+		if latencies[i] < latencies[selected] {
+			selected = i
+		}
+	}
+
+	return selected
+}
+
+// LeastLatency selects replica with the lowest average query latency.
+// Replica that haven't been used will be selected first.
+func LeastLatency() ReplicaSelector {
+	return leastLatency{}
+}
+
+// replica wraps adapter to measure latency of read operations.
+type replica struct {
+	Adapter
+	latency int64
+}
+
+func (r *replica) Query(ctx context.Context, query Query) (Cursor, error) {
+	start := time.Now()
+	defer r.observe(start)
+
+	return r.Adapter.Query(ctx, query)
+}
+
+func (r *replica) Aggregate(ctx context.Context, query Query, aggregate string, field string) (int, error) {
+	start := time.Now()
+	defer r.observe(start)
+
+	return r.Adapter.Aggregate(ctx, query, aggregate, field)
+}
+
+// observe updates exponential moving average of the latency.
+func (r *replica) observe(start time.Time) {
+	var (
+		duration = int64(time.Since(start))
+		latency  = atomic.LoadInt64(&r.latency)
+	)
+
+	// avoid zero latency, since it's used to mark unused replica.
+	if duration <= 0 {
+		duration = 1
+	}
+
+	if latency != 0 {
+		duration = (latency*7 + duration) / 8
+	}
+
+	atomic.StoreInt64(&r.latency, duration)
+}
+
+type replicas struct {
+	adapters []*replica
+	selector ReplicaSelector
+}
+
+func (rs replicas) adapter() Adapter {
+	var (
+		latencies = make([]time.Duration, len(rs.adapters))
+	)
+
+	for i := range rs.adapters {
+		latencies[i] = time.Duration(atomic.LoadInt64(&rs.adapters[i].latency))
+	}
+
+	return rs.adapters[rs.selector.Select(latencies)]
+}
+
+func newReplicas(selector ReplicaSelector, adapters []Adapter) *replicas {
+	if len(adapters) == 0 {
+		return nil
+	}
+
+	if selector == nil {
+		selector = RoundRobin()
+	}
+
+	rs := &replicas{
+		adapters: make([]*replica, len(adapters)),
+		selector: selector,
+	}
+
+	for i := range adapters {
+		rs.adapters[i] = &replica{Adapter: adapters[i]}
+	}
+
+	return rs
+}
@@ -0,0 +1,153 @@
+package rel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundRobin(t *testing.T) {
+	var (
+		selector  = RoundRobin()
+		latencies = make([]time.Duration, 3)
+	)
+
+	assert.Equal(t, 0, selector.Select(latencies))
+	assert.Equal(t, 1, selector.Select(latencies))
+	assert.Equal(t, 2, selector.Select(latencies))
+	assert.Equal(t, 0, selector.Select(latencies))
+}
+
+func TestLeastLatency(t *testing.T) {
+	var (
+		selector = LeastLatency()
+	)
+
+	assert.Equal(t, 1, selector.Select([]time.Duration{2, 1, 3}))
+	assert.Equal(t, 2, selector.Select([]time.Duration{2, 1, 0}))
+	assert.Equal(t, 0, selector.Select([]time.Duration{1, 1, 1}))
+}
+
+func TestReplica_observe(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		replica = &replica{Adapter: adapter}
+		query   = From("users")
+	)
+
+	adapter.On("Query", query).Return(&testCursor{}, nil).Once()
+	adapter.On("Aggregate", query, "count", "*").Return(1, nil).Once()
+
+	_, err := replica.Query(context.TODO(), query)
+	assert.Nil(t, err)
+	assert.NotZero(t, replica.latency)
+
+	replica.latency = int64(8 * time.Second)
+	_, err = replica.Aggregate(context.TODO(), query, "count", "*")
+	assert.Nil(t, err)
+	assert.Less(t, replica.latency, int64(8*time.Second))
+	assert.Greater(t, replica.latency, int64(7*time.Second))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestReplicas_adapter(t *testing.T) {
+	var (
+		adapters = []Adapter{&testAdapter{}, &testAdapter{}}
+		rs       = newReplicas(LeastLatency(), adapters)
+	)
+
+	assert.Nil(t, newReplicas(RoundRobin(), nil))
+
+	rs.adapters[0].latency = 10
+	rs.adapters[1].latency = 5
+	assert.Equal(t, rs.adapters[1], rs.adapter())
+
+	rs.adapters[0].latency = 1
+	assert.Equal(t, rs.adapters[0], rs.adapter())
+}
+
+func TestReplicas_defaultSelector(t *testing.T) {
+	var (
+		adapters = []Adapter{&testAdapter{}, &testAdapter{}}
+		rs       = newReplicas(nil, adapters)
+	)
+
+	assert.Equal(t, rs.adapters[0], rs.adapter())
+	assert.Equal(t, rs.adapters[1], rs.adapter())
+}
+
+func TestRepository_replicas(t *testing.T) {
+	var (
+		user    User
+		primary = &testAdapter{}
+		replica = &testAdapter{}
+		repo    = NewWithReplicas(primary, replica)
+		query   = From("users").Limit(1)
+	)
+
+	t.Run("read from replica", func(t *testing.T) {
+		cur := createCursor(1)
+		replica.On("Query", query).Return(cur, nil).Once()
+
+		assert.Nil(t, repo.Find(context.TODO(), &user, query))
+		assert.False(t, cur.Next())
+
+		replica.On("Aggregate", From("users"), "count", "*").Return(1, nil).Once()
+
+		count, err := repo.Count(context.TODO(), "users")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("force primary", func(t *testing.T) {
+		cur := createCursor(1)
+		primary.On("Query", query).Return(cur, nil).Once()
+
+		assert.Nil(t, repo.Find(UsePrimary(context.TODO()), &user, query))
+		assert.False(t, cur.Next())
+	})
+
+	t.Run("write to primary", func(t *testing.T) {
+		primary.On("Delete", From("users").Where(Eq("id", 10))).Return(1, nil).Once()
+
+		assert.Nil(t, repo.Delete(context.TODO(), &user))
+	})
+
+	t.Run("transaction uses primary", func(t *testing.T) {
+		cur := createCursor(1)
+		primary.On("Begin").Return(nil).Once()
+		primary.On("Query", query).Return(cur, nil).Once()
+		primary.On("Commit").Return(nil).Once()
+
+		assert.Nil(t, repo.Transaction(context.TODO(), func(ctx context.Context) error {
+			return repo.Find(ctx, &user, query)
+		}))
+		assert.False(t, cur.Next())
+	})
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
+
+func TestRepository_replicasPing(t *testing.T) {
+	var (
+		primary = &testAdapter{}
+		replica = &testAdapter{}
+		repo    = NewWithReplicaSelector(primary, LeastLatency(), replica)
+		err     = errors.New("error")
+	)
+
+	primary.On("Ping").Return(nil).Twice()
+	replica.On("Ping").Return(nil).Once()
+	replica.On("Ping").Return(err).Once()
+
+	assert.Nil(t, repo.Ping(context.TODO()))
+	assert.Equal(t, err, repo.Ping(context.TODO()))
+
+	primary.AssertExpectations(t)
+	replica.AssertExpectations(t)
+}
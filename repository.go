@@ -2,7 +2,10 @@ package rel
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"math/big"
 	"reflect"
 	"runtime"
 	"strings"
@@ -12,10 +15,18 @@ import (
 type Repository interface {
 	Adapter(ctx context.Context) Adapter
 	Instrumentation(instrumenter Instrumenter)
+	Tenant(field string, tables ...string)
+	DefaultScope(records ...interface{})
 	Ping(ctx context.Context) error
 	Iterate(ctx context.Context, query Query, option ...IteratorOption) Iterator
 	Aggregate(ctx context.Context, query Query, aggregate string, field string) (int, error)
 	MustAggregate(ctx context.Context, query Query, aggregate string, field string) int
+	AggregateFloat(ctx context.Context, query Query, aggregate string, field string) (float64, error)
+	MustAggregateFloat(ctx context.Context, query Query, aggregate string, field string) float64
+	AggregateDecimal(ctx context.Context, query Query, aggregate string, field string) (*big.Rat, error)
+	MustAggregateDecimal(ctx context.Context, query Query, aggregate string, field string) *big.Rat
+	AggregateGroup(ctx context.Context, records interface{}, query Query, aggregates ...SelectExpr) error
+	MustAggregateGroup(ctx context.Context, records interface{}, query Query, aggregates ...SelectExpr)
 	Count(ctx context.Context, collection string, queriers ...Querier) (int, error)
 	MustCount(ctx context.Context, collection string, queriers ...Querier) int
 	Find(ctx context.Context, record interface{}, queriers ...Querier) error
@@ -26,14 +37,16 @@ type Repository interface {
 	MustFindAndCountAll(ctx context.Context, records interface{}, queriers ...Querier) int
 	Insert(ctx context.Context, record interface{}, mutators ...Mutator) error
 	MustInsert(ctx context.Context, record interface{}, mutators ...Mutator)
-	InsertAll(ctx context.Context, records interface{}) error
-	MustInsertAll(ctx context.Context, records interface{})
+	InsertAll(ctx context.Context, records interface{}, mutators ...Mutator) error
+	MustInsertAll(ctx context.Context, records interface{}, mutators ...Mutator)
+	Upsert(ctx context.Context, record interface{}, mutators ...Mutator) error
+	MustUpsert(ctx context.Context, record interface{}, mutators ...Mutator)
 	Update(ctx context.Context, record interface{}, mutators ...Mutator) error
 	MustUpdate(ctx context.Context, record interface{}, mutators ...Mutator)
 	UpdateAll(ctx context.Context, query Query, mutates ...Mutate) error
 	MustUpdateAll(ctx context.Context, query Query, mutates ...Mutate)
-	Delete(ctx context.Context, record interface{}, options ...Cascade) error
-	MustDelete(ctx context.Context, record interface{}, options ...Cascade)
+	Delete(ctx context.Context, record interface{}, options ...DeleteOption) error
+	MustDelete(ctx context.Context, record interface{}, options ...DeleteOption)
 	DeleteAll(ctx context.Context, query Query) error
 	MustDeleteAll(ctx context.Context, query Query)
 	Preload(ctx context.Context, records interface{}, field string, queriers ...Querier) error
@@ -43,7 +56,11 @@ type Repository interface {
 
 type repository struct {
 	rootAdapter  Adapter
+	replicas     *replicas
 	instrumenter Instrumenter
+	tenantField  string
+	tenantTables map[string]bool
+	scopes       map[string]FilterQuery
 }
 
 func (r repository) Adapter(ctx context.Context) Adapter {
@@ -53,11 +70,60 @@ func (r repository) Adapter(ctx context.Context) Adapter {
 func (r *repository) Instrumentation(instrumenter Instrumenter) {
 	r.instrumenter = instrumenter
 	r.rootAdapter.Instrumentation(instrumenter)
+
+	if r.replicas != nil {
+		for _, replica := range r.replicas.adapters {
+			replica.Instrumentation(instrumenter)
+		}
+	}
+}
+
+// Tenant registers field that is used to scope operations by tenant value stored in the context using WithTenant.
+// Queries will be filtered by the tenant, and the field will be set on insert.
+// Operations that don't work on a struct are scoped only on the given tables, or every table if none is given.
+// Use Unscoped to bypass it.
+func (r *repository) Tenant(field string, tables ...string) {
+	r.tenantField = field
+	r.tenantTables = nil
+
+	if len(tables) > 0 {
+		r.tenantTables = make(map[string]bool, len(tables))
+		for _, table := range tables {
+			r.tenantTables[table] = true
+		}
+	}
+}
+
+// DefaultScope registers default scope of the records to be applied on Count and Aggregate of their tables,
+// since those operations only receive the table name.
+func (r *repository) DefaultScope(records ...interface{}) {
+	if r.scopes == nil {
+		r.scopes = make(map[string]FilterQuery, len(records))
+	}
+
+	for _, record := range records {
+		doc := NewDocument(record, true)
+		if doc.data.flag.Is(HasDefaultScope) {
+			r.scopes[doc.Table()] = doc.data.defaultScope
+		}
+	}
 }
 
-// Ping database.
+// Ping database, including every replicas.
 func (r *repository) Ping(ctx context.Context) error {
-	return r.rootAdapter.Ping(ctx)
+	if err := r.rootAdapter.Ping(ctx); err != nil {
+		return err
+	}
+
+	if r.replicas != nil {
+		for _, replica := range r.replicas.adapters {
+			if err := replica.Ping(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 // Iterate through a collection of records from database in batches.
@@ -65,9 +131,13 @@ func (r *repository) Ping(ctx context.Context) error {
 // Limit, Offset and Sort query is automatically ignored.
 func (r repository) Iterate(ctx context.Context, query Query, options ...IteratorOption) Iterator {
 	var (
-		cw = fetchContext(ctx, r.rootAdapter)
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
 	)
 
+	options = append(options, iteratorScope(func(ddata documentData, query Query) (Query, error) {
+		return r.withTenant(cw.ctx, ddata, query)
+	}))
+
 	return newIterator(cw.ctx, cw.adapter, query, options)
 }
 
@@ -80,9 +150,14 @@ func (r repository) Aggregate(ctx context.Context, query Query, aggregate string
 	defer finish(nil)
 
 	var (
-		cw = fetchContext(ctx, r.rootAdapter)
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
 	)
 
+	query, err := r.withTableScope(cw.ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
 	return r.aggregate(cw, query, aggregate, field)
 }
 
@@ -91,6 +166,8 @@ func (r repository) aggregate(cw contextWrapper, query Query, aggregate string,
 	query.LimitQuery = 0
 	query.OffsetQuery = 0
 	query.SortQuery = nil
+	query.PreloadQuery = nil
+	query.PartitionQuery = PartitionQuery{}
 
 	return cw.adapter.Aggregate(cw.ctx, query, aggregate, field)
 }
@@ -103,16 +180,220 @@ func (r repository) MustAggregate(ctx context.Context, query Query, aggregate st
 	return result
 }
 
+// AggregateFloat calculate aggregate over the given field and returns the result as float.
+// It's useful for aggregate that may returns fraction, such as avg or sum over decimal field.
+// Any select, group, offset, limit and sort query will be ignored automatically.
+func (r repository) AggregateFloat(ctx context.Context, query Query, aggregate string, field string) (float64, error) {
+	finish := r.instrumenter.Observe(ctx, "rel-aggregate-float", "aggregating records")
+	defer finish(nil)
+
+	var (
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
+	)
+
+	query, err := r.withTableScope(cw.ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.aggregateFloat(cw, query, aggregate, field)
+}
+
+func (r repository) aggregateFloat(cw contextWrapper, query Query, aggregate string, field string) (float64, error) {
+	result, err := r.aggregateDecimal(cw, query, aggregate, field)
+	if err != nil {
+		return 0, err
+	}
+
+	value, _ := result.Float64()
+	return value, nil
+}
+
+// aggregateDecimal reads the aggregate result as string to keep the precision of decimal.
+// results of multiple rows returned by adapter that queries multiple databases, such as sharding, are combined.
+func (r repository) aggregateDecimal(cw contextWrapper, query Query, aggregate string, field string) (*big.Rat, error) {
+	query.SelectQuery = SelectQuery{Exprs: []SelectExpr{NewSelectAggregate(aggregate, field).As(aggregate)}}
+	query.GroupQuery = GroupQuery{}
+	query.LimitQuery = 0
+	query.OffsetQuery = 0
+	query.SortQuery = nil
+	query.PreloadQuery = nil
+	query.PartitionQuery = PartitionQuery{}
+
+	cur, err := cw.adapter.Query(cw.ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cur.Close()
+
+	var (
+		result *big.Rat
+	)
+
+	for cur.Next() {
+		var (
+			str sql.NullString
+		)
+
+		if err := cur.Scan(&str); err != nil {
+			return nil, err
+		}
+
+		// aggregate of empty rows is null.
+		if !str.Valid {
+			continue
+		}
+
+		value, ok := new(big.Rat).SetString(str.String)
+		if !ok {
+			return nil, fmt.Errorf("rel: invalid %s aggregate result: %s", aggregate, str.String)
+		}
+
+		switch {
+		case result == nil:
+			result = value
+		case aggregate == "count", aggregate == "sum":
+			result.Add(result, value)
+		case aggregate == "max" && value.Cmp(result) > 0, aggregate == "min" && value.Cmp(result) < 0:
+			result = value
+		case aggregate != "max" && aggregate != "min":
+			return nil, fmt.Errorf("rel: unable to combine %s aggregate of multiple rows", aggregate)
+		}
+	}
+
+	if result == nil {
+		result = new(big.Rat)
+	}
+
+	return result, nil
+}
+
+// MustAggregateFloat calculate aggregate over the given field and returns the result as float.
+// It'll panic if any error eccured.
+func (r repository) MustAggregateFloat(ctx context.Context, query Query, aggregate string, field string) float64 {
+	result, err := r.AggregateFloat(ctx, query, aggregate, field)
+	must(err)
+	return result
+}
+
+// AggregateDecimal calculate aggregate over the given field and returns the result as exact rational number.
+// It's useful to aggregate decimal field without losing its precision.
+// Any select, group, offset, limit and sort query will be ignored automatically.
+func (r repository) AggregateDecimal(ctx context.Context, query Query, aggregate string, field string) (*big.Rat, error) {
+	finish := r.instrumenter.Observe(ctx, "rel-aggregate-decimal", "aggregating records")
+	defer finish(nil)
+
+	var (
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
+	)
+
+	query, err := r.withTableScope(cw.ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.aggregateDecimal(cw, query, aggregate, field)
+}
+
+// MustAggregateDecimal calculate aggregate over the given field and returns the result as exact rational number.
+// It'll panic if any error eccured.
+func (r repository) MustAggregateDecimal(ctx context.Context, query Query, aggregate string, field string) *big.Rat {
+	result, err := r.AggregateDecimal(ctx, query, aggregate, field)
+	must(err)
+	return result
+}
+
+// AggregateGroup calculate multiple aggregates for each group of the query, and scans the result into records.
+// Records can be a pointer to a slice of struct, which field is matched using the group field and the alias of aggregates,
+// or a pointer to a slice of map[string]interface{}.
+// Aggregates without alias are aliased using the function and field name, for example sum_total and count.
+func (r repository) AggregateGroup(ctx context.Context, records interface{}, query Query, aggregates ...SelectExpr) error {
+	finish := r.instrumenter.Observe(ctx, "rel-aggregate-group", "aggregating records by group")
+	defer finish(nil)
+
+	var (
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
+	)
+
+	query, err := r.withTableScope(cw.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return r.aggregateGroup(cw, records, query, aggregates)
+}
+
+func (r repository) aggregateGroup(cw contextWrapper, records interface{}, query Query, aggregates []SelectExpr) error {
+	var (
+		exprs = make([]SelectExpr, len(aggregates))
+	)
+
+	for i := range aggregates {
+		exprs[i] = aggregates[i]
+		if exprs[i].Alias == "" {
+			exprs[i].Alias = aggregateAlias(exprs[i])
+		}
+	}
+
+	query.SelectQuery = SelectQuery{Fields: query.GroupQuery.Fields, Exprs: exprs}
+	query.PreloadQuery = nil
+	query.PartitionQuery = PartitionQuery{}
+
+	if rows, ok := records.(*[]map[string]interface{}); ok {
+		*rows = nil
+
+		cur, err := cw.adapter.Query(cw.ctx, query)
+		if err != nil {
+			return err
+		}
+
+		return scanMaps(cur, rows)
+	}
+
+	var (
+		col = NewCollection(records)
+	)
+
+	col.Reset()
+
+	cur, err := cw.adapter.Query(cw.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return scanAll(cur, col)
+}
+
+// MustAggregateGroup calculate multiple aggregates for each group of the query, and scans the result into records.
+// It'll panic if any error eccured.
+func (r repository) MustAggregateGroup(ctx context.Context, records interface{}, query Query, aggregates ...SelectExpr) {
+	must(r.AggregateGroup(ctx, records, query, aggregates...))
+}
+
+func aggregateAlias(expr SelectExpr) string {
+	if expr.Field == "" || expr.Field == "*" {
+		return expr.Function
+	}
+
+	return expr.Function + "_" + strings.ReplaceAll(expr.Field, ".", "_")
+}
+
 // Count retrieves count of results that match the query.
 func (r repository) Count(ctx context.Context, collection string, queriers ...Querier) (int, error) {
 	finish := r.instrumenter.Observe(ctx, "rel-count", "aggregating records")
 	defer finish(nil)
 
 	var (
-		cw = fetchContext(ctx, r.rootAdapter)
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
 	)
 
-	return r.aggregate(cw, Build(collection, queriers...), "count", "*")
+	query, err := r.withTableScope(cw.ctx, Build(collection, queriers...))
+	if err != nil {
+		return 0, err
+	}
+
+	return r.aggregate(cw, query, "count", "*")
 }
 
 // MustCount retrieves count of results that match the query.
@@ -130,7 +411,7 @@ func (r repository) Find(ctx context.Context, record interface{}, queriers ...Qu
 	defer finish(nil)
 
 	var (
-		cw    = fetchContext(ctx, r.rootAdapter)
+		cw    = fetchReadContext(ctx, r.rootAdapter, r.replicas)
 		doc   = NewDocument(record)
 		query = Build(doc.Table(), queriers...)
 	)
@@ -145,7 +426,17 @@ func (r repository) MustFind(ctx context.Context, record interface{}, queriers .
 }
 
 func (r repository) find(cw contextWrapper, doc *Document, query Query) error {
-	query = r.withDefaultScope(doc.data, query)
+	var (
+		joins, preloads = r.splitPreloads(doc.rt, query.PreloadQuery)
+	)
+
+	query.PreloadQuery = nil
+	query, err := r.withDefaultScope(cw.ctx, doc.data, query)
+	if err != nil {
+		return err
+	}
+
+	query = joinPreloads(query, doc.rt, joins)
 	cur, err := cw.adapter.Query(cw.ctx, query.Limit(1))
 	if err != nil {
 		return err
@@ -154,7 +445,15 @@ func (r repository) find(cw contextWrapper, doc *Document, query Query) error {
 	finish := r.instrumenter.Observe(cw.ctx, "rel-scan-one", "scanning a record")
 	defer finish(nil)
 
-	return scanOne(cur, doc)
+	if err := scanOne(cur, doc, joins...); err != nil {
+		return err
+	}
+
+	if err := r.preloadAll(cw, doc, preloads); err != nil {
+		return err
+	}
+
+	return afterFind(cw.ctx, doc)
 }
 
 // FindAll records that match the query.
@@ -163,7 +462,7 @@ func (r repository) FindAll(ctx context.Context, records interface{}, queriers .
 	defer finish(nil)
 
 	var (
-		cw    = fetchContext(ctx, r.rootAdapter)
+		cw    = fetchReadContext(ctx, r.rootAdapter, r.replicas)
 		col   = NewCollection(records)
 		query = Build(col.Table(), queriers...)
 	)
@@ -180,7 +479,17 @@ func (r repository) MustFindAll(ctx context.Context, records interface{}, querie
 }
 
 func (r repository) findAll(cw contextWrapper, col *Collection, query Query) error {
-	query = r.withDefaultScope(col.data, query)
+	var (
+		joins, preloads = r.splitPreloads(col.rt.Elem(), query.PreloadQuery)
+	)
+
+	query.PreloadQuery = nil
+	query, err := r.withDefaultScope(cw.ctx, col.data, query)
+	if err != nil {
+		return err
+	}
+
+	query = joinPreloads(query, col.rt.Elem(), joins)
 	cur, err := cw.adapter.Query(cw.ctx, query)
 	if err != nil {
 		return err
@@ -189,7 +498,15 @@ func (r repository) findAll(cw contextWrapper, col *Collection, query Query) err
 	finish := r.instrumenter.Observe(cw.ctx, "rel-scan-all", "scanning all records")
 	defer finish(nil)
 
-	return scanAll(cur, col)
+	if err := scanAll(cur, col, joins...); err != nil {
+		return err
+	}
+
+	if err := r.preloadAll(cw, col, preloads); err != nil {
+		return err
+	}
+
+	return afterFindAll(cw.ctx, col)
 }
 
 // FindAndCountAll is convenient method that combines FindAll and Count. It's useful when dealing with queries related to pagination.
@@ -199,7 +516,7 @@ func (r repository) FindAndCountAll(ctx context.Context, records interface{}, qu
 	defer finish(nil)
 
 	var (
-		cw    = fetchContext(ctx, r.rootAdapter)
+		cw    = fetchReadContext(ctx, r.rootAdapter, r.replicas)
 		col   = NewCollection(records)
 		query = Build(col.Table(), queriers...)
 	)
@@ -210,6 +527,11 @@ func (r repository) FindAndCountAll(ctx context.Context, records interface{}, qu
 		return 0, err
 	}
 
+	query, err := r.withScope(cw.ctx, col.data, query)
+	if err != nil {
+		return 0, err
+	}
+
 	return r.aggregate(cw, query, "count", "*")
 }
 
@@ -238,7 +560,7 @@ func (r repository) Insert(ctx context.Context, record interface{}, mutators ...
 		mutation = Apply(doc, mutators...)
 	)
 
-	if !mutation.IsAssocEmpty() && mutation.Cascade == true {
+	if (!mutation.IsAssocEmpty() && mutation.Cascade == true) || hasHook(doc.v) {
 		return r.transaction(cw, func(cw contextWrapper) error {
 			return r.insert(cw, doc, mutation)
 		})
@@ -254,6 +576,14 @@ func (r repository) insert(cw contextWrapper, doc *Document, mutation Mutation)
 		queriers = Build(doc.Table())
 	)
 
+	if err := beforeInsert(cw.ctx, doc, &mutation); err != nil {
+		return err
+	}
+
+	if err := r.setTenant(cw.ctx, doc, &mutation); err != nil {
+		return err
+	}
+
 	if mutation.Cascade {
 		if err := r.saveBelongsTo(cw, doc, &mutation); err != nil {
 			return err
@@ -264,14 +594,20 @@ func (r repository) insert(cw contextWrapper, doc *Document, mutation Mutation)
 		pField = pFields[0]
 	}
 
-	pValue, err := cw.adapter.Insert(cw.ctx, queriers, pField, mutation.Mutates)
+	if mutation.OnConflict.Replace && len(mutation.OnConflict.Keys) == 0 {
+		mutation.OnConflict.Keys = pFields
+	}
+
+	pValue, err := cw.adapter.Insert(cw.ctx, queriers, pField, mutation.Mutates, mutation.OnConflict)
 	if err != nil {
 		return mutation.ErrorFunc.transform(err)
 	}
 
-	// update primary value
+	// update primary value, conflicting insertion may not return any value, keep the existing one.
 	if pField != "" {
-		doc.SetValue(pField, pValue)
+		if conflict := mutation.OnConflict.Ignore || mutation.OnConflict.Replace; !conflict || (!isZero(pValue) && isZero(doc.PrimaryValue())) {
+			doc.SetValue(pField, pValue)
+		}
 	}
 
 	if mutation.Reload {
@@ -293,9 +629,13 @@ func (r repository) insert(cw contextWrapper, doc *Document, mutation Mutation)
 		if err := r.saveHasMany(cw, doc, &mutation, true); err != nil {
 			return err
 		}
+
+		if err := r.saveManyToMany(cw, doc, &mutation, true); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return afterInsert(cw.ctx, doc)
 }
 
 // MustInsert an record to database.
@@ -304,7 +644,9 @@ func (r repository) MustInsert(ctx context.Context, record interface{}, mutators
 	must(r.Insert(ctx, record, mutators...))
 }
 
-func (r repository) InsertAll(ctx context.Context, records interface{}) error {
+// InsertAll records.
+// Records are always inserted using structset, additional mutators such as OnConflict and ErrorFunc can be passed as options.
+func (r repository) InsertAll(ctx context.Context, records interface{}, mutators ...Mutator) error {
 	finish := r.instrumenter.Observe(ctx, "rel-insert-all", "inserting multiple records")
 	defer finish(nil)
 
@@ -320,14 +662,22 @@ func (r repository) InsertAll(ctx context.Context, records interface{}) error {
 
 	for i := range muts {
 		doc := col.Get(i)
-		muts[i] = Apply(doc, newStructset(doc, false))
+		muts[i] = Apply(doc, append(mutators, newStructset(doc, false))...)
+	}
+
+	if col.Len() > 0 && hasHook(col.Get(0).v) {
+		return r.transaction(cw, func(cw contextWrapper) error {
+			return r.insertAll(cw, col, muts)
+		})
 	}
 
 	return r.insertAll(cw, col, muts)
 }
 
-func (r repository) MustInsertAll(ctx context.Context, records interface{}) {
-	must(r.InsertAll(ctx, records))
+// MustInsertAll records.
+// It'll panic if any error occurred.
+func (r repository) MustInsertAll(ctx context.Context, records interface{}, mutators ...Mutator) {
+	must(r.InsertAll(ctx, records, mutators...))
 }
 
 // TODO: support assocs
@@ -343,8 +693,19 @@ func (r repository) insertAll(cw contextWrapper, col *Collection, mutation []Mut
 		fields      = make([]string, 0, len(mutation[0].Mutates))
 		fieldMap    = make(map[string]struct{}, len(mutation[0].Mutates))
 		bulkMutates = make([]map[string]Mutate, len(mutation))
+		onConflict  = mutation[0].OnConflict
 	)
 
+	for i := range mutation {
+		if err := beforeInsert(cw.ctx, col.Get(i), &mutation[i]); err != nil {
+			return err
+		}
+
+		if err := r.setTenant(cw.ctx, col.Get(i), &mutation[i]); err != nil {
+			return err
+		}
+	}
+
 	// TODO: baypassable if it's predictable.
 	for i := range mutation {
 		for field := range mutation[i].Mutates {
@@ -360,21 +721,75 @@ func (r repository) insertAll(cw contextWrapper, col *Collection, mutation []Mut
 		pField = pFields[0]
 	}
 
-	ids, err := cw.adapter.InsertAll(cw.ctx, queriers, pField, fields, bulkMutates)
+	if onConflict.Replace && len(onConflict.Keys) == 0 {
+		onConflict.Keys = pFields
+	}
+
+	ids, err := cw.adapter.InsertAll(cw.ctx, queriers, pField, fields, bulkMutates, onConflict)
 	if err != nil {
 		return mutation[0].ErrorFunc.transform(err)
 	}
 
-	// apply ids
+	// apply ids, conflicting insertion may skip some records, keep the existing primary values.
 	if pField != "" {
-		for i, id := range ids {
-			col.Get(i).SetValue(pField, id)
+		if conflict := onConflict.Ignore || onConflict.Replace; !conflict {
+			for i, id := range ids {
+				col.Get(i).SetValue(pField, id)
+			}
+		} else if len(ids) == col.Len() {
+			for i, id := range ids {
+				if doc := col.Get(i); isZero(doc.PrimaryValue()) {
+					doc.SetValue(pField, id)
+				}
+			}
+		}
+	}
+
+	for i := range mutation {
+		if err := afterInsert(cw.ctx, col.Get(i)); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// Upsert a record to database, existing record will be updated when insertion conflicts.
+// By default, all inserted fields will be replaced when it conflicts on primary key,
+// use OnConflict mutator to customize the conflict target and the replaced fields.
+func (r repository) Upsert(ctx context.Context, record interface{}, mutators ...Mutator) error {
+	finish := r.instrumenter.Observe(ctx, "rel-upsert", "upserting a record")
+	defer finish(nil)
+
+	if record == nil {
+		return nil
+	}
+
+	var (
+		cw       = fetchContext(ctx, r.rootAdapter)
+		doc      = NewDocument(record)
+		mutation = Apply(doc, mutators...)
+	)
+
+	if !mutation.OnConflict.Ignore && !mutation.OnConflict.Replace {
+		mutation.OnConflict.Replace = true
+	}
+
+	if (!mutation.IsAssocEmpty() && mutation.Cascade == true) || hasHook(doc.v) {
+		return r.transaction(cw, func(cw contextWrapper) error {
+			return r.insert(cw, doc, mutation)
+		})
+	}
+
+	return r.insert(cw, doc, mutation)
+}
+
+// MustUpsert a record to database.
+// It'll panic if any error occurred.
+func (r repository) MustUpsert(ctx context.Context, record interface{}, mutators ...Mutator) {
+	must(r.Upsert(ctx, record, mutators...))
+}
+
 // Update an record in database.
 // It'll panic if any error occurred.
 func (r repository) Update(ctx context.Context, record interface{}, mutators ...Mutator) error {
@@ -392,7 +807,7 @@ func (r repository) Update(ctx context.Context, record interface{}, mutators ...
 		mutation = Apply(doc, mutators...)
 	)
 
-	if !mutation.IsAssocEmpty() && mutation.Cascade == true {
+	if (!mutation.IsAssocEmpty() && mutation.Cascade == true) || hasHook(doc.v) {
 		return r.transaction(cw, func(cw contextWrapper) error {
 			return r.update(cw, doc, mutation, filter)
 		})
@@ -402,6 +817,10 @@ func (r repository) Update(ctx context.Context, record interface{}, mutators ...
 }
 
 func (r repository) update(cw contextWrapper, doc *Document, mutation Mutation, filter FilterQuery) error {
+	if err := beforeUpdate(cw.ctx, doc, &mutation); err != nil {
+		return err
+	}
+
 	if mutation.Cascade {
 		if err := r.saveBelongsTo(cw, doc, &mutation); err != nil {
 			return err
@@ -409,16 +828,41 @@ func (r repository) update(cw contextWrapper, doc *Document, mutation Mutation,
 	}
 
 	if !mutation.IsMutatesEmpty() {
+		query, err := r.withDefaultScope(cw.ctx, doc.data, Build(doc.Table(), filter, mutation.Unscoped))
+		if err != nil {
+			return err
+		}
+
+		if _, ok := doc.data.index[r.tenantField]; ok && !bool(mutation.Unscoped) {
+			if err := r.checkTenantUpdate(cw.ctx, mutation.Mutates); err != nil {
+				return err
+			}
+		}
+
 		var (
-			query = r.withDefaultScope(doc.data, Build(doc.Table(), filter, mutation.Unscoped))
+			updateQuery                  = query
+			lockField, version, next, ok = lockVersion(doc)
 		)
 
-		if updatedCount, err := cw.adapter.Update(cw.ctx, query, mutation.Mutates); err != nil {
+		if ok {
+			updateQuery = query.Where(Eq(lockField, version))
+			mutation.Add(Set(lockField, next))
+		}
+
+		if updatedCount, err := cw.adapter.Update(cw.ctx, updateQuery, mutation.Mutates); err != nil {
 			return mutation.ErrorFunc.transform(err)
 		} else if updatedCount == 0 {
+			if ok {
+				return StaleRecordError{Table: doc.Table()}
+			}
+
 			return NotFoundError{}
 		}
 
+		if ok {
+			doc.SetValue(lockField, next)
+		}
+
 		if mutation.Reload {
 			if err := r.find(cw, doc, query); err != nil {
 				return err
@@ -434,9 +878,13 @@ func (r repository) update(cw contextWrapper, doc *Document, mutation Mutation,
 		if err := r.saveHasMany(cw, doc, &mutation, false); err != nil {
 			return err
 		}
+
+		if err := r.saveManyToMany(cw, doc, &mutation, false); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return afterUpdate(cw.ctx, doc)
 }
 
 // MustUpdate an record in database.
@@ -480,12 +928,21 @@ func (r repository) saveBelongsTo(cw contextWrapper, doc *Document, mutation *Mu
 
 			mutation.Add(Set(rField, fValue))
 			doc.SetValue(rField, fValue)
+			setPolymorphicType(assoc, doc, mutation)
 		}
 	}
 
 	return nil
 }
 
+// setPolymorphicType sets owner type of polymorphic association to the document that stores it.
+func setPolymorphicType(assoc Association, doc *Document, mutation *Mutation) {
+	if field := assoc.PolymorphicField(); field != "" {
+		mutation.Add(Set(field, assoc.PolymorphicValue()))
+		doc.SetValue(field, assoc.PolymorphicValue())
+	}
+}
+
 // TODO: suppprt deletion
 func (r repository) saveHasOne(cw contextWrapper, doc *Document, mutation *Mutation) error {
 	for _, field := range doc.HasOne() {
@@ -517,6 +974,7 @@ func (r repository) saveHasOne(cw contextWrapper, doc *Document, mutation *Mutat
 
 			assocMut.Add(Set(fField, rValue))
 			assocDoc.SetValue(fField, rValue)
+			setPolymorphicType(assoc, assocDoc, &assocMut)
 
 			if err := r.insert(cw, assocDoc, assocMut); err != nil {
 				return err
@@ -553,16 +1011,26 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 
 		if !insertion {
 			var (
-				filter = Eq(fField, rValue)
+				filter = filterPolymorphic(assoc, Eq(fField, rValue))
 			)
 
 			if deletedIDs == nil {
 				// if it's nil, then clear old association (used by structset).
-				if _, err := r.deleteAll(cw, col.data.flag, Build(table, filter)); err != nil {
+				query, err := r.withScope(cw.ctx, col.data, Build(table, filter))
+				if err != nil {
+					return err
+				}
+
+				if _, err := r.deleteAll(cw, col.data.flag, query); err != nil {
 					return err
 				}
 			} else if len(deletedIDs) > 0 {
-				if _, err := r.deleteAll(cw, col.data.flag, Build(table, filter.AndIn(pField, deletedIDs...))); err != nil {
+				query, err := r.withTenant(cw.ctx, col.data, Build(table, filter.AndIn(pField, deletedIDs...)))
+				if err != nil {
+					return err
+				}
+
+				if _, err := r.deleteAll(cw, col.data.flag, query); err != nil {
 					return err
 				}
 			}
@@ -580,7 +1048,7 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 			if deletedIDs != nil && !isZero(assocDoc.PrimaryValue()) {
 				var (
 					fValue, _ = assocDoc.Value(fField)
-					filter    = filterDocument(assocDoc).AndEq(fField, rValue)
+					filter    = filterPolymorphic(assoc, filterDocument(assocDoc).AndEq(fField, rValue))
 				)
 
 				if rValue != fValue {
@@ -604,6 +1072,7 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 			} else {
 				muts[i].Add(Set(fField, rValue))
 				assocDoc.SetValue(fField, rValue)
+				setPolymorphicType(assoc, assocDoc, &muts[i])
 			}
 		}
 
@@ -628,6 +1097,99 @@ func (r repository) saveHasMany(cw contextWrapper, doc *Document, mutation *Muta
 	return nil
 }
 
+// saveManyToMany inserts new records of the association and manages the join table rows.
+// Existing records are only linked, it won't be updated.
+func (r repository) saveManyToMany(cw contextWrapper, doc *Document, mutation *Mutation, insertion bool) error {
+	for _, field := range doc.ManyToMany() {
+		assocMuts, changed := mutation.Assoc[field]
+		if !changed {
+			continue
+		}
+
+		var (
+			assoc      = doc.Association(field)
+			col, _     = assoc.Collection()
+			through    = assoc.Through()
+			trField    = assoc.ThroughReferenceField()
+			tfField    = assoc.ThroughForeignField()
+			fField     = assoc.ForeignField()
+			rValue     = assoc.ReferenceValue()
+			muts       = assocMuts.Mutations
+			deletedIDs = assocMuts.DeletedIDs
+			replace    = insertion || deletedIDs == nil
+		)
+
+		// this shouldn't happen unless there's bug in the mutator.
+		if len(muts) != col.Len() {
+			panic("rel: invalid mutator")
+		}
+
+		if !insertion {
+			var (
+				filter = Eq(trField, rValue)
+			)
+
+			if deletedIDs == nil {
+				// if it's nil, then clear old join rows (used by structset).
+				if _, err := cw.adapter.Delete(cw.ctx, Build(through, filter)); err != nil {
+					return err
+				}
+			} else if len(deletedIDs) > 0 {
+				if _, err := cw.adapter.Delete(cw.ctx, Build(through, filter.AndIn(tfField, deletedIDs...))); err != nil {
+					return err
+				}
+			}
+		}
+
+		// move new records to the end for bulk insertion.
+		existCount := 0
+		for i := range muts {
+			if fValue, _ := col.Get(i).Value(fField); isZero(fValue) {
+				continue
+			}
+
+			if existCount < i {
+				col.Swap(existCount, i)
+				muts[i], muts[existCount] = muts[existCount], muts[i]
+			}
+
+			existCount++
+		}
+
+		if len(muts)-existCount > 0 {
+			if err := r.insertAll(cw, col.Slice(existCount, len(muts)), muts[existCount:]); err != nil {
+				return err
+			}
+		}
+
+		var (
+			fields      = []string{trField, tfField}
+			bulkMutates = make([]map[string]Mutate, 0, len(muts))
+		)
+
+		for i := range muts {
+			// already linked records doesn't contains any mutation (used by changeset).
+			if !replace && i < existCount && muts[i].IsEmpty() {
+				continue
+			}
+
+			fValue, _ := col.Get(i).Value(fField)
+			bulkMutates = append(bulkMutates, map[string]Mutate{
+				trField: Set(trField, rValue),
+				tfField: Set(tfField, fValue),
+			})
+		}
+
+		if len(bulkMutates) > 0 {
+			if _, err := cw.adapter.InsertAll(cw.ctx, Build(through), "", fields, bulkMutates, OnConflict{}); err != nil {
+				return mutation.ErrorFunc.transform(err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (r repository) UpdateAll(ctx context.Context, query Query, mutates ...Mutate) error {
 	finish := r.instrumenter.Observe(ctx, "rel-update-all", "updating multiple records")
 	defer finish(nil)
@@ -642,10 +1204,21 @@ func (r repository) UpdateAll(ctx context.Context, query Query, mutates ...Mutat
 		muts[mut.Field] = mut
 	}
 
-	if len(muts) > 0 {
-		_, err = cw.adapter.Update(cw.ctx, query, muts)
+	if len(muts) == 0 {
+		return nil
+	}
+
+	if query, err = r.withTenantScope(cw.ctx, query); err != nil {
+		return err
 	}
 
+	if !bool(query.UnscopedQuery) && (r.tenantTables == nil || r.tenantTables[query.Table]) {
+		if err := r.checkTenantUpdate(cw.ctx, muts); err != nil {
+			return err
+		}
+	}
+
+	_, err = cw.adapter.Update(cw.ctx, query, muts)
 	return err
 }
 
@@ -654,60 +1227,96 @@ func (r repository) MustUpdateAll(ctx context.Context, query Query, mutates ...M
 }
 
 // Delete single entry.
-func (r repository) Delete(ctx context.Context, record interface{}, options ...Cascade) error {
+// Use Cascade to delete the loaded associations, and Unscoped to ignore default scope and tenant scope.
+func (r repository) Delete(ctx context.Context, record interface{}, options ...DeleteOption) error {
 	finish := r.instrumenter.Observe(ctx, "rel-delete", "deleting a record")
 	defer finish(nil)
 
 	var (
-		cw      = fetchContext(ctx, r.rootAdapter)
-		doc     = NewDocument(record)
-		cascade = Cascade(false)
+		cw     = fetchContext(ctx, r.rootAdapter)
+		doc    = NewDocument(record)
+		option deleteOption
 	)
 
-	if len(options) > 0 {
-		cascade = options[0]
+	for i := range options {
+		options[i].applyDelete(&option)
 	}
 
-	if cascade {
+	if bool(option.cascade) || hasHook(doc.v) {
 		return r.transaction(cw, func(cw contextWrapper) error {
-			return r.delete(cw, doc, filterDocument(doc), cascade)
+			return r.delete(cw, doc, filterDocument(doc), option)
 		})
 	}
 
-	return r.delete(cw, doc, filterDocument(doc), cascade)
+	return r.delete(cw, doc, filterDocument(doc), option)
 }
 
-func (r repository) delete(cw contextWrapper, doc *Document, filter FilterQuery, cascade Cascade) error {
+func (r repository) delete(cw contextWrapper, doc *Document, filter FilterQuery, option deleteOption) error {
 	var (
-		table = doc.Table()
-		query = Build(table, filter)
+		table                     = doc.Table()
+		cascade                   = option.cascade
+		lockField, version, _, ok = lockVersion(doc)
 	)
 
+	query, err := r.withScope(cw.ctx, doc.data, Build(table, filter, option.unscoped))
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		query = query.Where(Eq(lockField, version))
+	}
+
+	if err := beforeDelete(cw.ctx, doc); err != nil {
+		return err
+	}
+
+	// check the version before deleting the associations, so stale record won't cascade.
+	if ok && bool(cascade) {
+		if count, err := r.aggregate(cw, query, "count", "*"); err != nil {
+			return err
+		} else if count == 0 {
+			return StaleRecordError{Table: table}
+		}
+	}
+
 	if cascade {
-		if err := r.deleteHasOne(cw, doc, cascade); err != nil {
+		if err := r.deleteHasOne(cw, doc, option); err != nil {
+			return err
+		}
+
+		if err := r.deleteHasMany(cw, doc, option); err != nil {
 			return err
 		}
 
-		if err := r.deleteHasMany(cw, doc); err != nil {
+		if err := r.deleteManyToMany(cw, doc); err != nil {
 			return err
 		}
 	}
 
 	deletedCount, err := r.deleteAll(cw, doc.data.flag, query)
 	if err == nil && deletedCount == 0 {
-		err = NotFoundError{}
+		if ok {
+			err = StaleRecordError{Table: table}
+		} else {
+			err = NotFoundError{}
+		}
 	}
 
-	if err == nil && cascade {
-		if err := r.deleteBelongsTo(cw, doc, cascade); err != nil {
+	if err != nil {
+		return err
+	}
+
+	if cascade {
+		if err := r.deleteBelongsTo(cw, doc, option); err != nil {
 			return err
 		}
 	}
 
-	return err
+	return afterDelete(cw.ctx, doc)
 }
 
-func (r repository) deleteBelongsTo(cw contextWrapper, doc *Document, cascade Cascade) error {
+func (r repository) deleteBelongsTo(cw contextWrapper, doc *Document, option deleteOption) error {
 	for _, field := range doc.BelongsTo() {
 		var (
 			assoc            = doc.Association(field)
@@ -720,7 +1329,7 @@ func (r repository) deleteBelongsTo(cw contextWrapper, doc *Document, cascade Ca
 				return err
 			}
 
-			if err := r.delete(cw, assocDoc, filter, cascade); err != nil {
+			if err := r.delete(cw, assocDoc, filter, option); err != nil {
 				return err
 			}
 		}
@@ -729,7 +1338,7 @@ func (r repository) deleteBelongsTo(cw contextWrapper, doc *Document, cascade Ca
 	return nil
 }
 
-func (r repository) deleteHasOne(cw contextWrapper, doc *Document, cascade Cascade) error {
+func (r repository) deleteHasOne(cw contextWrapper, doc *Document, option deleteOption) error {
 	for _, field := range doc.HasOne() {
 		var (
 			assoc            = doc.Association(field)
@@ -742,7 +1351,7 @@ func (r repository) deleteHasOne(cw contextWrapper, doc *Document, cascade Casca
 				return err
 			}
 
-			if err := r.delete(cw, assocDoc, filter, cascade); err != nil {
+			if err := r.delete(cw, assocDoc, filter, option); err != nil {
 				return err
 			}
 		}
@@ -751,7 +1360,7 @@ func (r repository) deleteHasOne(cw contextWrapper, doc *Document, cascade Casca
 	return nil
 }
 
-func (r repository) deleteHasMany(cw contextWrapper, doc *Document) error {
+func (r repository) deleteHasMany(cw contextWrapper, doc *Document, option deleteOption) error {
 	for _, field := range doc.HasMany() {
 		var (
 			assoc       = doc.Association(field)
@@ -763,10 +1372,15 @@ func (r repository) deleteHasMany(cw contextWrapper, doc *Document) error {
 				table  = col.Table()
 				fField = assoc.ForeignField()
 				rValue = assoc.ReferenceValue()
-				filter = Eq(fField, rValue).And(filterCollection(col))
+				filter = filterPolymorphic(assoc, Eq(fField, rValue).And(filterCollection(col)))
 			)
 
-			if _, err := r.deleteAll(cw, col.data.flag, Build(table, filter)); err != nil {
+			query, err := r.withScope(cw.ctx, col.data, Build(table, filter, option.unscoped))
+			if err != nil {
+				return err
+			}
+
+			if _, err := r.deleteAll(cw, col.data.flag, query); err != nil {
 				return err
 			}
 		}
@@ -775,9 +1389,29 @@ func (r repository) deleteHasMany(cw contextWrapper, doc *Document) error {
 	return nil
 }
 
+// deleteManyToMany only deletes the join table rows, associated records are kept.
+func (r repository) deleteManyToMany(cw contextWrapper, doc *Document) error {
+	for _, field := range doc.ManyToMany() {
+		var (
+			assoc  = doc.Association(field)
+			rValue = assoc.ReferenceValue()
+		)
+
+		if rValue == nil {
+			continue
+		}
+
+		if _, err := cw.adapter.Delete(cw.ctx, Build(assoc.Through(), Eq(assoc.ThroughReferenceField(), rValue))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MustDelete single entry.
 // It'll panic if any error eccured.
-func (r repository) MustDelete(ctx context.Context, record interface{}, options ...Cascade) {
+func (r repository) MustDelete(ctx context.Context, record interface{}, options ...DeleteOption) {
 	must(r.Delete(ctx, record, options...))
 }
 
@@ -787,10 +1421,15 @@ func (r repository) DeleteAll(ctx context.Context, query Query) error {
 	defer finish(nil)
 
 	var (
-		cw     = fetchContext(ctx, r.rootAdapter)
-		_, err = r.deleteAll(cw, Invalid, query)
+		cw = fetchContext(ctx, r.rootAdapter)
 	)
 
+	query, err := r.withTenantScope(cw.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.deleteAll(cw, Invalid, query)
 	return err
 }
 
@@ -817,10 +1456,9 @@ func (r repository) Preload(ctx context.Context, records interface{}, field stri
 	defer finish(nil)
 
 	var (
-		sl   slice
-		cw   = fetchContext(ctx, r.rootAdapter)
-		path = strings.Split(field, ".")
-		rt   = reflect.TypeOf(records)
+		sl slice
+		cw = fetchReadContext(ctx, r.rootAdapter, r.replicas)
+		rt = reflect.TypeOf(records)
 	)
 
 	if rt.Kind() != reflect.Ptr {
@@ -834,37 +1472,259 @@ func (r repository) Preload(ctx context.Context, records interface{}, field stri
 		sl = NewDocument(records)
 	}
 
+	return r.preload(cw, sl, field, queriers)
+}
+
+// MustPreload loads association with given query.
+// It'll panic if any error occurred.
+func (r repository) MustPreload(ctx context.Context, records interface{}, field string, queriers ...Querier) {
+	must(r.Preload(ctx, records, field, queriers...))
+}
+
+// preload loads association and then the nested associations defined using PreloadQuery.
+func (r repository) preload(cw contextWrapper, sl slice, field string, queriers []Querier) error {
 	var (
-		targets, table, keyField, keyType, ddata, loaded = r.mapPreloadTargets(sl, path)
-		ids                                              = r.targetIDs(targets)
-		query                                            = Build(table, append(queriers, In(keyField, ids...))...)
+		path                                          = strings.Split(field, ".")
+		targets, table, assoc, keyType, ddata, loaded = r.mapPreloadTargets(sl, path)
+		ids                                           = r.targetIDs(targets)
+		keyField                                      = assoc.ForeignField()
+		query                                         Query
 	)
 
-	if len(targets) == 0 || loaded && !bool(query.ReloadQuery) {
-		return nil
+	if assoc.Type() == ManyToMany {
+		keyField = assoc.ThroughReferenceField()
+		query = r.preloadThroughQuery(table, assoc, ids, queriers)
+	} else {
+		query = Build(table, append(queriers, filterPolymorphic(assoc, In(keyField, ids...)))...)
+
+		if query.PartitionQuery.Limit > 0 && query.PartitionQuery.Field == "" {
+			query.PartitionQuery.Field = keyField
+		}
 	}
 
 	var (
-		cur, err = cw.adapter.Query(cw.ctx, r.withDefaultScope(ddata, query))
+		nested = query.PreloadQuery
 	)
 
+	query.PreloadQuery = nil
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	if !loaded || bool(query.ReloadQuery) {
+		if err := r.scanPreload(cw, query, ddata, keyField, keyType, targets); err != nil {
+			return err
+		}
+	}
+
+	for i := range nested {
+		if err := r.preload(cw, sl, field+"."+nested[i].Field, nested[i].Queriers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r repository) scanPreload(cw contextWrapper, query Query, ddata documentData, keyField string, keyType reflect.Type, targets map[interface{}][]slice) error {
+	query, err := r.withDefaultScope(cw.ctx, ddata, query)
 	if err != nil {
 		return err
 	}
 
-	scanFinish := r.instrumenter.Observe(ctx, "rel-scan-multi", "scanning all records to multiple targets")
+	cur, err := cw.adapter.Query(cw.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	for _, slices := range targets {
+		for i := range slices {
+			slices[i].Reset()
+		}
+	}
+
+	scanFinish := r.instrumenter.Observe(cw.ctx, "rel-scan-multi", "scanning all records to multiple targets")
 	defer scanFinish(nil)
 
-	return scanMulti(cur, keyField, keyType, targets)
+	return scanMulti(cw.ctx, cur, keyField, keyType, targets)
 }
 
-// MustPreload loads association with given query.
-// It'll panic if any error occurred.
-func (r repository) MustPreload(ctx context.Context, records interface{}, field string, queriers ...Querier) {
-	must(r.Preload(ctx, records, field, queriers...))
+// preloadAll loads associations defined using PreloadQuery.
+func (r repository) preloadAll(cw contextWrapper, sl slice, preloads []PreloadQuery) error {
+	for i := range preloads {
+		if err := r.preload(cw, sl, preloads[i].Field, preloads[i].Queriers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitPreloads separates associations that are preloaded using join from the rest of preloads.
+// Nested preloads of the joined associations are preloaded after the records are retrieved.
+// Association that can't be joined is preloaded using separate query instead.
+func (r repository) splitPreloads(rt reflect.Type, preloads []PreloadQuery) ([]string, []PreloadQuery) {
+	var (
+		doc    *Document
+		joins  []string
+		result = make([]PreloadQuery, 0, len(preloads))
+	)
+
+	for i := range preloads {
+		if !preloads[i].Join {
+			result = append(result, preloads[i])
+			continue
+		}
+
+		if doc == nil {
+			doc = NewDocument(reflect.New(rt))
+		}
+
+		nested := Build("", preloads[i].Queriers...).PreloadQuery
+		if !r.joinable(doc.Association(preloads[i].Field)) {
+			queriers := make([]Querier, len(nested))
+			for j := range nested {
+				queriers[j] = nested[j]
+			}
+
+			result = append(result, Preload(preloads[i].Field, queriers...))
+			continue
+		}
+
+		joins = append(joins, preloads[i].Field)
+
+		for _, preload := range nested {
+			preload.Field = preloads[i].Field + "." + preload.Field
+			result = append(result, preload)
+		}
+	}
+
+	return joins, result
+}
+
+// joinable returns true if association can be loaded using left join.
+// Join condition only consists of the reference and foreign field, so association with soft delete,
+// default scope or tenant scope is left to separate query that applies the scopes.
+func (r repository) joinable(assoc Association) bool {
+	if (assoc.Type() != BelongsTo && assoc.Type() != HasOne) || assoc.PolymorphicField() != "" {
+		return false
+	}
+
+	var (
+		target, _ = assoc.Document()
+		_, tenant = target.data.index[r.tenantField]
+	)
+
+	return !target.data.flag.Is(HasDeletedAt) && !target.data.flag.Is(HasDefaultScope) && !tenant
 }
 
-func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}][]slice, string, string, reflect.Type, documentData, bool) {
+// joinPreloads adds left join and the columns of associations to the query.
+// Association columns are aliased using the association name as prefix, and unqualified columns of the query are qualified using the table name to avoid ambiguity.
+func joinPreloads(query Query, rt reflect.Type, joins []string) Query {
+	if len(joins) == 0 {
+		return query
+	}
+
+	var (
+		doc    = NewDocument(reflect.New(rt))
+		fields = make([]string, 0, len(query.SelectQuery.Fields)+1)
+	)
+
+	if len(query.SelectQuery.Fields) == 0 && len(query.SelectQuery.Exprs) == 0 {
+		fields = append(fields, query.Table+".*")
+	} else {
+		for _, field := range query.SelectQuery.Fields {
+			fields = append(fields, qualifyField(query.Table, field))
+		}
+	}
+
+	for _, join := range joins {
+		var (
+			assoc     = doc.Association(join)
+			target, _ = assoc.Document()
+		)
+
+		query.JoinQuery = append(query.JoinQuery, NewLeftJoinOn(
+			target.Table()+" AS "+join,
+			query.Table+"."+assoc.ReferenceField(),
+			join+"."+assoc.ForeignField(),
+		))
+
+		for _, field := range target.Fields() {
+			fields = append(fields, join+"."+field+" AS "+join+"."+field)
+		}
+	}
+
+	var (
+		sorts []SortQuery
+	)
+
+	for _, sort := range query.SortQuery {
+		sort.Field = qualifyField(query.Table, sort.Field)
+		sorts = append(sorts, sort)
+	}
+
+	query.SortQuery = sorts
+	query.SelectQuery.Fields = fields
+	query.WhereQuery = qualifyFilter(query.Table, query.WhereQuery)
+
+	return query
+}
+
+func qualifyField(table string, field string) string {
+	if field == "" || strings.ContainsAny(field, ".(^ ") {
+		return field
+	}
+
+	return table + "." + field
+}
+
+func qualifyFilter(table string, filter FilterQuery) FilterQuery {
+	switch filter.Type {
+	case FilterAndOp, FilterOrOp, FilterNotOp:
+		var (
+			inner []FilterQuery
+		)
+
+		for i := range filter.Inner {
+			inner = append(inner, qualifyFilter(table, filter.Inner[i]))
+		}
+
+		filter.Inner = inner
+	case FilterFragmentOp:
+	default:
+		filter.Field = qualifyField(table, filter.Field)
+	}
+
+	return filter
+}
+
+// preloadThroughQuery builds query that loads many to many association by joining the join table.
+// The reference column of join table is selected, so it can be used to map the result.
+func (r repository) preloadThroughQuery(table string, assoc Association, ids []interface{}, queriers []Querier) Query {
+	var (
+		through   = assoc.Through()
+		keyColumn = through + "." + assoc.ThroughReferenceField()
+		query     = Build(table, queriers...)
+	)
+
+	if len(query.SelectQuery.Fields) == 0 {
+		query.SelectQuery.Fields = []string{table + ".*", keyColumn}
+	} else {
+		query.SelectQuery.Fields = append(query.SelectQuery.Fields, keyColumn)
+	}
+
+	if query.PartitionQuery.Limit > 0 && query.PartitionQuery.Field == "" {
+		query.PartitionQuery.Field = keyColumn
+	}
+
+	return query.
+		JoinOn(through, through+"."+assoc.ThroughForeignField(), table+"."+assoc.ForeignField()).
+		Where(In(keyColumn, ids...))
+}
+
+func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}][]slice, string, Association, reflect.Type, documentData, bool) {
 	type frame struct {
 		index int
 		doc   *Document
@@ -872,7 +1732,7 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 
 	var (
 		table     string
-		keyField  string
+		assoc     Association
 		keyType   reflect.Type
 		ddata     documentData
 		loaded    = true
@@ -901,23 +1761,22 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 				ref          = assocs.ReferenceValue()
 			)
 
-			if ref == nil {
+			if ref == nil || !assocs.polymorphicMatch() {
 				continue
 			}
 
-			if assocs.Type() == HasMany {
+			if assocs.Type() == HasMany || assocs.Type() == ManyToMany {
 				target, targetLoaded = assocs.Collection()
 			} else {
 				target, targetLoaded = assocs.Document()
 			}
 
-			target.Reset()
 			mapTarget[ref] = append(mapTarget[ref], target)
 			loaded = loaded && targetLoaded
 
 			if table == "" {
 				table = target.Table()
-				keyField = assocs.ForeignField()
+				assoc = assocs
 				keyType = reflect.TypeOf(ref)
 
 				if doc, ok := target.(*Document); ok {
@@ -929,7 +1788,7 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 				}
 			}
 		} else {
-			if assocs.Type() == HasMany {
+			if assocs.Type() == HasMany || assocs.Type() == ManyToMany {
 				var (
 					col, loaded = assocs.Collection()
 				)
@@ -957,7 +1816,7 @@ func (r repository) mapPreloadTargets(sl slice, path []string) (map[interface{}]
 
 	}
 
-	return mapTarget, table, keyField, keyType, ddata, loaded
+	return mapTarget, table, assoc, keyType, ddata, loaded
 }
 
 func (r repository) targetIDs(targets map[interface{}][]slice) []interface{} {
@@ -974,16 +1833,216 @@ func (r repository) targetIDs(targets map[interface{}][]slice) []interface{} {
 	return ids
 }
 
-func (r repository) withDefaultScope(ddata documentData, query Query) Query {
+func (r repository) withDefaultScope(ctx context.Context, ddata documentData, query Query) (Query, error) {
 	if query.UnscopedQuery {
-		return query
+		return query, nil
 	}
 
 	if ddata.flag.Is(HasDeletedAt) {
 		query = query.Where(Nil("deleted_at"))
 	}
 
-	return query
+	return r.withScope(ctx, ddata, query)
+}
+
+// withScope applies default scope defined by the document and tenant scope, without soft delete filter.
+func (r repository) withScope(ctx context.Context, ddata documentData, query Query) (Query, error) {
+	if query.UnscopedQuery {
+		return query, nil
+	}
+
+	if ddata.flag.Is(HasDefaultScope) {
+		query = query.Where(ddata.defaultScope)
+	}
+
+	return r.withTenant(ctx, ddata, query)
+}
+
+// withTenant scopes query by tenant only if the document has the tenant field.
+func (r repository) withTenant(ctx context.Context, ddata documentData, query Query) (Query, error) {
+	if query.UnscopedQuery || r.tenantField == "" {
+		return query, nil
+	}
+
+	query, err := r.withSubqueryTenantScope(ctx, query)
+	if _, ok := ddata.index[r.tenantField]; !ok || err != nil {
+		return query, err
+	}
+
+	return r.tenantScope(ctx, query)
+}
+
+// withTableScope applies registered default scope of the table and tenant scope, used by count and aggregate.
+func (r repository) withTableScope(ctx context.Context, query Query) (Query, error) {
+	if query.UnscopedQuery {
+		return query, nil
+	}
+
+	if filter, ok := r.scopes[query.Table]; ok {
+		query = query.Where(filter)
+	}
+
+	return r.withTenantScope(ctx, query)
+}
+
+// withTenantScope scopes query that doesn't work on a document by tenant stored in the context.
+// when the tenant tables are registered, only query to those tables are scoped.
+func (r repository) withTenantScope(ctx context.Context, query Query) (Query, error) {
+	if query.UnscopedQuery || r.tenantField == "" {
+		return query, nil
+	}
+
+	query, err := r.withSubqueryTenantScope(ctx, query)
+	if err != nil || (r.tenantTables != nil && !r.tenantTables[query.Table]) {
+		return query, err
+	}
+
+	return r.tenantScope(ctx, query)
+}
+
+// withSubqueryTenantScope scopes subqueries, common table expressions and union of the query by tenant.
+// the tables of subqueries are only known by name, so only registered tenant tables are scoped.
+func (r repository) withSubqueryTenantScope(ctx context.Context, query Query) (Query, error) {
+	if r.tenantTables == nil {
+		return query, nil
+	}
+
+	var err error
+	if query.WithQuery != nil {
+		withQuery := make([]WithQuery, len(query.WithQuery))
+		for i, wq := range query.WithQuery {
+			if wq.Query, err = r.withTenantScope(ctx, wq.Query); err != nil {
+				return query, err
+			}
+
+			withQuery[i] = wq
+		}
+
+		query.WithQuery = withQuery
+	}
+
+	if query.UnionAllQuery != nil {
+		unionAllQuery := make([]Query, len(query.UnionAllQuery))
+		for i := range query.UnionAllQuery {
+			if unionAllQuery[i], err = r.withTenantScope(ctx, query.UnionAllQuery[i]); err != nil {
+				return query, err
+			}
+		}
+
+		query.UnionAllQuery = unionAllQuery
+	}
+
+	if query.WhereQuery, err = r.withFilterTenantScope(ctx, query.WhereQuery); err != nil {
+		return query, err
+	}
+
+	query.GroupQuery.Filter, err = r.withFilterTenantScope(ctx, query.GroupQuery.Filter)
+	return query, err
+}
+
+// withFilterTenantScope scopes subqueries used by exists and in filter by tenant.
+func (r repository) withFilterTenantScope(ctx context.Context, filter FilterQuery) (FilterQuery, error) {
+	var err error
+
+	switch filter.Type {
+	case FilterAndOp, FilterOrOp, FilterNotOp:
+		if filter.Inner != nil {
+			inner := make([]FilterQuery, len(filter.Inner))
+			for i := range filter.Inner {
+				if inner[i], err = r.withFilterTenantScope(ctx, filter.Inner[i]); err != nil {
+					return filter, err
+				}
+			}
+
+			filter.Inner = inner
+		}
+	case FilterExistsOp, FilterNotExistsOp:
+		if query, ok := filter.Value.(Query); ok {
+			filter.Value, err = r.withTenantScope(ctx, query)
+		}
+	case FilterInOp, FilterNinOp:
+		if values, ok := filter.Value.([]interface{}); ok && len(values) == 1 {
+			if query, ok := values[0].(Query); ok {
+				if query, err = r.withTenantScope(ctx, query); err == nil {
+					filter.Value = []interface{}{query}
+				}
+			}
+		}
+	}
+
+	return filter, err
+}
+
+func (r repository) tenantScope(ctx context.Context, query Query) (Query, error) {
+	value, ok := tenantValue(ctx)
+	if !ok {
+		return query, ErrTenantNotFound
+	}
+
+	return query.Where(Eq(r.tenantField, value)), nil
+}
+
+// setTenant sets tenant field of inserted document using tenant stored in the context.
+// conflict update is rejected, since the conflicting record may belong to other tenant.
+func (r repository) setTenant(ctx context.Context, doc *Document, mutation *Mutation) error {
+	if mutation.Unscoped || r.tenantField == "" {
+		return nil
+	}
+
+	if _, ok := doc.data.index[r.tenantField]; !ok {
+		return nil
+	}
+
+	value, ok := tenantValue(ctx)
+	if !ok {
+		return ErrTenantNotFound
+	}
+
+	if mutation.OnConflict.Replace {
+		return ErrTenantConflictUpdate
+	}
+
+	doc.SetValue(r.tenantField, value)
+	mutation.Add(Set(r.tenantField, value))
+
+	return nil
+}
+
+// checkTenantUpdate rejects update that moves tenant scoped record to other tenant.
+func (r repository) checkTenantUpdate(ctx context.Context, mutates map[string]Mutate) error {
+	mut, ok := mutates[r.tenantField]
+	if !ok || r.tenantField == "" {
+		return nil
+	}
+
+	value, _ := tenantValue(ctx)
+	if mut.Type != ChangeSetOp || !sameTenant(mut.Value, value) {
+		return ErrTenantFieldUpdate
+	}
+
+	return nil
+}
+
+// sameTenant compares tenant value that may be stored using different type than the field.
+func sameTenant(value interface{}, tenant interface{}) bool {
+	var (
+		rv = reflect.ValueOf(value)
+		rt = reflect.ValueOf(tenant)
+	)
+
+	if !rv.IsValid() || !rt.IsValid() {
+		return false
+	}
+
+	if rt.Type() != rv.Type() {
+		if !rt.Type().ConvertibleTo(rv.Type()) || rt.Kind() == reflect.String || rv.Kind() == reflect.String {
+			return false
+		}
+
+		rt = rt.Convert(rv.Type())
+	}
+
+	return rv.Type().Comparable() && rv.Interface() == rt.Interface()
 }
 
 // Transaction performs transaction with given function argument.
@@ -1037,8 +2096,21 @@ func (r repository) transaction(cw contextWrapper, fn func(cw contextWrapper) er
 
 // New create new repo using adapter.
 func New(adapter Adapter) Repository {
+	return NewWithReplicaSelector(adapter, nil)
+}
+
+// NewWithReplicas create new repo that routes read operations to replicas in round robin fashion.
+// Write operations, and any operations inside transaction always uses primary adapter.
+func NewWithReplicas(primary Adapter, replicas ...Adapter) Repository {
+	return NewWithReplicaSelector(primary, RoundRobin(), replicas...)
+}
+
+// NewWithReplicaSelector create new repo that routes read operations to replicas chosen by selector, defaults to RoundRobin.
+// Write operations, and any operations inside transaction always uses primary adapter.
+func NewWithReplicaSelector(primary Adapter, selector ReplicaSelector, replicas ...Adapter) Repository {
 	repo := &repository{
-		rootAdapter:  adapter,
+		rootAdapter:  primary,
+		replicas:     newReplicas(selector, replicas),
 		instrumenter: DefaultLogger,
 	}
 
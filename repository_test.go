@@ -118,6 +118,22 @@ func TestRepository_Aggregate(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Aggregate_ignorePartition(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").Where(Eq("active", true))
+	)
+
+	adapter.On("Aggregate", query, "count", "*").Return(3, nil).Once()
+
+	count, err := repo.Aggregate(context.TODO(), query.LimitPartition("user_id", 2), "count", "*")
+	assert.Equal(t, 3, count)
+	assert.Nil(t, err)
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_MustAggregate(t *testing.T) {
 	var (
 		adapter   = &testAdapter{}
@@ -137,6 +153,255 @@ func TestRepository_MustAggregate(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_AggregateFloat(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions").Where(Eq("status", "paid")).Group("user_id").SortAsc("id").Limit(10)
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("transactions").Where(Eq("status", "paid")).SelectExpr(NewSelectAggregate("avg", "total").As("avg"))).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan([]byte("12.5")).Once()
+	cur.On("Next").Return(false).Once()
+
+	avg, err := repo.AggregateFloat(context.TODO(), query, "avg", "total")
+	assert.Equal(t, 12.5, avg)
+	assert.Nil(t, err)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateFloat_queryError(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions")
+		err     = errors.New("error")
+	)
+
+	adapter.On("Query", query.SelectExpr(NewSelectAggregate("sum", "total").As("sum"))).Return(&testCursor{}, err).Once()
+
+	sum, qerr := repo.AggregateFloat(context.TODO(), query, "sum", "total")
+	assert.Equal(t, float64(0), sum)
+	assert.Equal(t, err, qerr)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_MustAggregateFloat(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query.SelectExpr(NewSelectAggregate("sum", "total").As("sum"))).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(false).Once()
+
+	assert.NotPanics(t, func() {
+		sum := repo.MustAggregateFloat(context.TODO(), query, "sum", "total")
+		assert.Equal(t, float64(0), sum)
+	})
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateFloat_multipleRows(t *testing.T) {
+	tests := []struct {
+		aggregate string
+		result    float64
+	}{
+		{aggregate: "count", result: 5},
+		{aggregate: "sum", result: 5},
+		{aggregate: "max", result: 3},
+		{aggregate: "min", result: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.aggregate, func(t *testing.T) {
+			var (
+				adapter = &testAdapter{}
+				repo    = New(adapter)
+				query   = From("transactions")
+				cur     = &testCursor{}
+			)
+
+			adapter.On("Query", query.SelectExpr(NewSelectAggregate(test.aggregate, "total").As(test.aggregate))).Return(cur, nil).Once()
+			cur.On("Close").Return(nil).Once()
+			cur.On("Next").Return(true).Times(3)
+			cur.MockScan(int64(2)).Once()
+			cur.MockScan(nil).Once()
+			cur.MockScan([]byte("3")).Once()
+			cur.On("Next").Return(false).Once()
+
+			result, err := repo.AggregateFloat(context.TODO(), query, test.aggregate, "total")
+			assert.Nil(t, err)
+			assert.Equal(t, test.result, result)
+
+			adapter.AssertExpectations(t)
+			cur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRepository_AggregateFloat_multipleRowsAvg(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query.SelectExpr(NewSelectAggregate("avg", "total").As("avg"))).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan([]byte("2.5")).Twice()
+
+	_, err := repo.AggregateFloat(context.TODO(), query, "avg", "total")
+	assert.Equal(t, errors.New("rel: unable to combine avg aggregate of multiple rows"), err)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateDecimal(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query.SelectExpr(NewSelectAggregate("sum", "total").As("sum"))).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan([]byte("12345678901234567.89")).Once()
+	cur.On("Next").Return(false).Once()
+
+	sum, err := repo.AggregateDecimal(context.TODO(), query, "sum", "total")
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234567.89", sum.FloatString(2))
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateDecimal_invalid(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query.SelectExpr(NewSelectAggregate("sum", "total").As("sum"))).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan([]byte("NaN")).Once()
+
+	assert.Panics(t, func() {
+		repo.MustAggregateDecimal(context.TODO(), query, "sum", "total")
+	})
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateGroup(t *testing.T) {
+	type UserStat struct {
+		UserID   int
+		Count    int
+		SumTotal float64 `db:"sum_transactions_total"`
+		AvgTotal float64 `db:"average"`
+	}
+
+	var (
+		stats   []UserStat
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions").Group("user_id").Preload("user")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("transactions").Group("user_id").Select("user_id").SelectExpr(
+		NewSelectAggregate("count", "*").As("count"),
+		NewSelectAggregate("sum", "transactions.total").As("sum_transactions_total"),
+		NewSelectAggregate("avg", "total").As("average"),
+	)).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"user_id", "count", "sum_transactions_total", "average"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(1, 2, []byte("15"), []byte("7.5")).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.AggregateGroup(context.TODO(), &stats, query,
+		NewSelectAggregate("count", "*"),
+		NewSelectAggregate("sum", "transactions.total"),
+		NewSelectAggregate("avg", "total").As("average"),
+	))
+	assert.Equal(t, []UserStat{
+		{UserID: 1, Count: 2, SumTotal: 15, AvgTotal: 7.5},
+		{UserID: 1, Count: 2, SumTotal: 15, AvgTotal: 7.5},
+	}, stats)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateGroup_maps(t *testing.T) {
+	var (
+		stats   []map[string]interface{}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions").Group("status")
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", query.Select("status").SelectExpr(NewSelectAggregate("count", "*").As("count"))).Return(cur, nil).Once()
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"status", "count"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan([]byte("paid"), int64(2)).Once()
+	cur.On("Next").Return(false).Once()
+
+	assert.NotPanics(t, func() {
+		repo.MustAggregateGroup(context.TODO(), &stats, query, NewSelectAggregate("count", "*"))
+	})
+	assert.Equal(t, []map[string]interface{}{
+		{"status": "paid", "count": int64(2)},
+	}, stats)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_AggregateGroup_queryError(t *testing.T) {
+	var (
+		stats   []map[string]interface{}
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("transactions").Group("status")
+		err     = errors.New("error")
+	)
+
+	adapter.On("Query", query.Select("status").SelectExpr(NewSelectAggregate("count", "*").As("count"))).Return(&testCursor{}, err).Twice()
+
+	assert.Equal(t, err, repo.AggregateGroup(context.TODO(), &stats, query, NewSelectAggregate("count", "*")))
+	assert.Panics(t, func() {
+		var records []struct{ Status string }
+		repo.MustAggregateGroup(context.TODO(), &records, query, NewSelectAggregate("count", "*"))
+	})
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Count(t *testing.T) {
 	var (
 		adapter = &testAdapter{}
@@ -464,7 +729,7 @@ func TestRepository_Insert(t *testing.T) {
 		}
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(1, nil).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(1, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user))
 	assert.Equal(t, User{
@@ -477,6 +742,32 @@ func TestRepository_Insert(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Insert_embedded(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		comment = Comment{Body: "body"}
+		mutates = map[string]Mutate{
+			"body":       Set("body", "body"),
+			"created_by": Set("created_by", nil),
+			"updated_by": Set("updated_by", nil),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("comments"), mutates, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &comment))
+	assert.Equal(t, Comment{
+		Model:      Model{ID: 1},
+		Body:       "body",
+		Timestamps: Timestamps{CreatedAt: now(), UpdatedAt: now()},
+	}, comment)
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Insert_compositePrimaryFields(t *testing.T) {
 	var (
 		adapter  = &testAdapter{}
@@ -491,7 +782,7 @@ func TestRepository_Insert_compositePrimaryFields(t *testing.T) {
 		}
 	)
 
-	adapter.On("Insert", From("user_roles"), mutates).Return(0, nil).Once()
+	adapter.On("Insert", From("user_roles"), mutates, OnConflict{}).Return(0, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &userRole))
 	assert.Equal(t, UserRole{
@@ -519,7 +810,7 @@ func TestRepository_Insert_sets(t *testing.T) {
 		}
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(1, nil).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(1, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user, mutators...))
 	assert.Equal(t, User{
@@ -551,7 +842,7 @@ func TestRepository_Insert_reload(t *testing.T) {
 		cur = createCursor(1)
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(10, nil).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(10, nil).Once()
 	adapter.On("Query", From("users").Where(Eq("id", 10)).Limit(1)).Return(cur, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user, mutators...))
@@ -587,7 +878,7 @@ func TestRepository_Insert_reloadError(t *testing.T) {
 		err = errors.New("error")
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(10, nil).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(10, nil).Once()
 	adapter.On("Query", From("users").Where(Eq("id", 10)).Limit(1)).Return(cur, err).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &user, mutators...))
@@ -609,8 +900,8 @@ func TestRepository_Insert_saveBelongsTo(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
-	adapter.On("Insert", From("addresses"), mock.Anything).Return(addressID, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
+	adapter.On("Insert", From("addresses"), mock.Anything, OnConflict{}).Return(addressID, nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &address))
@@ -640,7 +931,7 @@ func TestRepository_Insert_saveBelongsToCascadeDisabled(t *testing.T) {
 		newAddressID = 2
 	)
 
-	adapter.On("Insert", From("addresses"), mock.Anything).Return(newAddressID, nil).Once()
+	adapter.On("Insert", From("addresses"), mock.Anything, OnConflict{}).Return(newAddressID, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &address, Cascade(false)))
 	assert.Equal(t, Address{
@@ -664,7 +955,7 @@ func TestRepository_Insert_saveBelongsToError(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(0, err).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(0, err).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &address))
@@ -687,8 +978,8 @@ func TestRepository_Insert_saveHasOne(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
-	adapter.On("Insert", From("addresses"), mock.Anything).Return(addressID, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
+	adapter.On("Insert", From("addresses"), mock.Anything, OnConflict{}).Return(addressID, nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user))
@@ -720,7 +1011,7 @@ func TestRepository_Insert_saveHasOneCascadeDisabled(t *testing.T) {
 		repo    = New(adapter)
 	)
 
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user, Cascade(false)))
 	assert.Equal(t, User{
@@ -751,8 +1042,8 @@ func TestRepository_Insert_saveHasOneError(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(userID, nil).Once()
-	adapter.On("Insert", From("addresses"), mock.Anything).Return(0, err).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(userID, nil).Once()
+	adapter.On("Insert", From("addresses"), mock.Anything, OnConflict{}).Return(0, err).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &user))
@@ -773,8 +1064,8 @@ func TestRepository_Insert_saveHasMany(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, nil).Once()
-	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything).Return([]interface{}{2}, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{2}, nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user))
@@ -803,7 +1094,7 @@ func TestRepository_Insert_saveHasManyCascadeDisabled(t *testing.T) {
 		repo    = New(adapter)
 	)
 
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
 
 	assert.Nil(t, repo.Insert(context.TODO(), &user, Cascade(false)))
 	assert.Equal(t, User{
@@ -833,8 +1124,8 @@ func TestRepository_Insert_saveHasManyError(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, nil).Once()
-	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything).Return([]interface{}{}, err).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{}, err).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, err, repo.Insert(context.TODO(), &user))
@@ -842,6 +1133,66 @@ func TestRepository_Insert_saveHasManyError(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Insert_saveManyToMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{
+			Title: "golang",
+			Tags: []Tag{
+				{Name: "new"},
+				{ID: 2, Name: "existing"},
+			},
+		}
+		tagMutates = []map[string]Mutate{
+			{"name": Set("name", "new")},
+		}
+		joinMutates = []map[string]Mutate{
+			{"post_id": Set("post_id", 1), "tag_id": Set("tag_id", 2)},
+			{"post_id": Set("post_id", 1), "tag_id": Set("tag_id", 3)},
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("posts"), map[string]Mutate{"title": Set("title", "golang")}, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("tags"), []string{"name"}, tagMutates, OnConflict{}).Return([]interface{}{3}, nil).Once()
+	adapter.On("InsertAll", From("post_tags"), []string{"post_id", "tag_id"}, joinMutates, OnConflict{}).Return([]interface{}{}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &post))
+	assert.Equal(t, Post{
+		ID:    1,
+		Title: "golang",
+		Tags: []Tag{
+			{ID: 2, Name: "existing"},
+			{ID: 3, Name: "new"},
+		},
+	}, post)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_saveManyToManyError(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{
+			Title: "golang",
+			Tags:  []Tag{{ID: 2, Name: "existing"}},
+		}
+		err = errors.New("error")
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("posts"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("post_tags"), []string{"post_id", "tag_id"}, mock.Anything, OnConflict{}).Return([]interface{}{}, err).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Insert(context.TODO(), &post))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Insert_error(t *testing.T) {
 	var (
 		user     User
@@ -859,7 +1210,7 @@ func TestRepository_Insert_error(t *testing.T) {
 		}
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(0, errors.New("error")).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(0, errors.New("error")).Once()
 
 	assert.NotNil(t, repo.Insert(context.TODO(), &user, mutators...))
 	assert.Panics(t, func() { repo.MustInsert(context.TODO(), &user, mutators...) })
@@ -881,7 +1232,7 @@ func TestRepository_Insert_customError(t *testing.T) {
 		}
 	)
 
-	adapter.On("Insert", From("users"), mutates).Return(0, errors.New("error")).Once()
+	adapter.On("Insert", From("users"), mutates, OnConflict{}).Return(0, errors.New("error")).Once()
 
 	assert.Equal(t, errors.New("custom error"), repo.Insert(context.TODO(), &user, mutators...))
 	assert.Panics(t, func() { repo.MustInsert(context.TODO(), &user, mutators...) })
@@ -902,7 +1253,7 @@ func TestRepository_Insert_customErrorNested(t *testing.T) {
 	)
 
 	adapter.On("Begin").Return(nil).Once()
-	adapter.On("Insert", From("users"), mock.Anything).Return(1, errors.New("error")).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{}).Return(1, errors.New("error")).Once()
 	adapter.On("Rollback").Return(nil).Once()
 
 	assert.Equal(t, errors.New("error"), repo.Insert(context.TODO(), &address,
@@ -924,6 +1275,52 @@ func TestRepository_Insert_nothing(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Insert_onConflict(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{Keys: []string{"id"}, Replace: true}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, OnConflictReplace()))
+	assert.Equal(t, 1, user.ID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_onConflictIgnore(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{Keys: []string{"name"}, Ignore: true}).Return(nil, nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &user, OnConflictKeyIgnore("name")))
+	assert.Equal(t, 0, user.ID)
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_InsertAll(t *testing.T) {
 	var (
 		users = []User{
@@ -948,7 +1345,7 @@ func TestRepository_InsertAll(t *testing.T) {
 		}
 	)
 
-	adapter.On("InsertAll", From("users"), mock.Anything, mutates).Return([]interface{}{1, 2}, nil).Once()
+	adapter.On("InsertAll", From("users"), mock.Anything, mutates, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
 
 	assert.Nil(t, repo.InsertAll(context.TODO(), &users))
 	assert.Equal(t, []User{
@@ -979,7 +1376,7 @@ func TestRepository_InsertAll_compositePrimaryFields(t *testing.T) {
 		}
 	)
 
-	adapter.On("InsertAll", From("user_roles"), mock.Anything, mutates).Return([]interface{}{0, 0}, nil).Once()
+	adapter.On("InsertAll", From("user_roles"), mock.Anything, mutates, OnConflict{}).Return([]interface{}{0, 0}, nil).Once()
 
 	assert.Nil(t, repo.InsertAll(context.TODO(), &userRoles))
 	assert.Equal(t, []UserRole{
@@ -1014,24 +1411,177 @@ func TestRepository_InsertAll_nothing(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
-func TestRepository_Update(t *testing.T) {
+func TestRepository_InsertAll_onConflict(t *testing.T) {
 	var (
+		users = []User{
+			{ID: 1, Name: "name1"},
+			{ID: 2, Name: "name2", Age: 12},
+		}
 		adapter = &testAdapter{}
 		repo    = New(adapter)
-		user    = User{
-			ID:        1,
-			Name:      "name",
-			CreatedAt: now(),
-			UpdatedAt: now(),
-		}
-		mutates = map[string]Mutate{
-			"id":         Set("id", 1),
-			"name":       Set("name", "name"),
-			"age":        Set("age", 0),
-			"created_at": Set("created_at", now()),
-			"updated_at": Set("updated_at", now()),
-		}
-		queries = From("users").Where(Eq("id", user.ID))
+		mutates = []map[string]Mutate{
+			{
+				"id":         Set("id", 1),
+				"name":       Set("name", "name1"),
+				"age":        Set("age", 0),
+				"created_at": Set("created_at", now()),
+				"updated_at": Set("updated_at", now()),
+			},
+			{
+				"id":         Set("id", 2),
+				"name":       Set("name", "name2"),
+				"age":        Set("age", 12),
+				"created_at": Set("created_at", now()),
+				"updated_at": Set("updated_at", now()),
+			},
+		}
+	)
+
+	adapter.On("InsertAll", From("users"), mock.Anything, mutates, OnConflict{Keys: []string{"id"}, Replace: true}).Return([]interface{}{1, 2}, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(context.TODO(), &users, OnConflictReplace()))
+	assert.Equal(t, []User{
+		{ID: 1, Name: "name1", Age: 0, CreatedAt: now(), UpdatedAt: now()},
+		{ID: 2, Name: "name2", Age: 12, CreatedAt: now(), UpdatedAt: now()},
+	}, users)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Upsert(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			ID:   1,
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"id":         Set("id", 1),
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{Keys: []string{"id"}, Replace: true}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Upsert(context.TODO(), &user))
+	assert.Equal(t, User{
+		ID:        1,
+		Name:      "name",
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}, user)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Upsert_onConflictKeyUpdate(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+			Age:  10,
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 10),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+		onConflict = OnConflictKeyUpdate("name", "age", "updated_at")
+	)
+
+	adapter.On("Insert", From("users"), mutates, onConflict).Return(2, nil).Once()
+
+	assert.NotPanics(t, func() {
+		repo.MustUpsert(context.TODO(), &user, onConflict)
+	})
+	assert.Equal(t, 2, user.ID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Upsert_onConflictKeys(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			Name: "name",
+		}
+		mutates = map[string]Mutate{
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+	)
+
+	adapter.On("Insert", From("users"), mutates, OnConflict{Keys: []string{"name"}, Replace: true}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Upsert(context.TODO(), &user, OnConflict{Keys: []string{"name"}}))
+	assert.Equal(t, 1, user.ID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Upsert_saveHasMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			ID: 1,
+			Transactions: []Transaction{
+				{Item: "soap"},
+			},
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("users"), mock.Anything, OnConflict{Keys: []string{"id"}, Replace: true}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{2}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Upsert(context.TODO(), &user))
+	assert.Equal(t, 2, user.Transactions[0].ID)
+	assert.Equal(t, 1, user.Transactions[0].BuyerID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Upsert_nothing(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+	)
+
+	assert.Nil(t, repo.Upsert(context.TODO(), nil))
+	assert.NotPanics(t, func() { repo.MustUpsert(context.TODO(), nil) })
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{
+			ID:        1,
+			Name:      "name",
+			CreatedAt: now(),
+			UpdatedAt: now(),
+		}
+		mutates = map[string]Mutate{
+			"id":         Set("id", 1),
+			"name":       Set("name", "name"),
+			"age":        Set("age", 0),
+			"created_at": Set("created_at", now()),
+			"updated_at": Set("updated_at", now()),
+		}
+		queries = From("users").Where(Eq("id", user.ID))
 	)
 
 	adapter.On("Update", queries, mutates).Return(1, nil).Once()
@@ -1047,6 +1597,44 @@ func TestRepository_Update(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Update_lockVersion(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		invoice = Invoice{ID: 1, Name: "name", LockVersion: 2}
+		mutates = map[string]Mutate{
+			"name":         Set("name", "update"),
+			"lock_version": Set("lock_version", 3),
+		}
+	)
+
+	adapter.On("Update", From("invoices").Where(Eq("id", 1)).Where(Eq("lock_version", 2)), mutates).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &invoice, Set("name", "update")))
+	assert.Equal(t, Invoice{ID: 1, Name: "update", LockVersion: 3}, invoice)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_staleRecord(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		invoice = Invoice{ID: 1, Name: "name", LockVersion: 2}
+		mutates = map[string]Mutate{
+			"name":         Set("name", "update"),
+			"lock_version": Set("lock_version", 3),
+		}
+	)
+
+	adapter.On("Update", From("invoices").Where(Eq("id", 1)).Where(Eq("lock_version", 2)), mutates).Return(0, nil).Once()
+
+	assert.Equal(t, StaleRecordError{Table: "invoices"}, repo.Update(context.TODO(), &invoice, Set("name", "update")))
+	assert.Equal(t, 2, invoice.LockVersion)
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Update_compositePrimaryKeys(t *testing.T) {
 	var (
 		adapter  = &testAdapter{}
@@ -1426,7 +2014,7 @@ func TestRepository_Update_saveHasMany(t *testing.T) {
 	adapter.On("Begin").Return(nil).Once()
 	adapter.On("Update", From("users").Where(Eq("id", 10)), mock.Anything).Return(1, nil).Once()
 	adapter.On("Delete", From("transactions").Where(Eq("user_id", 10))).Return(1, nil).Once()
-	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything).Return([]interface{}{1}, nil).Once()
+	adapter.On("InsertAll", From("transactions"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{1}, nil).Once()
 	adapter.On("Commit").Return(nil).Once()
 
 	assert.Nil(t, repo.Update(context.TODO(), &user))
@@ -1446,6 +2034,93 @@ func TestRepository_Update_saveHasMany(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Update_saveManyToMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{
+			ID:    1,
+			Title: "golang",
+			Tags:  []Tag{{ID: 2, Name: "existing"}},
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("posts").Where(Eq("id", 1)), mock.Anything).Return(1, nil).Once()
+	adapter.On("Delete", From("post_tags").Where(Eq("post_id", 1))).Return(1, nil).Once()
+	adapter.On("InsertAll", From("post_tags"), []string{"post_id", "tag_id"}, []map[string]Mutate{
+		{"post_id": Set("post_id", 1), "tag_id": Set("tag_id", 2)},
+	}, OnConflict{}).Return([]interface{}{}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &post))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_saveManyToManyChangeset(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{
+			ID:    1,
+			Title: "golang",
+			Tags: []Tag{
+				{ID: 2, Name: "linked"},
+				{ID: 3, Name: "unlinked"},
+			},
+		}
+		changeset = NewChangeset(&post)
+	)
+
+	post.Tags = []Tag{
+		post.Tags[0],
+		{ID: 4, Name: "existing"},
+		{Name: "new"},
+	}
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("post_tags").Where(Eq("post_id", 1).AndIn("tag_id", 3))).Return(1, nil).Once()
+	adapter.On("InsertAll", From("tags"), []string{"name"}, []map[string]Mutate{
+		{"name": Set("name", "new")},
+	}, OnConflict{}).Return([]interface{}{5}, nil).Once()
+	adapter.On("InsertAll", From("post_tags"), []string{"post_id", "tag_id"}, []map[string]Mutate{
+		{"post_id": Set("post_id", 1), "tag_id": Set("tag_id", 4)},
+		{"post_id": Set("post_id", 1), "tag_id": Set("tag_id", 5)},
+	}, OnConflict{}).Return([]interface{}{}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &post, changeset))
+	assert.Equal(t, []Tag{
+		{ID: 2, Name: "linked", Posts: []Post{}},
+		{ID: 4, Name: "existing"},
+		{ID: 5, Name: "new"},
+	}, post.Tags)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_saveManyToManyDeleteError(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{
+			ID:   1,
+			Tags: []Tag{{ID: 2, Name: "existing"}},
+		}
+		err = errors.New("error")
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("posts").Where(Eq("id", 1)), mock.Anything).Return(1, nil).Once()
+	adapter.On("Delete", From("post_tags").Where(Eq("post_id", 1))).Return(0, err).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Update(context.TODO(), &post))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Update_saveHasManyCascadeDisabled(t *testing.T) {
 	var (
 		user = User{
@@ -1660,7 +2335,7 @@ func TestRepository_saveBelongsTo_insertNew(t *testing.T) {
 		q = Build("users")
 	)
 
-	adapter.On("Insert", q, mutates).Return(1, nil).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(1, nil).Once()
 
 	assert.Nil(t, repo.(*repository).saveBelongsTo(cw, doc, &mutation))
 	assert.Equal(t, Set("user_id", 1), mutation.Mutates["user_id"])
@@ -1702,7 +2377,7 @@ func TestRepository_saveBelongsTo_insertNewError(t *testing.T) {
 		q = Build("users")
 	)
 
-	adapter.On("Insert", q, mutates).Return(0, errors.New("insert error")).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(0, errors.New("insert error")).Once()
 
 	assert.Equal(t, errors.New("insert error"), repo.(*repository).saveBelongsTo(cw, doc, &mutation))
 	assert.Zero(t, mutation.Mutates["user_id"])
@@ -1828,7 +2503,7 @@ func TestRepository_saveHasOne_insertNew(t *testing.T) {
 		q = Build("addresses")
 	)
 
-	adapter.On("Insert", q, mutates).Return(2, nil).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(2, nil).Once()
 
 	assert.Nil(t, repo.(*repository).saveHasOne(cw, doc, &mutation))
 	assert.Equal(t, User{
@@ -1864,7 +2539,7 @@ func TestRepository_saveHasOne_insertNewError(t *testing.T) {
 		q = Build("addresses")
 	)
 
-	adapter.On("Insert", q, mutates).Return(nil, errors.New("insert error")).Once()
+	adapter.On("Insert", q, mutates, OnConflict{}).Return(nil, errors.New("insert error")).Once()
 
 	assert.Equal(t, errors.New("insert error"), repo.(*repository).saveHasOne(cw, doc, &mutation))
 
@@ -1893,8 +2568,8 @@ func TestRepository_saveHasMany_insert(t *testing.T) {
 		q = Build("transactions")
 	)
 
-	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates).Return(nil).Return([]interface{}{2, 3}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates).Return(nil).Return([]interface{}{2, 3}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates, OnConflict{}).Return(nil).Return([]interface{}{2, 3}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates, OnConflict{}).Return(nil).Return([]interface{}{2, 3}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, true))
 	assert.Equal(t, User{
@@ -1931,8 +2606,8 @@ func TestRepository_saveHasMany_insertError(t *testing.T) {
 		err = errors.New("insert all error")
 	)
 
-	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates).Return(nil).Return([]interface{}{}, err).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates).Return(nil).Return([]interface{}{}, err).Maybe()
+	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates, OnConflict{}).Return(nil).Return([]interface{}{}, err).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates, OnConflict{}).Return(nil).Return([]interface{}{}, err).Maybe()
 
 	assert.Equal(t, err, repo.(*repository).saveHasMany(cw, doc, &mutation, true))
 
@@ -2081,8 +2756,8 @@ func TestRepository_saveHasMany_updateWithInsert(t *testing.T) {
 	)
 
 	adapter.On("Update", q.Where(Eq("id", 1).AndEq("user_id", 1)), mutates[0]).Return(1, nil).Once()
-	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates[1:]).Return(nil).Return([]interface{}{2}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates[1:]).Return(nil).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates[1:], OnConflict{}).Return(nil).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates[1:], OnConflict{}).Return(nil).Return([]interface{}{2}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2125,8 +2800,8 @@ func TestRepository_saveHasMany_updateWithReorderInsert(t *testing.T) {
 	mutation.SetDeletedIDs("transactions", []interface{}{})
 
 	adapter.On("Update", q.Where(Eq("id", 1).AndEq("user_id", 1)), mutates[0]).Return(1, nil).Once()
-	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates[1:]).Return(nil).Return([]interface{}{2}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates[1:]).Return(nil).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates[1:], OnConflict{}).Return(nil).Return([]interface{}{2}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates[1:], OnConflict{}).Return(nil).Return([]interface{}{2}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2171,8 +2846,8 @@ func TestRepository_saveHasMany_deleteWithInsert(t *testing.T) {
 	)
 
 	adapter.On("Delete", q.Where(Eq("user_id", 1).AndIn("id", 1, 2))).Return(1, nil).Once()
-	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates).Return(nil).Return([]interface{}{3, 4, 5}, nil).Maybe()
-	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates).Return(nil).Return([]interface{}{3, 4, 5}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"item", "user_id"}, mutates, OnConflict{}).Return(nil).Return([]interface{}{3, 4, 5}, nil).Maybe()
+	adapter.On("InsertAll", q, []string{"user_id", "item"}, mutates, OnConflict{}).Return(nil).Return([]interface{}{3, 4, 5}, nil).Maybe()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2244,7 +2919,7 @@ func TestRepository_saveHasMany_replace(t *testing.T) {
 	)
 
 	adapter.On("Delete", q.Where(Eq("user_id", 1))).Return(1, nil).Once()
-	adapter.On("InsertAll", q, mock.Anything, mutates).Return(nil).Return([]interface{}{3, 4, 5}, nil).Once()
+	adapter.On("InsertAll", q, mock.Anything, mutates, OnConflict{}).Return(nil).Return([]interface{}{3, 4, 5}, nil).Once()
 
 	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
 	assert.Equal(t, User{
@@ -2359,6 +3034,50 @@ func TestRepository_Delete(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
+func TestRepository_Delete_lockVersion(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		invoice = Invoice{ID: 1, LockVersion: 2}
+	)
+
+	adapter.On("Delete", From("invoices").Where(Eq("id", 1), Eq("lock_version", 2))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &invoice))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Delete_staleRecord(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		invoice = Invoice{ID: 1, LockVersion: 2}
+	)
+
+	adapter.On("Delete", From("invoices").Where(Eq("id", 1), Eq("lock_version", 2))).Return(0, nil).Once()
+
+	assert.Equal(t, StaleRecordError{Table: "invoices"}, repo.Delete(context.TODO(), &invoice))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Delete_staleRecordCascade(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ledger  = Ledger{ID: 1, LockVersion: 2, Transactions: []Transaction{{ID: 1, BuyerID: 1}}}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Aggregate", From("ledgers").Where(Eq("id", 1), Eq("lock_version", 2)), "count", "*").Return(0, nil).Once()
+	adapter.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, StaleRecordError{Table: "ledgers"}, repo.Delete(context.TODO(), &ledger, Cascade(true)))
+
+	adapter.AssertExpectations(t)
+}
+
 func TestRepository_Delete_compositePrimaryKey(t *testing.T) {
 	var (
 		adapter  = &testAdapter{}
@@ -2627,44 +3346,78 @@ func TestRepository_Delete_hasManyError(t *testing.T) {
 	adapter.AssertExpectations(t)
 }
 
-func TestRepository_MustDelete(t *testing.T) {
+func TestRepository_Delete_manyToMany(t *testing.T) {
 	var (
+		post    = Post{ID: 1}
 		adapter = &testAdapter{}
 		repo    = New(adapter)
-		user    = User{ID: 1}
 	)
 
-	adapter.On("Delete", From("users").Where(Eq("id", user.ID))).Return(1, nil).Once()
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("post_tags").Where(Eq("post_id", 1))).Return(1, nil).Once()
+	adapter.On("Delete", From("posts").Where(Eq("id", 1))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
 
-	assert.NotPanics(t, func() {
-		repo.MustDelete(context.TODO(), &user)
-	})
+	assert.Nil(t, repo.Delete(context.TODO(), &post, Cascade(true)))
 
 	adapter.AssertExpectations(t)
 }
 
-func TestRepository_DeleteAll(t *testing.T) {
+func TestRepository_Delete_manyToManyError(t *testing.T) {
 	var (
+		post    = Post{ID: 1}
 		adapter = &testAdapter{}
 		repo    = New(adapter)
-		queries = From("logs").Where(Eq("user_id", 1))
+		err     = errors.New("err")
 	)
 
-	adapter.On("Delete", From("logs").Where(Eq("user_id", 1))).Return(1, nil).Once()
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("post_tags").Where(Eq("post_id", 1))).Return(0, err).Once()
+	adapter.On("Rollback").Return(nil).Once()
 
-	assert.Nil(t, repo.DeleteAll(context.TODO(), queries))
+	assert.Equal(t, err, repo.Delete(context.TODO(), &post, Cascade(true)))
 
 	adapter.AssertExpectations(t)
 }
 
-func TestRepository_MustDeleteAll(t *testing.T) {
+func TestRepository_MustDelete(t *testing.T) {
 	var (
 		adapter = &testAdapter{}
 		repo    = New(adapter)
-		queries = From("logs").Where(Eq("user_id", 1))
+		user    = User{ID: 1}
 	)
 
-	adapter.On("Delete", From("logs").Where(Eq("user_id", 1))).Return(1, nil).Once()
+	adapter.On("Delete", From("users").Where(Eq("id", user.ID))).Return(1, nil).Once()
+
+	assert.NotPanics(t, func() {
+		repo.MustDelete(context.TODO(), &user)
+	})
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_DeleteAll(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		queries = From("logs").Where(Eq("user_id", 1))
+	)
+
+	adapter.On("Delete", From("logs").Where(Eq("user_id", 1))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.DeleteAll(context.TODO(), queries))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_MustDeleteAll(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		queries = From("logs").Where(Eq("user_id", 1))
+	)
+
+	adapter.On("Delete", From("logs").Where(Eq("user_id", 1))).Return(1, nil).Once()
 
 	assert.NotPanics(t, func() {
 		repo.MustDeleteAll(context.TODO(), queries)
@@ -2850,6 +3603,67 @@ func TestRepository_Preload_sliceHasMany(t *testing.T) {
 	cur.AssertExpectations(t)
 }
 
+func TestRepository_Preload_manyToMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		posts   = []Post{{ID: 10}, {ID: 20}}
+		tags    = []Tag{
+			{ID: 1, Name: "go"},
+			{ID: 2, Name: "sql"},
+		}
+		cur   = &testCursor{}
+		query = From("tags").
+			Select("tags.*", "post_tags.post_id").
+			JoinOn("post_tags", "post_tags.tag_id", "tags.id")
+	)
+
+	adapter.On("Query", query.Where(In("post_tags.post_id", 10, 20))).Return(cur, nil).Maybe()
+	adapter.On("Query", query.Where(In("post_tags.post_id", 20, 10))).Return(cur, nil).Maybe()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name", "post_id"}, nil).Once()
+	cur.On("Next").Return(true).Times(3)
+	cur.MockScan(tags[0].ID, tags[0].Name, 10).Twice()
+	cur.MockScan(tags[1].ID, tags[1].Name, 10).Twice()
+	cur.MockScan(tags[0].ID, tags[0].Name, 20).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &posts, "tags"))
+	assert.Equal(t, tags, posts[0].Tags)
+	assert.Equal(t, tags[:1], posts[1].Tags)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_manyToManyWithSelect(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{ID: 10}
+		cur     = &testCursor{}
+		query   = From("tags").
+			Select("tags.id", "post_tags.post_id").
+			JoinOn("post_tags", "post_tags.tag_id", "tags.id").
+			Where(Eq("name", "go").AndIn("post_tags.post_id", 10))
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "post_id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 10).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &post, "tags", Select("tags.id"), Eq("name", "go")))
+	assert.Equal(t, []Tag{{ID: 1}}, post.Tags)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
 func TestRepository_Preload_nestedHasMany(t *testing.T) {
 	var (
 		adapter      = &testAdapter{}
@@ -3319,3 +4133,1128 @@ func TestRepository_Transaction_runtimeError(t *testing.T) {
 
 	adapter.AssertExpectations(t)
 }
+
+func TestRepository_Tenant_find(t *testing.T) {
+	var (
+		project Project
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		query   = From("projects").Where(Eq("id", 1))
+		cur     = createCursor(1)
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Query", query.Where(Eq("tenant_id", 5)).Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(ctx, &project, query))
+	assert.Equal(t, 10, project.ID)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_findUnscoped(t *testing.T) {
+	var (
+		project Project
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		query   = From("projects").Where(Eq("id", 1)).Unscoped()
+		cur     = createCursor(1)
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Query", query.Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(ctx, &project, query))
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_findWithoutTenantField(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		query   = From("users").Where(Eq("id", 1))
+		cur     = createCursor(1)
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Query", query.Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(ctx, &user, query))
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_findWithoutTenant(t *testing.T) {
+	var (
+		project Project
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("projects").Where(Eq("id", 1))
+	)
+
+	repo.Tenant("tenant_id")
+
+	assert.Equal(t, ErrTenantNotFound, repo.Find(context.TODO(), &project, query))
+	assert.Equal(t, ErrTenantNotFound, repo.Insert(context.TODO(), &project))
+	assert.Equal(t, ErrTenantNotFound, repo.DeleteAll(context.TODO(), query))
+
+	_, err := repo.Count(context.TODO(), "projects")
+	assert.Equal(t, ErrTenantNotFound, err)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_findAndCountAll(t *testing.T) {
+	var (
+		projects []Project
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		ctx      = WithTenant(context.TODO(), 5)
+		query    = From("projects").Where(Eq("name", "rel"))
+		scoped   = query.Where(Eq("tenant_id", 5))
+		cur      = createCursor(2)
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Query", scoped).Return(cur, nil).Once()
+	adapter.On("Aggregate", scoped, "count", "*").Return(2, nil).Once()
+
+	count, err := repo.FindAndCountAll(ctx, &projects, query)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, projects, 2)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_count(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Aggregate", From("projects").Where(Eq("tenant_id", 5)), "count", "*").Return(3, nil).Once()
+
+	count, err := repo.Count(ctx, "projects")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_countTables(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+	)
+
+	repo.Tenant("tenant_id", "projects")
+	adapter.On("Aggregate", From("projects").Where(Eq("tenant_id", 5)), "count", "*").Return(3, nil).Once()
+	adapter.On("Aggregate", From("users"), "count", "*").Return(2, nil).Once()
+
+	count, err := repo.Count(ctx, "projects")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = repo.Count(ctx, "users")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_subqueries(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		ctx      = WithTenant(context.TODO(), 5)
+		projects = From("projects").Where(Eq("user_id", 1))
+		scoped   = projects.Where(Eq("tenant_id", 5))
+		query    = From("users").Where(Exists(projects).OrNotExists(projects), Ne("id", 0).AndIn("id", Select("user_id").From("projects")))
+		expected = From("users").Where(Exists(scoped).OrNotExists(scoped), Ne("id", 0).AndIn("id", Select("user_id").From("projects").Where(Eq("tenant_id", 5))))
+	)
+
+	repo.Tenant("tenant_id", "projects")
+	adapter.On("Aggregate", expected, "count", "*").Return(1, nil).Once()
+	adapter.On("Aggregate", From("users").Where(Exists(From("tasks")), Exists(projects.Unscoped())), "count", "*").Return(2, nil).Once()
+
+	count, err := repo.Count(ctx, "users", query)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	// only registered tenant tables are scoped.
+	count, err = repo.Count(ctx, "users", Where(Exists(From("tasks")), Exists(projects.Unscoped())))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = repo.Count(context.TODO(), "users", query)
+	assert.Equal(t, ErrTenantNotFound, err)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_commonTableExpressions(t *testing.T) {
+	var (
+		user     User
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		ctx      = WithTenant(context.TODO(), 5)
+		cte      = From("projects").Where(Eq("user_id", 1)).UnionAll(From("projects").Join("tree"))
+		query    = With("tree", cte).Where(Eq("id", 1))
+		scoped   = From("projects").Where(Eq("user_id", 1), Eq("tenant_id", 5)).UnionAll(From("projects").Join("tree").Where(Eq("tenant_id", 5)))
+		expected = With("tree", scoped).From("users").Where(Eq("id", 1))
+		cur      = createCursor(1)
+	)
+
+	repo.Tenant("tenant_id", "projects")
+	adapter.On("Query", expected.Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(ctx, &user, query))
+	assert.Equal(t, 10, user.ID)
+	assert.False(t, cur.Next())
+	assert.Equal(t, ErrTenantNotFound, repo.Find(context.TODO(), &user, query))
+	assert.Equal(t, With("tree", From("projects").Where(Eq("user_id", 1)).UnionAll(From("projects").Join("tree"))).Where(Eq("id", 1)), query)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_iterate(t *testing.T) {
+	var (
+		project Project
+		user    User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		cur1    = createCursor(1)
+		cur2    = createCursor(1)
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Query", From("projects").Where(Eq("tenant_id", 5)).SortAsc("id").Limit(1000)).Return(cur1, nil).Once()
+	adapter.On("Query", From("users").SortAsc("id").Limit(1000)).Return(cur2, nil).Once()
+
+	it := repo.Iterate(ctx, From("projects"))
+	assert.Nil(t, it.Next(&project))
+	assert.Equal(t, 10, project.ID)
+
+	// users doesn't have tenant field, no tenant required.
+	it = repo.Iterate(context.TODO(), From("users"))
+	assert.Nil(t, it.Next(&user))
+	assert.Equal(t, 10, user.ID)
+
+	it = repo.Iterate(context.TODO(), From("projects"))
+	assert.Equal(t, ErrTenantNotFound, it.Next(&project))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_insert(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{Name: "rel", TenantID: 1}
+		mutates = map[string]Mutate{
+			"name":      Set("name", "rel"),
+			"tenant_id": Set("tenant_id", 5),
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Insert", From("projects"), mutates, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(ctx, &project))
+	assert.Equal(t, Project{ID: 1, Name: "rel", TenantID: 5}, project)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_insertUnscoped(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{Name: "rel", TenantID: 1}
+		mutates = map[string]Mutate{
+			"name":      Set("name", "rel"),
+			"tenant_id": Set("tenant_id", 1),
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Insert", From("projects"), mutates, OnConflict{}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Insert(ctx, &project, Unscoped(true)))
+	assert.Equal(t, Project{ID: 1, Name: "rel", TenantID: 1}, project)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_insertAll(t *testing.T) {
+	var (
+		adapter  = &testAdapter{}
+		repo     = New(adapter)
+		ctx      = WithTenant(context.TODO(), 5)
+		projects = []Project{{Name: "a"}, {Name: "b"}}
+		mutates  = []map[string]Mutate{
+			{"name": Set("name", "a"), "tenant_id": Set("tenant_id", 5)},
+			{"name": Set("name", "b"), "tenant_id": Set("tenant_id", 5)},
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("InsertAll", From("projects"), mock.Anything, mutates, OnConflict{}).Return([]interface{}{1, 2}, nil).Once()
+
+	assert.Nil(t, repo.InsertAll(ctx, &projects))
+	assert.Equal(t, []Project{{ID: 1, Name: "a", TenantID: 5}, {ID: 2, Name: "b", TenantID: 5}}, projects)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_upsert(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{ID: 1, Name: "rel"}
+	)
+
+	repo.Tenant("tenant_id")
+
+	assert.Equal(t, ErrTenantConflictUpdate, repo.Upsert(ctx, &project))
+	assert.Equal(t, ErrTenantConflictUpdate, repo.Insert(ctx, &project, OnConflictKeyReplace("id")))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_upsertIgnore(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{ID: 1, Name: "rel"}
+		mutates = map[string]Mutate{
+			"id":        Set("id", 1),
+			"name":      Set("name", "rel"),
+			"tenant_id": Set("tenant_id", 5),
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Insert", From("projects"), mutates, OnConflictIgnore()).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Upsert(ctx, &project, OnConflictIgnore()))
+	assert.Equal(t, Project{ID: 1, Name: "rel", TenantID: 5}, project)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_update(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{ID: 1, Name: "rel", TenantID: 5}
+		mutates = map[string]Mutate{
+			"name": Set("name", "REL"),
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Update", From("projects").Where(Eq("id", 1)).Where(Eq("tenant_id", 5)), mutates).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(ctx, &project, Set("name", "REL")))
+	assert.Equal(t, "REL", project.Name)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_updateTenantField(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{ID: 1, Name: "rel", TenantID: 5}
+		mutates = map[string]Mutate{
+			"name":      Set("name", "rel"),
+			"tenant_id": Set("tenant_id", 5),
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Update", From("projects").Where(Eq("id", 1)).Where(Eq("tenant_id", 5)), map[string]Mutate{"id": Set("id", 1), "name": Set("name", "rel"), "tenant_id": Set("tenant_id", 5)}).Return(1, nil).Once()
+	adapter.On("Update", From("projects").Where(Eq("id", 1)).Where(Eq("tenant_id", int64(5))), mutates).Return(1, nil).Once()
+	adapter.On("Update", From("projects").Where(Eq("id", 1)).Unscoped(), map[string]Mutate{"tenant_id": Set("tenant_id", 6)}).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(ctx, &project))
+	assert.Nil(t, repo.Update(WithTenant(context.TODO(), int64(5)), &project, Set("name", "rel"), Set("tenant_id", 5)))
+	assert.Equal(t, ErrTenantFieldUpdate, repo.Update(ctx, &project, Set("tenant_id", 6)))
+	assert.Equal(t, ErrTenantFieldUpdate, repo.Update(ctx, &project, Inc("tenant_id")))
+	assert.Equal(t, ErrTenantFieldUpdate, repo.UpdateAll(ctx, From("projects"), Set("tenant_id", 6)))
+	assert.Nil(t, repo.Update(ctx, &project, Set("tenant_id", 6), Unscoped(true)))
+	assert.Equal(t, 6, project.TenantID)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_delete(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		project = Project{ID: 1, Name: "rel", TenantID: 5}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Delete", From("projects").Where(Eq("id", 1)).Where(Eq("tenant_id", 5))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Delete(ctx, &project))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_saveHasManyDeletedIDs(t *testing.T) {
+	var (
+		adapter   = &testAdapter{}
+		cw        = fetchContext(WithTenant(context.TODO(), 5), adapter)
+		repo      = New(adapter)
+		workspace = Workspace{ID: 1, TenantID: 5}
+		doc       = NewDocument(&workspace)
+		mutation  = Apply(doc, Map{"tasks": []Map{}})
+	)
+
+	repo.Tenant("tenant_id")
+	mutation.SetDeletedIDs("tasks", []interface{}{2})
+	adapter.On("Delete", From("tasks").Where(Eq("workspace_id", 1).AndIn("id", 2)).Where(Eq("tenant_id", 5))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.(*repository).saveHasMany(cw, doc, &mutation, false))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Tenant_updateAllAndDeleteAll(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ctx     = WithTenant(context.TODO(), 5)
+		query   = From("projects").Where(Eq("name", "rel"))
+		mutates = map[string]Mutate{
+			"name": Set("name", "REL"),
+		}
+	)
+
+	repo.Tenant("tenant_id")
+	adapter.On("Update", query.Where(Eq("tenant_id", 5)), mutates).Return(1, nil).Once()
+	adapter.On("Delete", query.Where(Eq("tenant_id", 5))).Return(1, nil).Once()
+	adapter.On("Delete", query.Unscoped()).Return(1, nil).Once()
+
+	assert.Nil(t, repo.UpdateAll(ctx, query, Set("name", "REL")))
+	assert.Nil(t, repo.DeleteAll(ctx, query))
+	assert.Nil(t, repo.DeleteAll(ctx, query.Unscoped()))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_find(t *testing.T) {
+	var (
+		ticket  Ticket
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("tickets").Where(Eq("id", 1))
+		cur     = createCursor(1)
+	)
+
+	adapter.On("Query", query.Where(Ne("status", "archived")).Limit(1)).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &ticket, query))
+	assert.Equal(t, 10, ticket.ID)
+	assert.False(t, cur.Next())
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_findAndCountAllUnscoped(t *testing.T) {
+	var (
+		tickets []Ticket
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("tickets").Unscoped()
+		cur     = createCursor(2)
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+	adapter.On("Aggregate", query, "count", "*").Return(2, nil).Once()
+
+	count, err := repo.FindAndCountAll(context.TODO(), &tickets, query)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, tickets, 2)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_update(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ticket  = Ticket{ID: 1, Status: "open"}
+		mutates = map[string]Mutate{
+			"status": Set("status", "closed"),
+		}
+	)
+
+	adapter.On("Update", From("tickets").Where(Eq("id", 1)).Where(Ne("status", "archived")), mutates).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &ticket, Set("status", "closed")))
+	assert.Equal(t, "closed", ticket.Status)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_delete(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ticket  = Ticket{ID: 1, Status: "open"}
+	)
+
+	adapter.On("Delete", From("tickets").Where(Eq("id", 1)).Where(Ne("status", "archived"))).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &ticket))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_deleteUnscoped(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		ticket  = Ticket{ID: 1, Status: "archived"}
+	)
+
+	adapter.On("Delete", From("tickets").Where(Eq("id", 1)).Unscoped()).Return(1, nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &ticket, Unscoped(true)))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_countAndAggregate(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		scoped  = From("tickets").Where(Ne("status", "archived"))
+	)
+
+	repo.DefaultScope(&Ticket{}, &User{})
+	adapter.On("Aggregate", scoped, "count", "*").Return(2, nil).Once()
+	adapter.On("Aggregate", scoped, "max", "id").Return(5, nil).Once()
+	adapter.On("Aggregate", From("tickets").Unscoped(), "count", "*").Return(3, nil).Once()
+
+	count, err := repo.Count(context.TODO(), "tickets")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	max, err := repo.Aggregate(context.TODO(), From("tickets"), "max", "id")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, max)
+
+	count, err = repo.Count(context.TODO(), "tickets", Unscoped(true))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_DefaultScope_preload(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		board   = Board{ID: 10}
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("tickets").Where(In("board_id", 10)).Where(Ne("status", "archived"))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "board_id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 10).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &board, "tickets"))
+	assert.Equal(t, []Ticket{{ID: 1, BoardID: 10}}, board.Tickets)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Scope(t *testing.T) {
+	var (
+		tickets []Ticket
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		open    = Scope(func(query Query) Query {
+			return query.Where(Eq("status", "open"))
+		})
+		cur = createCursor(1)
+	)
+
+	adapter.On("Query", From("tickets").Where(Eq("status", "open")).Where(Ne("status", "archived"))).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &tickets, open))
+	assert.Len(t, tickets, 1)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Insert_savePolymorphicHasMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		article = Article{
+			Remarks: []Remark{{Body: "a"}, {Body: "b"}},
+		}
+		polymorphic = mock.MatchedBy(func(mutates []map[string]Mutate) bool {
+			return len(mutates) == 2 &&
+				mutates[0]["remarkable_type"] == Set("remarkable_type", "articles") &&
+				mutates[1]["remarkable_id"] == Set("remarkable_id", 1)
+		})
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("articles"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("InsertAll", From("remarks"), mock.Anything, polymorphic, OnConflict{}).Return([]interface{}{2, 3}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &article))
+	assert.Equal(t, Article{
+		ID: 1,
+		Remarks: []Remark{
+			{ID: 2, Body: "a", RemarkableID: 1, RemarkableType: "articles"},
+			{ID: 3, Body: "b", RemarkableID: 1, RemarkableType: "articles"},
+		},
+	}, article)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_savePolymorphicHasOne(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		photo   = Photo{
+			Remark: &Remark{Body: "a"},
+		}
+		polymorphic = mock.MatchedBy(func(mutates map[string]Mutate) bool {
+			return mutates["remarkable_type"] == Set("remarkable_type", "image") &&
+				mutates["remarkable_id"] == Set("remarkable_id", 1)
+		})
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("photos"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Insert", From("remarks"), polymorphic, OnConflict{}).Return(2, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &photo))
+	assert.Equal(t, Photo{
+		ID:     1,
+		Remark: &Remark{ID: 2, Body: "a", RemarkableID: 1, RemarkableType: "image"},
+	}, photo)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Insert_savePolymorphicBelongsTo(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		remark  = Remark{
+			Body:    "a",
+			Article: &Article{Title: "rel"},
+		}
+		polymorphic = mock.MatchedBy(func(mutates map[string]Mutate) bool {
+			return mutates["remarkable_type"] == Set("remarkable_type", "articles") &&
+				mutates["remarkable_id"] == Set("remarkable_id", 1)
+		})
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Insert", From("articles"), mock.Anything, OnConflict{}).Return(1, nil).Once()
+	adapter.On("Insert", From("remarks"), polymorphic, OnConflict{}).Return(2, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Insert(context.TODO(), &remark))
+	assert.Equal(t, Remark{
+		ID:             2,
+		Body:           "a",
+		RemarkableID:   1,
+		RemarkableType: "articles",
+		Article:        &Article{ID: 1, Title: "rel"},
+	}, remark)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Update_savePolymorphicHasMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		article = Article{
+			ID:      1,
+			Remarks: []Remark{{Body: "a"}},
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Update", From("articles").Where(Eq("id", 1)), mock.Anything).Return(1, nil).Once()
+	adapter.On("Delete", From("remarks").Where(Eq("remarkable_id", 1).AndEq("remarkable_type", "articles"))).Return(1, nil).Once()
+	adapter.On("InsertAll", From("remarks"), mock.Anything, mock.Anything, OnConflict{}).Return([]interface{}{2}, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Update(context.TODO(), &article))
+	assert.Equal(t, []Remark{{ID: 2, Body: "a", RemarkableID: 1, RemarkableType: "articles"}}, article.Remarks)
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Delete_polymorphicHasMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		article = Article{
+			ID:      1,
+			Remarks: []Remark{{ID: 5, RemarkableID: 1, RemarkableType: "articles"}},
+		}
+	)
+
+	adapter.On("Begin").Return(nil).Once()
+	adapter.On("Delete", From("remarks").Where(Eq("remarkable_id", 1).AndIn("id", 5).AndEq("remarkable_type", "articles"))).Return(1, nil).Once()
+	adapter.On("Delete", From("articles").Where(Eq("id", 1))).Return(1, nil).Once()
+	adapter.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Delete(context.TODO(), &article, Cascade(true)))
+
+	adapter.AssertExpectations(t)
+}
+
+func TestRepository_Preload_polymorphicHasMany(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		article = Article{ID: 1}
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("remarks").Where(In("remarkable_id", 1).AndEq("remarkable_type", "articles"))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "remarkable_id", "remarkable_type"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(2, 1, "articles").Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &article, "remarks"))
+	assert.Equal(t, []Remark{{ID: 2, RemarkableID: 1, RemarkableType: "articles"}}, article.Remarks)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_polymorphicBelongsTo(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		remarks = []Remark{
+			{ID: 1, RemarkableID: 1, RemarkableType: "articles"},
+			{ID: 2, RemarkableID: 1, RemarkableType: "image"},
+		}
+		cur = &testCursor{}
+	)
+
+	adapter.On("Query", From("articles").Where(In("id", 1))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &remarks, "article"))
+	assert.Equal(t, &Article{ID: 1}, remarks[0].Article)
+	assert.Nil(t, remarks[1].Article)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Find_preload(t *testing.T) {
+	var (
+		user    User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		query   = From("users").Where(Eq("id", 10)).Preload("transactions", Eq("item", "soap"), Preload("histories", NewSortAsc("id")))
+		userCur = &testCursor{}
+		trxCur  = &testCursor{}
+		histCur = &testCursor{}
+	)
+
+	adapter.On("Query", From("users").Where(Eq("id", 10)).Limit(1)).Return(userCur, nil).Once()
+	adapter.On("Query", From("transactions").Where(Eq("item", "soap"), In("user_id", 10))).Return(trxCur, nil).Once()
+	adapter.On("Query", From("histories").Where(In("transaction_id", 5)).SortAsc("id")).Return(histCur, nil).Once()
+
+	userCur.On("Close").Return(nil).Once()
+	userCur.On("Fields").Return([]string{"id"}, nil).Once()
+	userCur.On("Next").Return(true).Once()
+	userCur.MockScan(10).Once()
+
+	trxCur.On("Close").Return(nil).Once()
+	trxCur.On("Fields").Return([]string{"id", "user_id"}, nil).Once()
+	trxCur.On("Next").Return(true).Once()
+	trxCur.MockScan(5, 10).Twice()
+	trxCur.On("Next").Return(false).Once()
+
+	histCur.On("Close").Return(nil).Once()
+	histCur.On("Fields").Return([]string{"id", "transaction_id"}, nil).Once()
+	histCur.On("Next").Return(true).Once()
+	histCur.MockScan(1, 5).Twice()
+	histCur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &user, query))
+	assert.Equal(t, 10, user.ID)
+	assert.Equal(t, []Transaction{
+		{ID: 5, BuyerID: 10, Histories: &[]History{{ID: 1, TransactionID: 5}}},
+	}, user.Transactions)
+
+	adapter.AssertExpectations(t)
+	userCur.AssertExpectations(t)
+	trxCur.AssertExpectations(t)
+	histCur.AssertExpectations(t)
+}
+
+func TestRepository_FindAll_preloadError(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = createCursor(1)
+		err     = errors.New("error")
+	)
+
+	adapter.On("Query", From("users")).Return(cur, nil).Once()
+	adapter.On("Query", From("transactions").Where(In("user_id", 10))).Return(&testCursor{}, err).Once()
+
+	assert.Equal(t, err, repo.FindAll(context.TODO(), &users, Preload("transactions")))
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_nestedLoaded(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		user    = User{ID: 10, Transactions: []Transaction{{ID: 5, BuyerID: 10}}}
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("histories").Where(In("transaction_id", 5))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "transaction_id"}, nil).Once()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &user, "transactions", Preload("histories")))
+	assert.Equal(t, &[]History{}, user.Transactions[0].Histories)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_FindAndCountAll_preload(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = createCursor(0)
+		query   = From("users").Limit(10)
+	)
+
+	adapter.On("Query", query).Return(cur, nil).Once()
+	adapter.On("Aggregate", From("users"), "count", "*").Return(0, nil).Once()
+
+	count, err := repo.FindAndCountAll(context.TODO(), &users, query.Preload("transactions"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Find_preloadJoin(t *testing.T) {
+	var (
+		address Address
+		userID  = 10
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = &testCursor{}
+	)
+
+	adapter.On("Query", From("addresses").
+		Select("addresses.*", "user.id AS user.id", "user.name AS user.name", "user.age AS user.age", "user.created_at AS user.created_at", "user.updated_at AS user.updated_at").
+		JoinWith("LEFT JOIN", "users AS user", "addresses.user_id", "user.id").
+		Where(Eq("addresses.id", 1), Nil("addresses.deleted_at")).
+		Limit(1)).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id", "street", "user.id", "user.name"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 10, "Grove Street", 10, "Del Piero").Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &address, Eq("id", 1), PreloadJoin("user")))
+	assert.Equal(t, Address{
+		ID:     1,
+		UserID: &userID,
+		Street: "Grove Street",
+		User:   &User{ID: 10, Name: "Del Piero"},
+	}, address)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_FindAll_preloadJoin(t *testing.T) {
+	var (
+		transactions []Transaction
+		adapter      = &testAdapter{}
+		repo         = New(adapter)
+		cur          = &testCursor{}
+		trxCur       = &testCursor{}
+		query        = From("transactions").Select("id", "user_id").Where(Eq("status", "paid")).SortAsc("id")
+	)
+
+	adapter.On("Query", From("transactions").
+		Select("transactions.id", "transactions.user_id", "buyer.id AS buyer.id", "buyer.name AS buyer.name", "buyer.age AS buyer.age", "buyer.created_at AS buyer.created_at", "buyer.updated_at AS buyer.updated_at").
+		JoinWith("LEFT JOIN", "users AS buyer", "transactions.user_id", "buyer.id").
+		Where(Eq("transactions.status", "paid")).
+		SortAsc("transactions.id")).Return(cur, nil).Once()
+	adapter.On("Query", From("transactions").Where(In("user_id", 10))).Return(trxCur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id", "buyer.id", "buyer.name"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(1, 10, 10, "Del Piero").Once()
+	cur.MockScan(2, nil, nil, nil).Once()
+	cur.On("Next").Return(false).Once()
+
+	trxCur.On("Close").Return(nil).Once()
+	trxCur.On("Fields").Return([]string{"id", "user_id"}, nil).Once()
+	trxCur.On("Next").Return(true).Once()
+	trxCur.MockScan(1, 10).Twice()
+	trxCur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &transactions, query, PreloadJoin("buyer", Preload("transactions"))))
+	assert.Equal(t, []Transaction{
+		{ID: 1, BuyerID: 10, Buyer: User{ID: 10, Name: "Del Piero", Transactions: []Transaction{{ID: 1, BuyerID: 10}}}},
+		{ID: 2},
+	}, transactions)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+	trxCur.AssertExpectations(t)
+}
+
+func TestRepository_FindAll_preloadJoinHasMany(t *testing.T) {
+	var (
+		users   []User
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		cur     = createCursor(1)
+		trxCur  = &testCursor{}
+	)
+
+	adapter.On("Query", From("users")).Return(cur, nil).Once()
+	adapter.On("Query", From("transactions").Where(In("user_id", 10))).Return(trxCur, nil).Once()
+
+	trxCur.On("Close").Return(nil).Once()
+	trxCur.On("Fields").Return([]string{"id", "user_id"}, nil).Once()
+	trxCur.On("Next").Return(true).Once()
+	trxCur.MockScan(1, 10).Twice()
+	trxCur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &users, PreloadJoin("transactions")))
+	assert.Equal(t, []User{{ID: 10, Transactions: []Transaction{{ID: 1, BuyerID: 10}}}}, users)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+	trxCur.AssertExpectations(t)
+}
+
+func TestRepository_FindAll_preloadJoinPolymorphic(t *testing.T) {
+	var (
+		remarks    []Remark
+		adapter    = &testAdapter{}
+		repo       = New(adapter)
+		cur        = &testCursor{}
+		articleCur = &testCursor{}
+	)
+
+	adapter.On("Query", From("remarks")).Return(cur, nil).Once()
+	adapter.On("Query", From("articles").Where(In("id", 10))).Return(articleCur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "remarkable_id", "remarkable_type"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 10, "articles").Once()
+	cur.On("Next").Return(false).Once()
+
+	articleCur.On("Close").Return(nil).Once()
+	articleCur.On("Fields").Return([]string{"id", "title"}, nil).Once()
+	articleCur.On("Next").Return(true).Once()
+	articleCur.MockScan(10, "rel").Twice()
+	articleCur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &remarks, PreloadJoin("article")))
+	assert.Equal(t, []Remark{{ID: 1, RemarkableID: 10, RemarkableType: "articles", Article: &Article{ID: 10, Title: "rel"}}}, remarks)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+	articleCur.AssertExpectations(t)
+}
+
+func TestRepository_FindAll_preloadJoinSoftDelete(t *testing.T) {
+	var (
+		transactions []Transaction
+		adapter      = &testAdapter{}
+		repo         = New(adapter)
+		cur          = &testCursor{}
+		addressCur   = &testCursor{}
+	)
+
+	// address is soft deleted, thus not loaded.
+	adapter.On("Query", From("transactions")).Return(cur, nil).Once()
+	adapter.On("Query", From("addresses").Where(In("id", 5), Nil("deleted_at"))).Return(addressCur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "address_id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 5).Once()
+	cur.On("Next").Return(false).Once()
+
+	addressCur.On("Close").Return(nil).Once()
+	addressCur.On("Fields").Return([]string{"id"}, nil).Once()
+	addressCur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &transactions, PreloadJoin("address")))
+	assert.Equal(t, []Transaction{{ID: 1, AddressID: 5}}, transactions)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+	addressCur.AssertExpectations(t)
+}
+
+func TestRepository_Find_preloadJoinTenant(t *testing.T) {
+	var (
+		member     Member
+		adapter    = &testAdapter{}
+		repo       = New(adapter)
+		ctx        = WithTenant(context.TODO(), 5)
+		cur        = &testCursor{}
+		projectCur = &testCursor{}
+	)
+
+	// project belongs to other tenant, thus not loaded.
+	repo.Tenant("tenant_id")
+	adapter.On("Query", From("members").Where(Eq("id", 1)).Limit(1)).Return(cur, nil).Once()
+	adapter.On("Query", From("projects").Where(In("id", 2), Eq("tenant_id", 5))).Return(projectCur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "project_id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, 2).Once()
+
+	projectCur.On("Close").Return(nil).Once()
+	projectCur.On("Fields").Return([]string{"id"}, nil).Once()
+	projectCur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Find(ctx, &member, Eq("id", 1), PreloadJoin("project")))
+	assert.Equal(t, Member{ID: 1, ProjectID: 2}, member)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+	projectCur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_limitPerParent(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		users   = []User{{ID: 10}, {ID: 20}}
+		cur     = &testCursor{}
+		query   = From("transactions").SortDesc("id").LimitPartition("user_id", 1)
+	)
+
+	adapter.On("Query", query.Where(In("user_id", 10, 20))).Return(cur, nil).Maybe()
+	adapter.On("Query", query.Where(In("user_id", 20, 10))).Return(cur, nil).Maybe()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "user_id"}, nil).Once()
+	cur.On("Next").Return(true).Twice()
+	cur.MockScan(15, 20).Twice()
+	cur.MockScan(10, 10).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &users, "transactions", NewSortDesc("id"), LimitPerParent(1)))
+	assert.Equal(t, []Transaction{{ID: 10, BuyerID: 10}}, users[0].Transactions)
+	assert.Equal(t, []Transaction{{ID: 15, BuyerID: 20}}, users[1].Transactions)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestRepository_Preload_manyToManyLimitPerParent(t *testing.T) {
+	var (
+		adapter = &testAdapter{}
+		repo    = New(adapter)
+		post    = Post{ID: 10}
+		cur     = &testCursor{}
+		query   = From("tags").
+			Select("tags.*", "post_tags.post_id").
+			JoinOn("post_tags", "post_tags.tag_id", "tags.id").
+			LimitPartition("post_tags.post_id", 1)
+	)
+
+	adapter.On("Query", query.Where(In("post_tags.post_id", 10))).Return(cur, nil).Once()
+
+	cur.On("Close").Return(nil).Once()
+	cur.On("Fields").Return([]string{"id", "name", "post_id"}, nil).Once()
+	cur.On("Next").Return(true).Once()
+	cur.MockScan(1, "go", 10).Twice()
+	cur.On("Next").Return(false).Once()
+
+	assert.Nil(t, repo.Preload(context.TODO(), &post, "tags", LimitPerParent(1)))
+	assert.Equal(t, []Tag{{ID: 1, Name: "go"}}, post.Tags)
+
+	adapter.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
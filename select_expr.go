@@ -0,0 +1,126 @@
+package rel
+
+// SelectExprOp defines enumeration of all supported select expression types.
+type SelectExprOp int
+
+const (
+	// SelectFieldOp is select expression type for field.
+	SelectFieldOp SelectExprOp = iota
+	// SelectAggregateOp is select expression type for aggregate function.
+	SelectAggregateOp
+	// SelectRowNumberOp is select expression type for row number window function.
+	SelectRowNumberOp
+	// SelectCaseOp is select expression type for case expression.
+	SelectCaseOp
+)
+
+// SelectExpr defines typed expression in select clause.
+// The result can be scanned into struct field using the alias as the column name.
+type SelectExpr struct {
+	Type     SelectExprOp
+	Function string
+	Field    string
+	Cases    []SelectCase
+	Else     interface{}
+	Window   *Window
+	Alias    string
+}
+
+// Build query.
+func (se SelectExpr) Build(query *Query) {
+	query.SelectQuery.Exprs = append(query.SelectQuery.Exprs, se)
+}
+
+// As sets alias of the expression.
+func (se SelectExpr) As(alias string) SelectExpr {
+	se.Alias = alias
+	return se
+}
+
+// Over turns the expression into window function evaluated over given window.
+func (se SelectExpr) Over(window Window) SelectExpr {
+	se.Window = &window
+	return se
+}
+
+// When adds a branch to case expression.
+// Value can be either a SelectExpr or a value that will be passed as argument.
+func (se SelectExpr) When(filter FilterQuery, value interface{}) SelectExpr {
+	se.Cases = append(se.Cases, SelectCase{When: filter, Then: value})
+	return se
+}
+
+// ElseValue sets value of case expression when none of the branches matches.
+func (se SelectExpr) ElseValue(value interface{}) SelectExpr {
+	se.Else = value
+	return se
+}
+
+// SelectCase defines a branch of case expression.
+type SelectCase struct {
+	When FilterQuery
+	Then interface{}
+}
+
+// Window defines partition and order of rows used by window function.
+type Window struct {
+	Partition []string
+	Sort      []SortQuery
+}
+
+// SortAsc sort rows in the window ascending by specified fields.
+func (w Window) SortAsc(fields ...string) Window {
+	for i := range fields {
+		w.Sort = append(w.Sort, NewSortAsc(fields[i]))
+	}
+
+	return w
+}
+
+// SortDesc sort rows in the window descending by specified fields.
+func (w Window) SortDesc(fields ...string) Window {
+	for i := range fields {
+		w.Sort = append(w.Sort, NewSortDesc(fields[i]))
+	}
+
+	return w
+}
+
+// NewWindow partitioned by given fields.
+func NewWindow(partition ...string) Window {
+	return Window{
+		Partition: partition,
+	}
+}
+
+// NewSelectField expression.
+func NewSelectField(field string) SelectExpr {
+	return SelectExpr{
+		Type:  SelectFieldOp,
+		Field: field,
+	}
+}
+
+// NewSelectAggregate expression using given function and field.
+func NewSelectAggregate(function string, field string) SelectExpr {
+	return SelectExpr{
+		Type:     SelectAggregateOp,
+		Function: function,
+		Field:    field,
+	}
+}
+
+// NewRowNumber expression.
+// It should be used together with Over to define the window.
+func NewRowNumber() SelectExpr {
+	return SelectExpr{
+		Type: SelectRowNumberOp,
+	}
+}
+
+// NewCase expression, use When to add the branches.
+func NewCase() SelectExpr {
+	return SelectExpr{
+		Type: SelectCaseOp,
+	}
+}
@@ -0,0 +1,75 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectExpr(t *testing.T) {
+	assert.Equal(t, rel.SelectExpr{
+		Type:  rel.SelectFieldOp,
+		Field: "name",
+		Alias: "username",
+	}, rel.NewSelectField("name").As("username"))
+}
+
+func TestSelectExpr_aggregate(t *testing.T) {
+	assert.Equal(t, rel.SelectExpr{
+		Type:     rel.SelectAggregateOp,
+		Function: "sum",
+		Field:    "total",
+		Alias:    "total",
+	}, rel.NewSelectAggregate("sum", "total").As("total"))
+}
+
+func TestSelectExpr_window(t *testing.T) {
+	assert.Equal(t, rel.SelectExpr{
+		Type: rel.SelectRowNumberOp,
+		Window: &rel.Window{
+			Partition: []string{"user_id"},
+			Sort:      []rel.SortQuery{rel.NewSortDesc("created_at"), rel.NewSortAsc("id")},
+		},
+		Alias: "rank",
+	}, rel.NewRowNumber().Over(rel.NewWindow("user_id").SortDesc("created_at").SortAsc("id")).As("rank"))
+}
+
+func TestSelectExpr_case(t *testing.T) {
+	assert.Equal(t, rel.SelectExpr{
+		Type: rel.SelectCaseOp,
+		Cases: []rel.SelectCase{
+			{When: where.Gte("total", 1000), Then: "gold"},
+			{When: where.Gte("total", 500), Then: "silver"},
+		},
+		Else:  "bronze",
+		Alias: "tier",
+	}, rel.NewCase().
+		When(where.Gte("total", 1000), "gold").
+		When(where.Gte("total", 500), "silver").
+		ElseValue("bronze").
+		As("tier"))
+}
+
+func TestSelectExpr_Build(t *testing.T) {
+	var (
+		total = rel.NewSelectAggregate("sum", "total").As("total")
+	)
+
+	assert.Equal(t, rel.Query{
+		Table: "transactions",
+		SelectQuery: rel.SelectQuery{
+			Fields: []string{"user_id"},
+			Exprs:  []rel.SelectExpr{total},
+		},
+	}, rel.Build("transactions", rel.Select("user_id"), total))
+
+	assert.Equal(t, rel.Query{
+		Table: "transactions",
+		SelectQuery: rel.SelectQuery{
+			Fields: []string{"user_id"},
+			Exprs:  []rel.SelectExpr{total},
+		},
+	}, rel.Build("", rel.Select("user_id").From("transactions").SelectExpr(total)))
+}
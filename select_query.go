@@ -1,9 +1,11 @@
 package rel
 
 // SelectQuery defines select clause of the query.
+// Exprs are selected after the fields.
 type SelectQuery struct {
 	OnlyDistinct bool
 	Fields       []string
+	Exprs        []SelectExpr
 }
 
 // Distinct select query.
@@ -0,0 +1,618 @@
+package rel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrShardKeyNotFound returned when insert operation doesn't contain shard key.
+	ErrShardKeyNotFound = errors.New("rel: shard key not found")
+)
+
+// ShardKey returns index of the shard for given table.
+// values contains equality filters of the query, or the inserted values in case of insert operation.
+// Returning false will cause read, update and delete operations to be executed on all shards.
+type ShardKey func(table string, values map[string]interface{}) (int, bool)
+
+type sharding struct {
+	key    ShardKey
+	shards []Adapter
+}
+
+// Instrumentation set instrumenter for all shards.
+func (s *sharding) Instrumentation(instrumenter Instrumenter) {
+	for i := range s.shards {
+		s.shards[i].Instrumentation(instrumenter)
+	}
+}
+
+// Ping all shards.
+func (s *sharding) Ping(ctx context.Context) error {
+	for i := range s.shards {
+		if err := s.shards[i].Ping(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Aggregate from the matching shard, or combine results from all shards if shard key is not present.
+// Fan out is supported for count, sum, max and min.
+func (s *sharding) Aggregate(ctx context.Context, query Query, aggregate string, field string) (int, error) {
+	shard, err := s.shard(query.Table, filterValues(query.WhereQuery))
+	if err != nil {
+		return 0, err
+	}
+
+	if shard != nil {
+		return shard.Aggregate(ctx, query, aggregate, field)
+	}
+
+	if aggregate != "count" && aggregate != "sum" && aggregate != "max" && aggregate != "min" {
+		return 0, fmt.Errorf("rel: aggregate %s is not supported across shards", aggregate)
+	}
+
+	var (
+		result int
+		found  bool
+	)
+
+	for i := range s.shards {
+		// max and min of empty shard is null, which is returned as zero, skip it.
+		if aggregate == "max" || aggregate == "min" {
+			if count, err := s.shards[i].Aggregate(ctx, query, "count", field); err != nil {
+				return 0, err
+			} else if count == 0 {
+				continue
+			}
+		}
+
+		value, err := s.shards[i].Aggregate(ctx, query, aggregate, field)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case !found:
+			found = true
+			result = value
+		case aggregate == "max" && value > result, aggregate == "min" && value < result:
+			result = value
+		case aggregate == "count", aggregate == "sum":
+			result += value
+		}
+	}
+
+	return result, nil
+}
+
+// Query from the matching shard, or query all shards if shard key is not present.
+// When querying all shards, records are merged using the sort order of the query, then offset and limit are applied to the merged records.
+// Sort fields must be selected by the query, records without sort order are returned shard by shard.
+// Grouped query is not supported across shards, since rows of the same group can't be combined without knowing the aggregates.
+func (s *sharding) Query(ctx context.Context, query Query) (Cursor, error) {
+	shard, err := s.shard(query.Table, filterValues(query.WhereQuery))
+	if err != nil {
+		return nil, err
+	}
+
+	if shard != nil {
+		return shard.Query(ctx, query)
+	}
+
+	if len(query.GroupQuery.Fields) > 0 && len(s.shards) > 1 {
+		return nil, errors.New("rel: group query is not supported across shards")
+	}
+
+	var (
+		shardQuery = query
+		cur        = &shardCursor{
+			cursors: make([]Cursor, 0, len(s.shards)),
+			sorts:   query.SortQuery,
+			offset:  int(query.OffsetQuery),
+			limit:   int(query.LimitQuery),
+		}
+	)
+
+	// any shard may contain the whole page, fetch every record up to the end of the page.
+	shardQuery.OffsetQuery = 0
+	if query.LimitQuery > 0 {
+		shardQuery.LimitQuery = query.LimitQuery + Limit(query.OffsetQuery)
+	}
+
+	for i := range s.shards {
+		c, err := s.shards[i].Query(ctx, shardQuery)
+		if err != nil {
+			cur.Close()
+			return nil, err
+		}
+
+		cur.cursors = append(cur.cursors, c)
+	}
+
+	if err := cur.init(); err != nil {
+		cur.Close()
+		return nil, err
+	}
+
+	return cur, nil
+}
+
+// Insert to the matching shard.
+func (s *sharding) Insert(ctx context.Context, query Query, primaryField string, mutates map[string]Mutate, onConflict OnConflict) (interface{}, error) {
+	shard, err := s.insertShard(query.Table, mutates)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.shards[shard].Insert(ctx, query, primaryField, mutates, onConflict)
+}
+
+// InsertAll groups records by their shard and insert them to each shard.
+func (s *sharding) InsertAll(ctx context.Context, query Query, primaryField string, fields []string, bulkMutates []map[string]Mutate, onConflict OnConflict) ([]interface{}, error) {
+	var (
+		groups  = make(map[int][]int, len(s.shards))
+		ordered = make([]int, 0, len(s.shards))
+		ids     = make([]interface{}, len(bulkMutates))
+	)
+
+	for i := range bulkMutates {
+		shard, err := s.insertShard(query.Table, bulkMutates[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := groups[shard]; !ok {
+			ordered = append(ordered, shard)
+		}
+
+		groups[shard] = append(groups[shard], i)
+	}
+
+	for _, shard := range ordered {
+		var (
+			indexes = groups[shard]
+			mutates = make([]map[string]Mutate, len(indexes))
+		)
+
+		for i, index := range indexes {
+			mutates[i] = bulkMutates[index]
+		}
+
+		result, err := s.shards[shard].InsertAll(ctx, query, primaryField, fields, mutates, onConflict)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range result {
+			if i < len(indexes) {
+				ids[indexes[i]] = result[i]
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// Update records in the matching shard, or in all shards if shard key is not present.
+func (s *sharding) Update(ctx context.Context, query Query, mutates map[string]Mutate) (int, error) {
+	return s.exec(query, func(shard Adapter) (int, error) {
+		return shard.Update(ctx, query, mutates)
+	})
+}
+
+// Delete records in the matching shard, or in all shards if shard key is not present.
+func (s *sharding) Delete(ctx context.Context, query Query) (int, error) {
+	return s.exec(query, func(shard Adapter) (int, error) {
+		return shard.Delete(ctx, query)
+	})
+}
+
+// Begin transaction in all shards.
+// Transaction across shards is not atomic, commit in a shard may succeed while the others failed.
+func (s *sharding) Begin(ctx context.Context) (Adapter, error) {
+	var (
+		tx = &sharding{key: s.key, shards: make([]Adapter, 0, len(s.shards))}
+	)
+
+	for i := range s.shards {
+		shard, err := s.shards[i].Begin(ctx)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+
+		tx.shards = append(tx.shards, shard)
+	}
+
+	return tx, nil
+}
+
+// Commit transaction in all shards.
+func (s *sharding) Commit(ctx context.Context) error {
+	for i := range s.shards {
+		if err := s.shards[i].Commit(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rollback transaction in all shards.
+func (s *sharding) Rollback(ctx context.Context) error {
+	var (
+		err error
+	)
+
+	for i := range s.shards {
+		if e := s.shards[i].Rollback(ctx); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// Apply migration to all shards.
+func (s *sharding) Apply(ctx context.Context, migration Migration) error {
+	for i := range s.shards {
+		if err := s.shards[i].Apply(ctx, migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sharding) exec(query Query, fn func(shard Adapter) (int, error)) (int, error) {
+	shard, err := s.shard(query.Table, filterValues(query.WhereQuery))
+	if err != nil {
+		return 0, err
+	}
+
+	if shard != nil {
+		return fn(shard)
+	}
+
+	var (
+		total int
+	)
+
+	for i := range s.shards {
+		count, err := fn(s.shards[i])
+		if err != nil {
+			return 0, err
+		}
+
+		total += count
+	}
+
+	return total, nil
+}
+
+// shard returns nil when shard key is not present.
+func (s *sharding) shard(table string, values map[string]interface{}) (Adapter, error) {
+	index, ok := s.key(table, values)
+	if !ok {
+		return nil, nil
+	}
+
+	if index < 0 || index >= len(s.shards) {
+		return nil, fmt.Errorf("rel: shard index %d out of range", index)
+	}
+
+	return s.shards[index], nil
+}
+
+func (s *sharding) insertShard(table string, mutates map[string]Mutate) (int, error) {
+	var (
+		values = make(map[string]interface{}, len(mutates))
+	)
+
+	for field, mut := range mutates {
+		if mut.Type == ChangeSetOp {
+			values[field] = mut.Value
+		}
+	}
+
+	index, ok := s.key(table, values)
+	if !ok {
+		return 0, ErrShardKeyNotFound
+	}
+
+	if index < 0 || index >= len(s.shards) {
+		return 0, fmt.Errorf("rel: shard index %d out of range", index)
+	}
+
+	return index, nil
+}
+
+// filterValues collects equality filters that are required by the query.
+func filterValues(filter FilterQuery) map[string]interface{} {
+	var (
+		values = make(map[string]interface{})
+	)
+
+	collectFilterValues(filter, values)
+	return values
+}
+
+func collectFilterValues(filter FilterQuery, values map[string]interface{}) {
+	switch filter.Type {
+	case FilterEqOp:
+		values[filter.Field] = filter.Value
+	case FilterAndOp:
+		for i := range filter.Inner {
+			collectFilterValues(filter.Inner[i], values)
+		}
+	}
+}
+
+// NewSharding returns adapter that routes each operation to one of the shards using shard key function.
+// Operations without shard key are executed in all shards, cursors are merged and aggregate results are combined.
+// Insert operations without shard key returns ErrShardKeyNotFound.
+func NewSharding(key ShardKey, shards ...Adapter) Adapter {
+	return &sharding{
+		key:    key,
+		shards: shards,
+	}
+}
+
+// shardCursor merges cursors from multiple shards.
+type shardCursor struct {
+	cursors []Cursor
+	sorts   []SortQuery
+	offset  int
+	limit   int
+	fields  []string
+	index   []int
+	values  [][]interface{}
+	ready   []bool
+	started bool
+	current int
+	count   int
+	err     error
+}
+
+// init locates column of the sort fields, used to compare the current record of each cursor.
+func (sc *shardCursor) init() error {
+	sc.ready = make([]bool, len(sc.cursors))
+	sc.values = make([][]interface{}, len(sc.cursors))
+
+	if len(sc.sorts) == 0 || len(sc.cursors) == 0 {
+		return nil
+	}
+
+	fields, err := sc.Fields()
+	if err != nil {
+		return err
+	}
+
+	sc.index = make([]int, len(sc.sorts))
+	for i := range sc.sorts {
+		sc.index[i] = -1
+		for j := range fields {
+			if fields[j] == keysetField(sc.sorts[i].Field) {
+				sc.index[i] = j
+				break
+			}
+		}
+
+		if sc.index[i] < 0 {
+			return fmt.Errorf("rel: sort field %s must be selected to query across shards", sc.sorts[i].Field)
+		}
+	}
+
+	for i := range sc.values {
+		sc.values[i] = make([]interface{}, len(sc.sorts))
+	}
+
+	return nil
+}
+
+func (sc *shardCursor) Close() error {
+	var (
+		err error
+	)
+
+	for i := range sc.cursors {
+		if e := sc.cursors[i].Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+func (sc *shardCursor) Fields() ([]string, error) {
+	if sc.fields == nil && len(sc.cursors) > 0 {
+		fields, err := sc.cursors[0].Fields()
+		if err != nil {
+			return nil, err
+		}
+
+		sc.fields = fields
+	}
+
+	return sc.fields, nil
+}
+
+func (sc *shardCursor) Next() bool {
+	if sc.err != nil || (sc.limit > 0 && sc.count >= sc.limit) {
+		return false
+	}
+
+	if !sc.started {
+		sc.started = true
+		for i := range sc.cursors {
+			sc.advance(i)
+		}
+	} else {
+		sc.advance(sc.current)
+	}
+
+	for sc.current = sc.pick(); sc.current >= 0 && sc.offset > 0; sc.current = sc.pick() {
+		sc.offset--
+		sc.advance(sc.current)
+	}
+
+	if sc.current < 0 || sc.err != nil {
+		return false
+	}
+
+	sc.count++
+	return true
+}
+
+// advance moves the cursor to the next record and reads the sort values.
+func (sc *shardCursor) advance(i int) {
+	if sc.ready[i] = sc.cursors[i].Next(); !sc.ready[i] || len(sc.sorts) == 0 {
+		return
+	}
+
+	scanners := make([]interface{}, len(sc.fields))
+	for j := range scanners {
+		scanners[j] = sc.cursors[i].NopScanner()
+	}
+
+	for j, index := range sc.index {
+		scanners[index] = &sc.values[i][j]
+	}
+
+	if err := sc.cursors[i].Scan(scanners...); err != nil {
+		sc.err = err
+		sc.ready[i] = false
+	}
+}
+
+// pick returns cursor with the first record in sort order, or -1 if all cursors are exhausted.
+// cursor of the first shard is preferred when the records are equal.
+func (sc *shardCursor) pick() int {
+	selected := -1
+	for i := range sc.cursors {
+		if sc.ready[i] && (selected < 0 || sc.less(i, selected)) {
+			selected = i
+		}
+	}
+
+	return selected
+}
+
+func (sc *shardCursor) less(i, j int) bool {
+	for k := range sc.sorts {
+		c := compareValue(sc.values[i][k], sc.values[j][k])
+		if c == 0 {
+			continue
+		}
+
+		if sc.sorts[k].Desc() {
+			return c > 0
+		}
+
+		return c < 0
+	}
+
+	return false
+}
+
+func (sc *shardCursor) Scan(dest ...interface{}) error {
+	if sc.err != nil {
+		return sc.err
+	}
+
+	return sc.cursors[sc.current].Scan(dest...)
+}
+
+func (sc *shardCursor) NopScanner() interface{} {
+	if len(sc.cursors) == 0 {
+		return nopScanner{}
+	}
+
+	return sc.cursors[0].NopScanner()
+}
+
+// compareValue compares values scanned from database, nil is ordered first.
+func compareValue(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if at, ok := a.(time.Time); ok {
+		if bt, ok := b.(time.Time); ok {
+			switch {
+			case at.Before(bt):
+				return -1
+			case at.After(bt):
+				return 1
+			}
+		}
+
+		return 0
+	}
+
+	if ab, ok := a.([]byte); ok {
+		a = string(ab)
+	}
+
+	if bb, ok := b.([]byte); ok {
+		b = string(bb)
+	}
+
+	var (
+		av = reflect.ValueOf(a)
+		bv = reflect.ValueOf(b)
+	)
+
+	if av.Kind() == reflect.String && bv.Kind() == reflect.String {
+		return strings.Compare(av.String(), bv.String())
+	}
+
+	if av.Kind() == reflect.Bool && bv.Kind() == reflect.Bool {
+		switch {
+		case av.Bool() == bv.Bool():
+			return 0
+		case bv.Bool():
+			return -1
+		}
+
+		return 1
+	}
+
+	af, aok := numberValue(av)
+	bf, bok := numberValue(bv)
+	if aok && bok {
+		return af.Cmp(bf)
+	}
+
+	return 0
+}
+
+// numberValue converts numeric value to big.Float, so integers can be compared with floats without losing precision.
+func numberValue(rv reflect.Value) (*big.Float, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if !math.IsNaN(rv.Float()) {
+			return big.NewFloat(rv.Float()), true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,608 @@
+package rel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func userShard(table string, values map[string]interface{}) (int, bool) {
+	if id, ok := values["tenant_id"].(int); ok {
+		return id % 2, true
+	}
+
+	return 0, false
+}
+
+func TestSharding_Find(t *testing.T) {
+	var (
+		user   User
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+		query  = From("users").Where(Eq("tenant_id", 1), Gt("age", 10)).Limit(1)
+		cur    = createCursor(1)
+	)
+
+	shard1.On("Query", query).Return(cur, nil).Once()
+
+	assert.Nil(t, repo.Find(context.TODO(), &user, query))
+	assert.Equal(t, 10, user.ID)
+	assert.False(t, cur.Next())
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+	cur.AssertExpectations(t)
+}
+
+func TestSharding_FindAll_fanOut(t *testing.T) {
+	var (
+		users  []User
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+		query  = From("users").Where(Eq("tenant_id", 1).OrEq("tenant_id", 2))
+		cur0   = createCursor(2)
+		cur1   = &testCursor{}
+	)
+
+	cur1.On("Next").Return(true).Once()
+	cur1.MockScan(20).Once()
+	cur1.On("Next").Return(false).Once()
+	cur1.On("Close").Return(nil).Once()
+
+	shard0.On("Query", query).Return(cur0, nil).Once()
+	shard1.On("Query", query).Return(cur1, nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &users, query))
+	assert.Len(t, users, 3)
+	assert.Equal(t, 10, users[0].ID)
+	assert.Equal(t, 10, users[1].ID)
+	assert.Equal(t, 20, users[2].ID)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+	cur0.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+}
+
+func TestSharding_FindAll_fanOutSorted(t *testing.T) {
+	var (
+		users  []User
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+		query  = From("users").SortDesc("age").Offset(1).Limit(2)
+		cur0   = &testCursor{}
+		cur1   = &testCursor{}
+	)
+
+	cur0.On("Fields").Return([]string{"id", "age"}, nil).Once()
+	cur0.On("Next").Return(true).Twice()
+	cur0.MockScan(1, 30).Twice()
+	cur0.MockScan(2, 10).Once()
+	cur0.On("Close").Return(nil).Once()
+
+	cur1.On("Next").Return(true).Twice()
+	cur1.MockScan(3, 40).Once()
+	cur1.MockScan(4, 20).Twice()
+	cur1.On("Close").Return(nil).Once()
+
+	// every shard fetches up to offset + limit, and merged page is taken from the combined result.
+	shard0.On("Query", query.Offset(0).Limit(3)).Return(cur0, nil).Once()
+	shard1.On("Query", query.Offset(0).Limit(3)).Return(cur1, nil).Once()
+
+	assert.Nil(t, repo.FindAll(context.TODO(), &users, query))
+	assert.Equal(t, []User{{ID: 1, Age: 30}, {ID: 4, Age: 20}}, users)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+	cur0.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+}
+
+func TestSharding_Query_sortFieldNotSelected(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0)
+		query   = From("users").Select("id").SortAsc("age")
+		cur0    = &testCursor{}
+	)
+
+	cur0.On("Fields").Return([]string{"id"}, nil).Once()
+	cur0.On("Close").Return(nil).Once()
+	shard0.On("Query", query).Return(cur0, nil).Once()
+
+	_, err := adapter.Query(context.TODO(), query)
+	assert.EqualError(t, err, "rel: sort field age must be selected to query across shards")
+
+	shard0.AssertExpectations(t)
+	cur0.AssertExpectations(t)
+}
+
+func TestSharding_Query_group(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users").Group("age")
+		cur1    = &testCursor{}
+	)
+
+	shard1.On("Query", query.Where(Eq("tenant_id", 1))).Return(cur1, nil).Once()
+
+	_, err := adapter.Query(context.TODO(), query)
+	assert.EqualError(t, err, "rel: group query is not supported across shards")
+
+	cur, err := adapter.Query(context.TODO(), query.Where(Eq("tenant_id", 1)))
+	assert.Nil(t, err)
+	assert.Equal(t, cur1, cur)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Query_noShard(t *testing.T) {
+	var (
+		adapter = NewSharding(userShard)
+	)
+
+	cur, err := adapter.Query(context.TODO(), From("users"))
+	assert.Nil(t, err)
+
+	fields, err := cur.Fields()
+	assert.Nil(t, err)
+	assert.Nil(t, fields)
+	assert.False(t, cur.Next())
+	assert.Nil(t, cur.Close())
+}
+
+func TestCompareValue(t *testing.T) {
+	var (
+		now = time.Now()
+	)
+
+	assert.Equal(t, 0, compareValue(nil, nil))
+	assert.Equal(t, -1, compareValue(nil, 1))
+	assert.Equal(t, 1, compareValue(1, nil))
+	assert.Equal(t, -1, compareValue(int64(1), 2.5))
+	assert.Equal(t, 1, compareValue(uint(3), int64(2)))
+	assert.Equal(t, 0, compareValue(int64(9007199254740993), int64(9007199254740993)))
+	assert.Equal(t, -1, compareValue([]byte("a"), "b"))
+	assert.Equal(t, 1, compareValue(true, false))
+	assert.Equal(t, -1, compareValue(now, now.Add(time.Second)))
+	assert.Equal(t, 0, compareValue(now, "now"))
+}
+
+func TestSharding_Query_fanOutError(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users")
+		cur0    = &testCursor{}
+		err     = errors.New("error")
+	)
+
+	cur0.On("Close").Return(nil).Once()
+	shard0.On("Query", query).Return(cur0, nil).Once()
+	shard1.On("Query", query).Return(&testCursor{}, err).Once()
+
+	_, qerr := adapter.Query(context.TODO(), query)
+	assert.Equal(t, err, qerr)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+	cur0.AssertExpectations(t)
+}
+
+func TestSharding_Query_outOfRange(t *testing.T) {
+	var (
+		adapter = NewSharding(func(string, map[string]interface{}) (int, bool) { return 2, true }, &testAdapter{})
+	)
+
+	_, err := adapter.Query(context.TODO(), From("users"))
+	assert.EqualError(t, err, "rel: shard index 2 out of range")
+}
+
+func TestSharding_Aggregate(t *testing.T) {
+	tests := []struct {
+		aggregate string
+		result    int
+	}{
+		{aggregate: "count", result: 5},
+		{aggregate: "sum", result: 5},
+		{aggregate: "max", result: 3},
+		{aggregate: "min", result: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.aggregate, func(t *testing.T) {
+			var (
+				shard0  = &testAdapter{}
+				shard1  = &testAdapter{}
+				adapter = NewSharding(userShard, shard0, shard1)
+				query   = From("users")
+			)
+
+			if test.aggregate == "max" || test.aggregate == "min" {
+				shard0.On("Aggregate", query, "count", "age").Return(1, nil).Once()
+				shard1.On("Aggregate", query, "count", "age").Return(1, nil).Once()
+			}
+
+			shard0.On("Aggregate", query, test.aggregate, "age").Return(2, nil).Once()
+			shard1.On("Aggregate", query, test.aggregate, "age").Return(3, nil).Once()
+
+			result, err := adapter.Aggregate(context.TODO(), query, test.aggregate, "age")
+			assert.Nil(t, err)
+			assert.Equal(t, test.result, result)
+
+			shard0.AssertExpectations(t)
+			shard1.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSharding_Aggregate_emptyShard(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users")
+	)
+
+	shard0.On("Aggregate", query, "count", "age").Return(0, nil).Once()
+	shard1.On("Aggregate", query, "count", "age").Return(2, nil).Once()
+	shard1.On("Aggregate", query, "min", "age").Return(3, nil).Once()
+
+	result, err := adapter.Aggregate(context.TODO(), query, "min", "age")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_AggregateFloat(t *testing.T) {
+	var (
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+		query  = From("users").SelectExpr(NewSelectAggregate("sum", "balance").As("sum"))
+		cur0   = &testCursor{}
+		cur1   = &testCursor{}
+	)
+
+	cur0.On("Next").Return(true).Once()
+	cur0.MockScan([]byte("10.25")).Once()
+	cur0.On("Next").Return(false).Once()
+	cur0.On("Close").Return(nil).Once()
+	cur1.On("Next").Return(true).Once()
+	cur1.MockScan([]byte("2.5")).Once()
+	cur1.On("Next").Return(false).Once()
+	cur1.On("Close").Return(nil).Once()
+
+	shard0.On("Query", query).Return(cur0, nil).Once()
+	shard1.On("Query", query).Return(cur1, nil).Once()
+
+	sum, err := repo.AggregateFloat(context.TODO(), From("users"), "sum", "balance")
+	assert.Nil(t, err)
+	assert.Equal(t, 12.75, sum)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+	cur0.AssertExpectations(t)
+	cur1.AssertExpectations(t)
+}
+
+func TestSharding_Aggregate_shard(t *testing.T) {
+	var (
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+		query  = From("users").Where(Eq("tenant_id", 2))
+	)
+
+	shard0.On("Aggregate", query, "count", "*").Return(3, nil).Once()
+
+	count, err := repo.Count(context.TODO(), "users", Eq("tenant_id", 2))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Aggregate_unsupported(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0)
+	)
+
+	_, err := adapter.Aggregate(context.TODO(), From("users"), "avg", "age")
+	assert.EqualError(t, err, "rel: aggregate avg is not supported across shards")
+
+	shard0.AssertExpectations(t)
+}
+
+func TestSharding_Aggregate_error(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users")
+		err     = errors.New("error")
+	)
+
+	shard0.On("Aggregate", query, "count", "*").Return(0, err).Once()
+
+	_, aerr := adapter.Aggregate(context.TODO(), query, "count", "*")
+	assert.Equal(t, err, aerr)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Insert(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users")
+		mutates = map[string]Mutate{
+			"name":      Set("name", "name"),
+			"tenant_id": Set("tenant_id", 1),
+		}
+	)
+
+	shard1.On("Insert", query, mutates, OnConflict{}).Return(1, nil).Once()
+
+	id, err := adapter.Insert(context.TODO(), query, "id", mutates, OnConflict{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, id)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Insert_shardKeyNotFound(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0)
+		mutates = map[string]Mutate{
+			"name":      Set("name", "name"),
+			"tenant_id": Inc("tenant_id"),
+		}
+	)
+
+	_, err := adapter.Insert(context.TODO(), From("users"), "id", mutates, OnConflict{})
+	assert.Equal(t, ErrShardKeyNotFound, err)
+
+	shard0.AssertExpectations(t)
+}
+
+func TestSharding_InsertAll(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users")
+		fields  = []string{"name", "tenant_id"}
+		mutates = []map[string]Mutate{
+			{"name": Set("name", "a"), "tenant_id": Set("tenant_id", 1)},
+			{"name": Set("name", "b"), "tenant_id": Set("tenant_id", 2)},
+			{"name": Set("name", "c"), "tenant_id": Set("tenant_id", 3)},
+		}
+	)
+
+	shard1.On("InsertAll", query, fields, []map[string]Mutate{mutates[0], mutates[2]}, OnConflict{}).Return([]interface{}{1, 3}, nil).Once()
+	shard0.On("InsertAll", query, fields, []map[string]Mutate{mutates[1]}, OnConflict{}).Return([]interface{}{2}, nil).Once()
+
+	ids, err := adapter.InsertAll(context.TODO(), query, "id", fields, mutates, OnConflict{})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, ids)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_InsertAll_error(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0)
+		query   = From("users")
+		fields  = []string{"name"}
+		mutates = []map[string]Mutate{
+			{"name": Set("name", "a"), "tenant_id": Set("tenant_id", 2)},
+			{"name": Set("name", "b")},
+		}
+		err = errors.New("error")
+	)
+
+	_, ierr := adapter.InsertAll(context.TODO(), query, "id", fields, mutates, OnConflict{})
+	assert.Equal(t, ErrShardKeyNotFound, ierr)
+
+	shard0.On("InsertAll", query, fields, mutates[:1], OnConflict{}).Return([]interface{}{}, err).Once()
+
+	_, ierr = adapter.InsertAll(context.TODO(), query, "id", fields, mutates[:1], OnConflict{})
+	assert.Equal(t, err, ierr)
+
+	shard0.AssertExpectations(t)
+}
+
+func TestSharding_Update(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users").Where(Eq("tenant_id", 2), Eq("id", 1))
+		mutates = map[string]Mutate{"name": Set("name", "name")}
+	)
+
+	shard0.On("Update", query, mutates).Return(1, nil).Once()
+
+	count, err := adapter.Update(context.TODO(), query, mutates)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_UpdateAll_fanOut(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users").Where(Eq("name", "name"))
+		mutates = map[string]Mutate{"age": Inc("age")}
+	)
+
+	shard0.On("Update", query, mutates).Return(2, nil).Once()
+	shard1.On("Update", query, mutates).Return(3, nil).Once()
+
+	count, err := adapter.Update(context.TODO(), query, mutates)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, count)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Delete_fanOutError(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		query   = From("users")
+		err     = errors.New("error")
+	)
+
+	shard0.On("Delete", query).Return(0, err).Once()
+
+	_, derr := adapter.Delete(context.TODO(), query)
+	assert.Equal(t, err, derr)
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Delete_outOfRange(t *testing.T) {
+	var (
+		adapter = NewSharding(userShard, &testAdapter{})
+	)
+
+	_, err := adapter.Delete(context.TODO(), From("users").Where(Eq("tenant_id", 1)))
+	assert.EqualError(t, err, "rel: shard index 1 out of range")
+}
+
+func TestSharding_Transaction(t *testing.T) {
+	var (
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+	)
+
+	shard0.On("Begin").Return(nil).Once()
+	shard1.On("Begin").Return(nil).Once()
+	shard0.On("Commit").Return(nil).Once()
+	shard1.On("Commit").Return(nil).Once()
+
+	assert.Nil(t, repo.Transaction(context.TODO(), func(ctx context.Context) error {
+		return nil
+	}))
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Transaction_beginError(t *testing.T) {
+	var (
+		shard0 = &testAdapter{}
+		shard1 = &testAdapter{}
+		repo   = New(NewSharding(userShard, shard0, shard1))
+		err    = errors.New("error")
+	)
+
+	shard0.On("Begin").Return(nil).Once()
+	shard1.On("Begin").Return(err).Once()
+	shard0.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, repo.Transaction(context.TODO(), func(ctx context.Context) error {
+		return nil
+	}))
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Rollback(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		err     = errors.New("error")
+	)
+
+	shard0.On("Rollback").Return(err).Once()
+	shard1.On("Rollback").Return(nil).Once()
+
+	assert.Equal(t, err, adapter.Rollback(context.TODO()))
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Commit_error(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		err     = errors.New("error")
+	)
+
+	shard0.On("Commit").Return(err).Once()
+
+	assert.Equal(t, err, adapter.Commit(context.TODO()))
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Ping(t *testing.T) {
+	var (
+		shard0  = &testAdapter{}
+		shard1  = &testAdapter{}
+		adapter = NewSharding(userShard, shard0, shard1)
+		err     = errors.New("error")
+	)
+
+	shard0.On("Ping").Return(nil).Once()
+	shard1.On("Ping").Return(err).Once()
+
+	assert.Equal(t, err, adapter.Ping(context.TODO()))
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
+
+func TestSharding_Apply(t *testing.T) {
+	var (
+		shard0    = &testAdapter{}
+		shard1    = &testAdapter{}
+		adapter   = NewSharding(userShard, shard0, shard1)
+		migration = Table{Name: "users"}
+		err       = errors.New("error")
+	)
+
+	shard0.On("Apply", migration).Return(nil).Once()
+	shard1.On("Apply", migration).Return(err).Once()
+
+	assert.Equal(t, err, adapter.Apply(context.TODO(), migration))
+
+	shard0.AssertExpectations(t)
+	shard1.AssertExpectations(t)
+}
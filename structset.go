@@ -28,7 +28,7 @@ func (s Structset) Apply(doc *Document, mut *Mutation) {
 		switch field {
 		case "created_at", "inserted_at":
 			if doc.Flag(HasCreatedAt) {
-				if value, ok := doc.Value(field); ok && value.(time.Time).IsZero() {
+				if value, ok := doc.Value(field); ok && isZero(value) {
 					s.set(doc, mut, field, t, true)
 					continue
 				}
@@ -83,6 +83,10 @@ func (s Structset) applyAssoc(mut *Mutation) {
 	for _, field := range s.doc.HasMany() {
 		s.buildAssocMany(field, mut)
 	}
+
+	for _, field := range s.doc.ManyToMany() {
+		s.buildAssocMany(field, mut)
+	}
 }
 
 func (s Structset) buildAssoc(field string, mut *Mutation) {
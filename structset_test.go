@@ -53,6 +53,30 @@ func TestStructset(t *testing.T) {
 	assert.Equal(t, mutation, Apply(doc, NewStructset(&user, false)))
 }
 
+func TestStructset_embedded(t *testing.T) {
+	var (
+		comment = Comment{
+			Body:  "body",
+			Audit: &Audit{CreatedBy: "admin"},
+		}
+		doc      = NewDocument(&comment)
+		mutation = Mutation{
+			Cascade: true,
+			Mutates: map[string]Mutate{
+				"body":       Set("body", "body"),
+				"created_by": Set("created_by", "admin"),
+				"updated_by": Set("updated_by", ""),
+				"created_at": Set("created_at", now()),
+				"updated_at": Set("updated_at", now()),
+			},
+		}
+	)
+
+	assert.Equal(t, mutation, Apply(doc, NewStructset(&comment, false)))
+	assert.Equal(t, now(), comment.CreatedAt)
+	assert.Equal(t, now(), comment.UpdatedAt)
+}
+
 func TestStructset_skipZeroPrimaryKey(t *testing.T) {
 	var (
 		user = User{
@@ -61,6 +61,11 @@ func (t *Table) Text(name string, options ...ColumnOption) {
 	t.Column(name, Text, options...)
 }
 
+// Binary defines a column with name and Binary type.
+func (t *Table) Binary(name string, options ...ColumnOption) {
+	t.Column(name, Binary, options...)
+}
+
 // Date defines a column with name and Date type.
 func (t *Table) Date(name string, options ...ColumnOption) {
 	t.Column(name, Date, options...)
@@ -73,6 +73,14 @@ func TestTable(t *testing.T) {
 		}, table.Definitions[len(table.Definitions)-1])
 	})
 
+	t.Run("Binary", func(t *testing.T) {
+		table.Binary("binary")
+		assert.Equal(t, Column{
+			Name: "binary",
+			Type: Binary,
+		}, table.Definitions[len(table.Definitions)-1])
+	})
+
 	t.Run("Date", func(t *testing.T) {
 		table.Date("date")
 		assert.Equal(t, Column{
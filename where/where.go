@@ -70,6 +70,12 @@ var (
 	// NotLike compares value of field to not match string pattern.
 	NotLike = rel.NotLike
 
+	// Exists check whether subquery returns any rows.
+	Exists = rel.Exists
+
+	// NotExists check whether subquery returns no rows.
+	NotExists = rel.NotExists
+
 	// Fragment add custom filter.
 	Fragment = rel.FilterFragment
 )
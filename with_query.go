@@ -0,0 +1,31 @@
+package rel
+
+// WithQuery defines common table expression (WITH clause) of the query.
+type WithQuery struct {
+	Name      string
+	Query     Query
+	Recursive bool
+}
+
+// Build query.
+func (wq WithQuery) Build(query *Query) {
+	query.WithQuery = append(query.WithQuery, wq)
+}
+
+// NewWith defines a common table expression with given name and query.
+func NewWith(name string, query Query) WithQuery {
+	return WithQuery{
+		Name:  name,
+		Query: query,
+	}
+}
+
+// NewWithRecursive defines a recursive common table expression with given name and query.
+// The query is expected to reference itself by name, usually combined using UnionAll.
+func NewWithRecursive(name string, query Query) WithQuery {
+	return WithQuery{
+		Name:      name,
+		Query:     query,
+		Recursive: true,
+	}
+}
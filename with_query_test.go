@@ -0,0 +1,32 @@
+package rel_test
+
+import (
+	"testing"
+
+	"github.com/Fs02/rel"
+	"github.com/Fs02/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWith(t *testing.T) {
+	var (
+		query = rel.From("transactions").Where(where.Eq("status", "paid"))
+	)
+
+	assert.Equal(t, rel.WithQuery{
+		Name:  "paid_transactions",
+		Query: query,
+	}, rel.NewWith("paid_transactions", query))
+}
+
+func TestWithRecursive(t *testing.T) {
+	var (
+		query = rel.From("categories").Where(where.Nil("parent_id")).UnionAll(rel.From("categories").JoinOn("tree", "tree.id", "categories.parent_id"))
+	)
+
+	assert.Equal(t, rel.WithQuery{
+		Name:      "tree",
+		Query:     query,
+		Recursive: true,
+	}, rel.NewWithRecursive("tree", query))
+}